@@ -39,7 +39,9 @@ const (
 )
 
 type appManifestTemplate struct {
-	BaseURL string
+	BaseURL    string
+	SocketMode bool
+	OrgDeploy  bool
 }
 
 type appsManifestCreateRequest struct {
@@ -122,6 +124,18 @@ func exec() error {
 		Help: "the base URL to receive Slack events and interactions",
 	})
 
+	socketMode := parser.Flag("", "socket-mode", &argparse.Options{
+		Required: false,
+		Default:  false,
+		Help:     "receive Slack events and interactions over Socket Mode instead of the base URL",
+	})
+
+	orgDeploy := parser.Flag("", "org-deploy", &argparse.Options{
+		Required: false,
+		Default:  false,
+		Help:     "allow the app to be installed org-wide in an Enterprise Grid org",
+	})
+
 	outputPath := parser.File("o", "output", os.O_RDWR|os.O_CREATE, 0644, &argparse.Options{
 		Required: false,
 		Default:  "config.json",
@@ -135,7 +149,9 @@ func exec() error {
 
 	// Template the App Manifest
 	templateParams := appManifestTemplate{
-		BaseURL: *baseURL,
+		BaseURL:    *baseURL,
+		SocketMode: *socketMode,
+		OrgDeploy:  *orgDeploy,
 	}
 
 	b := new(bytes.Buffer)
@@ -231,6 +247,16 @@ func exec() error {
 	v.Set("server.secret_key", secretKey)
 	v.Set("database.encryption.key", encryptionKey)
 
+	if *socketMode {
+		v.Set("bot.socket_mode", true)
+		v.Set("bot.app_token", "xapp-app-level-token-here")
+	}
+
+	// Org-wide installations are installed with the OAuth install flow
+	if *orgDeploy {
+		v.Set("bot.distributed", true)
+	}
+
 	if err := v.WriteConfigAs(outputPath.Name()); err != nil {
 		logger.Error("failed to write...", "error", err)
 		return err
@@ -243,5 +269,9 @@ func exec() error {
 	// Print message to help user complete setup
 	logger.Info(fmt.Sprintf("browse to https://api.slack.com/apps/%s to complete setup of the Slack app", createResponse.AppID))
 
+	if *socketMode {
+		logger.Info("generate an app-level token with the connections:write scope under Basic Information and set it as bot.app_token in the config file")
+	}
+
 	return nil
 }
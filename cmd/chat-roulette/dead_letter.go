@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/akamensky/argparse"
+	"github.com/hashicorp/go-hclog"
+	"github.com/segmentio/ksuid"
+
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
+	"github.com/chat-roulettte/chat-roulette/internal/config"
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+)
+
+// runDeadLetter runs the "dead-letter" subcommand for
+// managing jobs that ended FAILED or CANCELED.
+func runDeadLetter(args []string) error {
+	parser := argparse.NewParser("chat-roulette dead-letter", "Manage jobs that ended FAILED or CANCELED")
+
+	configFile := parser.String("c", "config", &argparse.Options{
+		Required: false,
+		Help:     "the path to the config file",
+	})
+
+	// list
+	listCmd := parser.NewCommand("list", "list jobs in the dead-letter queue")
+
+	listChannelID := listCmd.String("", "channel", &argparse.Options{
+		Required: false,
+		Help:     "only list jobs for this Slack channel ID",
+	})
+
+	listJobType := listCmd.String("", "job-type", &argparse.Options{
+		Required: false,
+		Help:     "only list jobs of this type, eg: CREATE_ROUND",
+	})
+
+	listSince := listCmd.String("", "since", &argparse.Options{
+		Required: false,
+		Help:     "only list jobs that ended at or after this RFC3339 timestamp",
+	})
+
+	listUntil := listCmd.String("", "until", &argparse.Options{
+		Required: false,
+		Help:     "only list jobs that ended before this RFC3339 timestamp",
+	})
+
+	listLimit := listCmd.Int("", "limit", &argparse.Options{
+		Required: false,
+		Default:  bot.DefaultDeadLetterLimit,
+		Help:     "the maximum number of jobs to list",
+	})
+
+	listJSON := listCmd.Flag("", "json", &argparse.Options{
+		Required: false,
+		Default:  false,
+		Help:     "output the jobs with their data and attempt history in JSON format",
+	})
+
+	// show
+	showCmd := parser.NewCommand("show", "show a job in the dead-letter queue with its data and attempt history")
+
+	showJobID := showCmd.String("", "job", &argparse.Options{
+		Required: true,
+		Help:     "the ID of the job",
+	})
+
+	// replay
+	replayCmd := parser.NewCommand("replay", "return a job in the dead-letter queue to the job queue")
+
+	replayJobID := replayCmd.String("", "job", &argparse.Options{
+		Required: true,
+		Help:     "the ID of the job",
+	})
+
+	replayData := replayCmd.String("", "data", &argparse.Options{
+		Required: false,
+		Help:     "a JSON object to replace the params of the job",
+	})
+
+	// discard
+	discardCmd := parser.NewCommand("discard", "permanently delete a job in the dead-letter queue")
+
+	discardJobID := discardCmd.String("", "job", &argparse.Options{
+		Required: true,
+		Help:     "the ID of the job",
+	})
+
+	if err := parser.Parse(args); err != nil {
+		fmt.Fprint(os.Stderr, parser.Usage(err))
+		return err
+	}
+
+	// Only log warnings and errors to stderr, so that stdout is left for the output
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:   "dead-letter",
+		Level:  hclog.Warn,
+		Output: os.Stderr,
+	})
+
+	ctx := hclog.WithContext(context.Background(), logger)
+
+	conf, err := config.LoadConfig(*configFile)
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		return err
+	}
+
+	db, err := database.CreateGormDB(logger, conf)
+	if err != nil {
+		logger.Error("failed to create gorm.DB", "error", err)
+		return err
+	}
+
+	switch {
+	case listCmd.Happened():
+		filter := &bot.DeadLetterFilter{
+			ChannelID: *listChannelID,
+			JobType:   *listJobType,
+			Limit:     *listLimit,
+		}
+
+		if *listSince != "" {
+			if filter.Since, err = time.Parse(time.RFC3339, *listSince); err != nil {
+				logger.Error("failed to parse --since", "error", err)
+				return err
+			}
+		}
+
+		if *listUntil != "" {
+			if filter.Until, err = time.Parse(time.RFC3339, *listUntil); err != nil {
+				logger.Error("failed to parse --until", "error", err)
+				return err
+			}
+		}
+
+		jobs, err := bot.ListDeadLetterJobs(ctx, db, filter)
+		if err != nil {
+			return err
+		}
+
+		if *listJSON {
+			return printJSON(jobs)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "JOB ID\tJOB TYPE\tSTATUS\tCHANNEL\tATTEMPTS\tUPDATED AT\tLAST ERROR")
+
+		for _, job := range jobs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+				job.JobID,
+				job.JobType,
+				job.Status,
+				job.ChannelID,
+				job.Attempts,
+				job.UpdatedAt.Format(time.RFC3339),
+				truncate(job.LastError, 80),
+			)
+		}
+
+		return w.Flush()
+
+	case showCmd.Happened():
+		jobID, err := ksuid.Parse(*showJobID)
+		if err != nil {
+			logger.Error("failed to parse job ID", "error", err)
+			return err
+		}
+
+		job, err := bot.GetDeadLetterJob(ctx, db, jobID)
+		if err != nil {
+			return err
+		}
+
+		return printJSON(job)
+
+	case replayCmd.Happened():
+		jobID, err := ksuid.Parse(*replayJobID)
+		if err != nil {
+			logger.Error("failed to parse job ID", "error", err)
+			return err
+		}
+
+		var data json.RawMessage
+		if *replayData != "" {
+			data = json.RawMessage(*replayData)
+		}
+
+		if err := bot.ReplayDeadLetterJob(ctx, db, jobID, data); err != nil {
+			if database.IsUniqueViolation(err) {
+				logger.Error("a pending job with the same idempotency key already exists", "job_id", jobID.String())
+			}
+			return err
+		}
+
+		fmt.Printf("replayed job %s\n", jobID)
+
+	case discardCmd.Happened():
+		jobID, err := ksuid.Parse(*discardJobID)
+		if err != nil {
+			logger.Error("failed to parse job ID", "error", err)
+			return err
+		}
+
+		if err := bot.DiscardDeadLetterJob(ctx, db, jobID); err != nil {
+			return err
+		}
+
+		fmt.Printf("discarded job %s\n", jobID)
+	}
+
+	return nil
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// truncate shortens s to at most n characters on a single line
+func truncate(s string, n int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) <= n {
+		return s
+	}
+
+	return s[:n-3] + "..."
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/chat-roulettte/chat-roulette/internal/server/api/health"
+	"github.com/chat-roulettte/chat-roulette/internal/server/api/metrics"
+)
+
+// healthServer is a HTTP server that only serves the health routes,
+// for a worker-only process that does not run the HTTP server
+type healthServer struct {
+	addr       string
+	httpServer *http.Server
+}
+
+// newHealthServer creates a new healthServer with the given readiness checks.
+// The metrics endpoint is also served if a metrics handler is given.
+func newHealthServer(logger hclog.Logger, addr string, metricsHandler http.Handler, checks ...health.Check) *healthServer {
+	handler := health.NewHandler(checks...)
+	if metricsHandler != nil {
+		r := http.NewServeMux()
+		r.Handle(metrics.Path, metricsHandler)
+		r.Handle("/", handler)
+		handler = r
+	}
+
+	return &healthServer{
+		addr: addr,
+		httpServer: &http.Server{
+			Addr:         addr,
+			WriteTimeout: time.Second * 5,
+			ReadTimeout:  time.Second * 5,
+			Handler:      handler,
+			BaseContext: func(net.Listener) context.Context {
+				return hclog.WithContext(context.Background(), logger)
+			},
+		},
+	}
+}
+
+// Start runs the health server
+func (s *healthServer) Start(ctx context.Context, ch chan error) {
+	logger := hclog.FromContext(ctx)
+
+	logger.Info("starting the health server", "addr", s.addr)
+	ch <- s.httpServer.ListenAndServe()
+}
+
+// Stop gracefully stops the health server
+func (s *healthServer) Stop(ctx context.Context, wg *sync.WaitGroup, stop chan os.Signal, ch chan error) {
+	defer wg.Done()
+
+	logger := hclog.FromContext(ctx)
+
+	select {
+	case <-stop:
+		logger.Info("received signal, gracefully shutting down health server")
+
+	case err := <-ch:
+		logger.Error("failed to start the health server", "error", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		logger.Error("failed to shutdown health server", "error", err)
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
@@ -13,12 +15,13 @@ import (
 	"github.com/bincyber/go-sqlcrypter/providers/aesgcm"
 	"go.opentelemetry.io/otel"
 
-	"github.com/chat-roulettte/chat-roulette/internal/bot"
 	"github.com/chat-roulettte/chat-roulette/internal/config"
 	"github.com/chat-roulettte/chat-roulette/internal/database"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y"
 	"github.com/chat-roulettte/chat-roulette/internal/server"
 	"github.com/chat-roulettte/chat-roulette/internal/server/api/health"
+	"github.com/chat-roulettte/chat-roulette/internal/server/api/metrics"
+	"github.com/chat-roulettte/chat-roulette/internal/server/api/oauth"
 	"github.com/chat-roulettte/chat-roulette/internal/server/api/oidc"
 	apiv1 "github.com/chat-roulettte/chat-roulette/internal/server/api/v1"
 	"github.com/chat-roulettte/chat-roulette/internal/server/ui"
@@ -32,6 +35,11 @@ func main() {
 }
 
 func run() error {
+	// The dead-letter subcommand manages jobs that ended FAILED or CANCELED
+	if len(os.Args) > 1 && os.Args[1] == "dead-letter" {
+		return runDeadLetter(os.Args[1:])
+	}
+
 	parser := argparse.NewParser("chat-roulette", "Chat Roulette for Slack")
 
 	// Define flags
@@ -60,6 +68,13 @@ func run() error {
 		Help:     "enable logging in JSON format",
 	})
 
+	mode := parser.Selector("", "mode",
+		[]string{string(config.ModeAll), string(config.ModeServer), string(config.ModeWorker)},
+		&argparse.Options{
+			Required: false,
+			Help:     "run the HTTP server, the worker, or both. Defaults to the mode in the config",
+		})
+
 	if err := parser.Parse(os.Args); err != nil {
 		log.Fatalf("failed to evaluate command-line flags: %s", err)
 	}
@@ -72,12 +87,17 @@ func run() error {
 		logger.Info(fmt.Sprintf("loading configuration from %s", *configFile))
 	}
 
-	conf, err := config.LoadConfig(*configFile)
+	var overrides []config.Override
+	if *mode != "" {
+		overrides = append(overrides, config.WithMode(config.Mode(*mode)))
+	}
+
+	conf, err := config.LoadConfig(*configFile, overrides...)
 	if err != nil {
 		logger.Error("failed to load config", "error", err)
 		return err
 	}
-	logger.Debug("successfully loaded configuration")
+	logger.Debug("successfully loaded configuration", "mode", conf.Mode)
 
 	// Optionally perform database migration
 	if *migrateDatabase {
@@ -91,6 +111,8 @@ func run() error {
 
 	// Create OpenTelemetry tracer
 	if conf.Tracing.Enabled {
+		logger.Warn("the tracing config is deprecated, use telemetry.traces instead")
+
 		tp, err := o11y.NewTracerProvider(&conf.Tracing)
 		if err != nil {
 			logger.Error("failed to configure tracing")
@@ -100,6 +122,47 @@ func run() error {
 		defer o11y.ShutdownTracer(ctx, logger, tp)
 	}
 
+	if conf.Telemetry.TracesEnabled() {
+		tp, err := o11y.NewOTLPTracerProvider(&conf.Telemetry)
+		if err != nil {
+			logger.Error("failed to configure tracing", "error", err)
+			return err
+		}
+
+		defer o11y.ShutdownTracer(ctx, logger, tp)
+	}
+
+	// Create OpenTelemetry meter for Prometheus and OTLP metrics
+	var metricsHandler http.Handler
+
+	if conf.Metrics.Enabled || conf.Telemetry.MetricsEnabled() {
+		mp, handler, err := o11y.NewMeterProvider(conf)
+		if err != nil {
+			logger.Error("failed to configure metrics", "error", err)
+			return err
+		}
+
+		defer o11y.ShutdownMeter(ctx, logger, mp)
+
+		metricsHandler = handler
+	}
+
+	// Export logs with OTLP, correlated with traces
+	if conf.Telemetry.LogsEnabled() {
+		lp, err := o11y.NewLoggerProvider(&conf.Telemetry)
+		if err != nil {
+			logger.Error("failed to configure logging", "error", err)
+			return err
+		}
+
+		if err := o11y.RegisterLogSink(logger, lp); err != nil {
+			logger.Error("failed to configure logging", "error", err)
+			return err
+		}
+
+		defer o11y.ShutdownLogger(ctx, logger, lp)
+	}
+
 	// Start new span
 	tracer := otel.Tracer("")
 	ctx, span := tracer.Start(ctx, "main")
@@ -125,20 +188,6 @@ func run() error {
 
 	sqlcrypter.Init(aesCrypter)
 
-	// Create the Server
-	s, err := server.New(ctx, logger, conf)
-	if err != nil {
-		logger.Error("failed to create the Server", "error", err)
-		return err
-	}
-
-	// Register API routes
-	logger.Info("registering API routes")
-	ui.RegisterRoutes(s)
-	oidc.RegisterRoutes(s)
-	health.RegisterRoutes(s)
-	apiv1.RegisterRoutes(s)
-
 	// Setup signal handlers for graceful shutdown
 	stopCh := make(chan os.Signal, 2)
 	errorCh := make(chan error)
@@ -150,31 +199,95 @@ func run() error {
 	wg := new(sync.WaitGroup)
 
 	// Create the worker
-	w, err := worker.New(ctx, logger, conf, shutdownCh)
-	if err != nil {
-		logger.Error("failed to create worker", "error", err)
-		return err
+	var w *worker.Worker
+	var readinessChecks []health.Check
+
+	if conf.Mode.RunsWorker() {
+		w, err = worker.New(ctx, logger, conf, shutdownCh)
+		if err != nil {
+			logger.Error("failed to create worker", "error", err)
+			return err
+		}
+
+		readinessChecks = append(readinessChecks, w.Ready)
+	}
+
+	// Create the HTTP server. A worker-only process only serves the
+	// health routes and does not need to discover the Slack OIDC provider.
+	var httpServer interface {
+		Start(ctx context.Context, ch chan error)
+		Stop(ctx context.Context, wg *sync.WaitGroup, stop chan os.Signal, ch chan error)
 	}
 
-	// Sync channels during startup
-	if err := bot.QueueSyncChannelsJob(ctx, s.GetDB(), &bot.SyncChannelsParams{
-		BotUserID: s.GetSlackBotUserID(),
-	}); err != nil {
-		logger.Error("failed to queue SYNC_CHANNELS job on startup", "error", err)
+	var apiServer *server.Server
+
+	if conf.Mode.RunsServer() {
+		s, err := server.New(ctx, logger, conf)
+		if err != nil {
+			logger.Error("failed to create the Server", "error", err)
+			return err
+		}
+
+		// Register API routes
+		logger.Info("registering API routes")
+		ui.RegisterRoutes(s)
+		oidc.RegisterRoutes(s)
+		health.RegisterRoutes(s, readinessChecks...)
+		apiv1.RegisterRoutes(s)
+
+		if conf.Bot.Distributed {
+			oauth.RegisterRoutes(s)
+		}
+
+		if metricsHandler != nil {
+			metrics.RegisterRoutes(s, metricsHandler)
+		}
+
+		queueStartupJobs(ctx, logger, s.GetDB(), s.GetSlackTeamID(), s.GetSlackBotUserID())
+
+		apiServer = s
+		httpServer = s
+	} else {
+		teamID, botUserID, err := getSlackBotIdentity(ctx, logger, conf)
+		if err != nil {
+			logger.Error("failed to retrieve the chat-roulette Slack bot", "error", err)
+			return err
+		}
+
+		queueStartupJobs(ctx, logger, w.GetDB(), teamID, botUserID)
+
+		httpServer = newHealthServer(logger, conf.GetWorkerHealthAddr(), metricsHandler, readinessChecks...)
 	}
 
 	// End the span here before starting the HTTP server and worker(s)
 	span.End()
 
-	// Start the Server
-	go s.Start(ctx, errorCh)
+	// Start the HTTP server
+	go httpServer.Start(ctx, errorCh)
+
+	// Receive requests from Slack over Socket Mode
+	socketModeCtx, stopSocketMode := context.WithCancel(ctx)
+	defer stopSocketMode()
+
+	if apiServer != nil && conf.Bot.SocketMode {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			apiServer.RunSocketMode(socketModeCtx) //nolint:errcheck
+		}()
+	}
 
 	// Start the Worker
-	w.Start(ctx, wg)
+	if w != nil {
+		w.Start(ctx, wg)
+	}
 
-	// Stop the Server when a signal is received
+	// Stop the HTTP server when a signal is received
 	wg.Add(1)
-	s.Stop(ctx, wg, stopCh, errorCh)
+	httpServer.Stop(ctx, wg, stopCh, errorCh)
+
+	// Disconnect from Socket Mode
+	stopSocketMode()
 
 	// Close the shutdown channel to stop all goroutines
 	close(shutdownCh)
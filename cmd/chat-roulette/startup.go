@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
+	"github.com/chat-roulettte/chat-roulette/internal/config"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+	"github.com/chat-roulettte/chat-roulette/internal/slackclient"
+)
+
+// queueStartupJobs queues the jobs that sync the Slack channels of every Slack workspace
+// and reconcile the chat-roulette schedules when the process starts. Every process queues
+// them, whether it runs the server or a worker, and their idempotency keys deduplicate them.
+//
+// teamID and botUserID identify the bot token in the config, and are empty if there is none.
+func queueStartupJobs(ctx context.Context, logger hclog.Logger, db *gorm.DB, teamID, botUserID string) {
+	// Rows created before the app supported multiple Slack workspaces
+	// belong to the workspace of the bot token in the config
+	if teamID != "" {
+		if err := bot.BackfillTeamID(ctx, db, teamID); err != nil {
+			logger.Error("failed to backfill the Slack workspace of existing rows", "error", err)
+		}
+
+		// Sync channels during startup
+		if err := bot.QueueSyncChannelsJob(bot.ContextWithTeamID(ctx, teamID), db, &bot.SyncChannelsParams{
+			BotUserID: botUserID,
+		}); err != nil {
+			logger.Error("failed to queue SYNC_CHANNELS job on startup", "error", err)
+		}
+	}
+
+	// Sync channels of the Slack workspaces that the app was installed in with OAuth
+	workspaces, err := bot.ListWorkspaces(ctx, db)
+	if err != nil {
+		logger.Error("failed to retrieve Slack workspaces on startup", "error", err)
+	}
+
+	for _, workspace := range workspaces {
+		if workspace.TeamID == teamID {
+			continue
+		}
+
+		if err := bot.QueueSyncChannelsJob(bot.ContextWithWorkspace(ctx, &workspace), db, &bot.SyncChannelsParams{
+			BotUserID: workspace.BotUserID,
+		}); err != nil {
+			logger.Error("failed to queue SYNC_CHANNELS job on startup", "error", err, attributes.SlackTeamID, workspace.TeamID)
+		}
+	}
+
+	// Reconcile chat-roulette schedules during startup
+	if err := bot.QueueStartupReconcileSchedulesJob(ctx, db); err != nil {
+		logger.Error("failed to queue RECONCILE_SCHEDULES job on startup", "error", err)
+	}
+}
+
+// getSlackBotIdentity retrieves the Slack workspace and the user ID of the chat-roulette
+// Slack bot for the bot token in the config. Both are empty if there is no bot token.
+func getSlackBotIdentity(ctx context.Context, logger hclog.Logger, conf *config.Config) (string, string, error) {
+	if conf.Bot.AuthToken == "" {
+		return "", "", nil
+	}
+
+	slackClient, _ := slackclient.New(logger, conf.Bot.AuthToken)
+
+	slackCtx, cancel := context.WithTimeout(ctx, 3000*time.Millisecond)
+	defer cancel()
+
+	resp, err := slackClient.AuthTestContext(slackCtx)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to retrieve the user ID of the chat-roulette Slack bot")
+	}
+
+	return resp.TeamID, resp.UserID, nil
+}
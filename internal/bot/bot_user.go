@@ -58,6 +58,36 @@ func isUserASlackBot(ctx context.Context, client *slack.Client, userID string) (
 	return resp.IsBot, nil
 }
 
+// getBotTeamID returns the ID of the Slack workspace of the chat-roulette bot, using
+// Slack's auth.test API method if the workspace is not already in the context.
+func getBotTeamID(ctx context.Context, client *slack.Client) (string, error) {
+	if teamID := TeamIDFromContext(ctx); teamID != "" {
+		return teamID, nil
+	}
+
+	resp, err := client.AuthTestContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.TeamID, nil
+}
+
+// isExternalUser checks if a Slack user belongs to a different org than the Slack workspace
+// or Enterprise Grid org of a channel, such as a member of a Slack Connect channel.
+func isExternalUser(user *slack.User, teamID, enterpriseID string) bool {
+	if user.IsStranger {
+		return true
+	}
+
+	// Members of other workspaces in the same Enterprise Grid org are not external
+	if enterpriseID != "" && user.Enterprise.EnterpriseID == enterpriseID {
+		return false
+	}
+
+	return user.TeamID != teamID
+}
+
 // isBotAChannelMember checks if the chat roulette bot is a member of a Slack channel.
 func isBotAChannelMember(ctx context.Context, client *slack.Client, botUserID, channelID string) (bool, error) {
 	slackChannels, err := getChannels(ctx, client, botUserID)
@@ -54,6 +54,28 @@ func Test_IsUserABot(t *testing.T) {
 	})
 }
 
+func Test_isExternalUser(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         *slack.User
+		teamID       string
+		enterpriseID string
+		expected     bool
+	}{
+		{"same workspace", &slack.User{TeamID: "T0123456789"}, "T0123456789", "", false},
+		{"other workspace", &slack.User{TeamID: "T9876543210"}, "T0123456789", "", true},
+		{"stranger", &slack.User{TeamID: "T0123456789", IsStranger: true}, "T0123456789", "", true},
+		{"same org", &slack.User{TeamID: "T9876543210", Enterprise: slack.EnterpriseUser{EnterpriseID: "E0123456789"}}, "T0123456789", "E0123456789", false},
+		{"other org", &slack.User{TeamID: "T9876543210", Enterprise: slack.EnterpriseUser{EnterpriseID: "E9876543210"}}, "T0123456789", "E0123456789", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isExternalUser(tt.user, tt.teamID, tt.enterpriseID))
+		})
+	}
+}
+
 func Test_isBotChannelMember(t *testing.T) {
 	channelID := "C0123456789"
 	botUserID := "U0000000000"
@@ -14,6 +14,10 @@ type chatRouletteChannel struct {
 	// ChannelID is the id of the Slack channel
 	ChannelID string
 
+	// TeamID is the id of the Slack workspace of the channel. It is only
+	// set for the channels of an org-wide installation in an Enterprise Grid org.
+	TeamID string
+
 	// Inviter is the user who invited the chat-roulette bot
 	// to the Slack channel or the creator of the Slack channel
 	Inviter string
@@ -45,37 +49,94 @@ type chatRouletteMember struct {
 // getChannels uses Slack's users.conversations API method to retrieve all
 // of the public and private channels that the chat-roulette bot is a member of.
 //
+// For an org-wide installation in an Enterprise Grid org, the channels are retrieved
+// from every workspace in the org that the app has been granted access to. Channels
+// that are shared between workspaces are only returned once.
+//
 // See: https://api.slack.com/methods/users.conversations
 func getChannels(ctx context.Context, client *slack.Client, botUserID string) ([]chatRouletteChannel, error) {
-	params := &slack.GetConversationsForUserParameters{
-		UserID:          botUserID,
-		Types:           []string{"public_channel", "private_channel"},
-		Limit:           100,
-		ExcludeArchived: true,
-	}
+	// An empty team ID retrieves the channels of the workspace of the bot token
+	teamIDs := []string{""}
 
-	// Set deadline for Slack API call
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
-	defer cancel()
-
-	// Skip pagination, assume bot is not a member of more than 100 channels
-	resp, _, err := client.GetConversationsForUserContext(ctx, params)
-	if err != nil {
-		return nil, err
+	if EnterpriseIDFromContext(ctx) != "" && TeamIDFromContext(ctx) == "" {
+		var err error
+		if teamIDs, err = getTeams(ctx, client); err != nil {
+			return nil, err
+		}
 	}
 
+	seen := make(map[string]bool)
+
 	var slackChannels []chatRouletteChannel
-	for _, i := range resp {
-		channel := chatRouletteChannel{
-			ChannelID: i.ID,
-			Inviter:   i.Creator,
+	for _, teamID := range teamIDs {
+		params := &slack.GetConversationsForUserParameters{
+			UserID:          botUserID,
+			Types:           []string{"public_channel", "private_channel"},
+			Limit:           100,
+			ExcludeArchived: true,
+			TeamID:          teamID,
+		}
+
+		// Set deadline for Slack API call
+		ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		defer cancel()
+
+		// Skip pagination, assume bot is not a member of more than 100 channels
+		resp, _, err := client.GetConversationsForUserContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range resp {
+			if seen[i.ID] {
+				continue
+			}
+			seen[i.ID] = true
+
+			channel := chatRouletteChannel{
+				ChannelID: i.ID,
+				TeamID:    teamID,
+				Inviter:   i.Creator,
+			}
+			slackChannels = append(slackChannels, channel)
 		}
-		slackChannels = append(slackChannels, channel)
 	}
 
 	return slackChannels, nil
 }
 
+// getTeams uses Slack's auth.teams.list API method to retrieve the workspaces
+// in an Enterprise Grid org that an org-wide app has been granted access to.
+//
+// See: https://api.slack.com/methods/auth.teams.list
+func getTeams(ctx context.Context, client *slack.Client) ([]string, error) {
+	var params slack.ListTeamsParameters
+
+	var teamIDs []string
+	for {
+		// Set deadline for Slack API call
+		ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		defer cancel()
+
+		teams, cursor, err := client.ListTeamsContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range teams {
+			teamIDs = append(teamIDs, i.ID)
+		}
+
+		if cursor != "" {
+			params.Cursor = cursor
+		} else {
+			break
+		}
+	}
+
+	return teamIDs, nil
+}
+
 // getChannelMembers uses Slack's conversation.members API method to retrieve
 // the members of the given Slack channel using cursor-based pagination.
 //
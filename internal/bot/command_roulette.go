@@ -0,0 +1,642 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/hashicorp/go-hclog"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
+	"github.com/chat-roulettte/chat-roulette/internal/isx"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+	"github.com/chat-roulettte/chat-roulette/internal/templatex"
+)
+
+const (
+	// RouletteCommand is the slash command for members and admins of chat-roulette channels
+	RouletteCommand = "/roulette"
+
+	// rouletteHistoryLimit is the number of past matches listed by "/roulette history"
+	rouletteHistoryLimit = 5
+
+	// rouletteDefaultRoundDays is the default length in days of an ad-hoc round started by "/roulette start-round"
+	rouletteDefaultRoundDays = 7
+
+	// skipRoundResumeLead is how long before the start of the following round
+	// a member who skipped a round is resumed
+	skipRoundResumeLead = time.Hour
+)
+
+// rouletteCommandUsageKeys are the keys of the lines of the usage message for
+// the /roulette slash command. An empty key is a blank line.
+var rouletteCommandUsageKeys = []string{
+	"roulette.usage.intro",
+	"roulette.usage.status",
+	"roulette.usage.pause",
+	"roulette.usage.resume",
+	"roulette.usage.skip",
+	"roulette.usage.history",
+	"roulette.usage.block",
+	"",
+	"roulette.usage.admin",
+	"roulette.usage.settings",
+	"roulette.usage.start_round",
+	"roulette.usage.report",
+}
+
+var (
+	errNoRouletteChannel        = errors.New("user is not in any chat-roulette channels")
+	errAmbiguousRouletteChannel = errors.New("user is in multiple chat-roulette channels")
+	errNotInRouletteChannel     = errors.New("user is not in the chat-roulette channel")
+
+	// userMentionRegex matches an escaped Slack user mention, eg: <@U0123456789|bincyber>
+	userMentionRegex = regexp.MustCompile(`^<@([A-Z0-9]+)(?:\|[^>]*)?>$`)
+)
+
+// RouletteCommandParams are the parameters for handling the /roulette slash command.
+type RouletteCommandParams struct {
+	// AppURL is the base URL of the chat-roulette web app
+	AppURL string
+
+	// ChannelID is the ID of the Slack channel the command was used in
+	ChannelID string
+
+	// UserID is the ID of the Slack user who used the command
+	UserID string
+
+	// Text is the text after the command, eg: "block @bincyber"
+	Text string
+}
+
+// memberMatch is a match that a member of a chat-roulette channel was in
+type memberMatch struct {
+	MatchID   int32
+	MpimID    string
+	HasMet    bool
+	HasEnded  bool
+	StartedAt time.Time
+	Partners  pq.StringArray
+}
+
+// HandleRouletteCommand handles the /roulette slash command and returns the
+// text of the ephemeral message to respond to the user with.
+//
+// Changes are made by queueing the same background jobs that are
+// used by the App Home, onboarding, and web app.
+func HandleRouletteCommand(ctx context.Context, db *gorm.DB, p *RouletteCommandParams) (string, error) {
+	lang, err := memberLanguage(ctx, db, p.ChannelID, p.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	args := strings.Fields(p.Text)
+	if len(args) == 0 {
+		return rouletteCommandUsage(lang), nil
+	}
+
+	subcommand := strings.ToLower(args[0])
+	args = args[1:]
+
+	logger := hclog.FromContext(ctx).With(
+		attributes.SlackUserID, p.UserID,
+		"subcommand", subcommand,
+	)
+	ctx = hclog.WithContext(ctx, logger)
+
+	var admin bool
+	switch subcommand {
+	case "status", "pause", "resume", "skip", "history", "block":
+	case "settings", "start-round", "report":
+		admin = true
+	case "help":
+		return rouletteCommandUsage(lang), nil
+	default:
+		return i18n.T(lang, "roulette.unknown", subcommand) + "\n\n" + rouletteCommandUsage(lang), nil
+	}
+
+	channel, err := resolveRouletteChannel(ctx, db, p.ChannelID, p.UserID, admin)
+	switch {
+	case (errors.Is(err, errNoRouletteChannel) || errors.Is(err, errNotInRouletteChannel)) && admin:
+		return i18n.T(lang, "roulette.admin_only", subcommand), nil
+	case errors.Is(err, errNoRouletteChannel):
+		return i18n.T(lang, "roulette.no_channel"), nil
+	case errors.Is(err, errNotInRouletteChannel):
+		return i18n.T(lang, "roulette.not_member"), nil
+	case errors.Is(err, errAmbiguousRouletteChannel):
+		return i18n.T(lang, "roulette.ambiguous_channel", subcommand), nil
+	case err != nil:
+		return "", err
+	}
+
+	logger = logger.With(attributes.SlackChannelID, channel.ChannelID)
+	ctx = hclog.WithContext(ctx, logger)
+
+	switch subcommand {
+	case "status":
+		return rouletteStatus(ctx, db, lang, channel, p.UserID)
+	case "pause":
+		return roulettePause(ctx, db, lang, channel, p.UserID)
+	case "resume":
+		return rouletteResume(ctx, db, lang, channel, p.UserID)
+	case "skip":
+		return rouletteSkip(ctx, db, lang, channel, p.UserID)
+	case "history":
+		return rouletteHistory(ctx, db, lang, channel, p.UserID, p.AppURL)
+	case "block":
+		return rouletteBlock(ctx, db, lang, p.UserID, args)
+	case "settings":
+		return rouletteSettings(lang, channel, p.AppURL), nil
+	case "start-round":
+		return rouletteStartRound(ctx, db, lang, channel, args)
+	default:
+		return rouletteReport(ctx, db, lang, channel)
+	}
+}
+
+// resolveRouletteChannel returns the chat-roulette channel that a slash command applies to.
+// This is the channel the command was used in, or the only chat-roulette channel the user
+// is a member of (or admin of, for admin commands) if it was used outside of any chat-roulette
+// channel, such as in a DM.
+func resolveRouletteChannel(ctx context.Context, db *gorm.DB, channelID, userID string, admin bool) (*models.Channel, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	query := db.WithContext(dbCtx).Model(&models.Channel{})
+
+	if admin {
+		query = query.Where("inviter = ?", userID)
+	} else {
+		query = query.Where("channel_id IN (?)", db.Model(&models.Member{}).Select("channel_id").Where("user_id = ?", userID))
+	}
+
+	var channels []models.Channel
+	if err := query.Find(&channels).Error; err != nil {
+		message := "failed to retrieve chat-roulette channels for the user"
+		hclog.FromContext(ctx).Error(message, "error", err)
+		return nil, errors.Wrap(err, message)
+	}
+
+	for i := range channels {
+		if channels[i].ChannelID == channelID {
+			return &channels[i], nil
+		}
+	}
+
+	// A command used in another chat-roulette channel must not act on a different channel
+	var count int64
+	if err := db.WithContext(dbCtx).Model(&models.Channel{}).Where("channel_id = ?", channelID).Count(&count).Error; err != nil {
+		message := "failed to check if the Slack channel is a chat-roulette channel"
+		hclog.FromContext(ctx).Error(message, "error", err)
+		return nil, errors.Wrap(err, message)
+	}
+
+	if count > 0 {
+		return nil, errNotInRouletteChannel
+	}
+
+	switch len(channels) {
+	case 0:
+		return nil, errNoRouletteChannel
+	case 1:
+		return &channels[0], nil
+	default:
+		return nil, errAmbiguousRouletteChannel
+	}
+}
+
+// rouletteStatus handles "/roulette status"
+func rouletteStatus(ctx context.Context, db *gorm.DB, lang i18n.Language, channel *models.Channel, userID string) (string, error) {
+	logger := hclog.FromContext(ctx)
+
+	member, err := models.GetMemberByUserID(ctx, db, channel.ChannelID, userID)
+	if err != nil {
+		logger.Error("failed to retrieve member", "error", err)
+		return "", err
+	}
+
+	matches, err := getMemberMatches(ctx, db, channel.ChannelID, userID, 1)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	if len(matches) > 0 && !matches[0].HasEnded {
+		b.WriteString(i18n.T(lang, "roulette.status.matched", mentionUsers(lang, matches[0].Partners), channel.ChannelID))
+
+		if matches[0].HasMet {
+			b.WriteString(" " + i18n.T(lang, "roulette.status.met"))
+		} else {
+			b.WriteString(" " + i18n.T(lang, "roulette.status.not_met"))
+		}
+	} else {
+		b.WriteString(i18n.T(lang, "roulette.status.unmatched", channel.ChannelID))
+	}
+
+	if member.IsActive != nil && *member.IsActive {
+		b.WriteString("\n\n" + i18n.T(lang, "roulette.status.next_round", i18n.FormatLongDate(lang, channel.NextRound)))
+	} else {
+		b.WriteString("\n\n" + i18n.T(lang, "roulette.status.paused"))
+	}
+
+	return b.String(), nil
+}
+
+// roulettePause handles "/roulette pause"
+func roulettePause(ctx context.Context, db *gorm.DB, lang i18n.Language, channel *models.Channel, userID string) (string, error) {
+	if err := setRouletteParticipation(ctx, db, channel.ChannelID, userID, false); err != nil {
+		return "", err
+	}
+
+	return i18n.T(lang, "roulette.pause", channel.ChannelID), nil
+}
+
+// rouletteResume handles "/roulette resume"
+func rouletteResume(ctx context.Context, db *gorm.DB, lang i18n.Language, channel *models.Channel, userID string) (string, error) {
+	if err := setRouletteParticipation(ctx, db, channel.ChannelID, userID, true); err != nil {
+		return "", err
+	}
+
+	return i18n.T(lang, "roulette.resume", channel.ChannelID, i18n.FormatLongDate(lang, channel.NextRound)), nil
+}
+
+// rouletteSkip handles "/roulette skip"
+func rouletteSkip(ctx context.Context, db *gorm.DB, lang i18n.Language, channel *models.Channel, userID string) (string, error) {
+	resumeAt, err := skipRound(ctx, db, channel, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return i18n.T(lang, "roulette.skip",
+		channel.ChannelID, i18n.FormatLongDate(lang, channel.NextRound), i18n.FormatLongDate(lang, resumeAt)), nil
+}
+
+// skipRound pauses a member now and queues an UPDATE_MEMBER job to resume them before
+// the round after the next one begins. The start of that round is returned.
+//
+// Both jobs are queued in a transaction, so that the App Home refreshed after
+// the member is paused always shows that the member is skipping the round.
+func skipRound(ctx context.Context, db *gorm.DB, channel *models.Channel, userID string) (time.Time, error) {
+	logger := hclog.FromContext(ctx)
+
+	resumeAt := NextChatRouletteRound(channel.NextRound, channel.Interval)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := setRouletteParticipation(ctx, tx, channel.ChannelID, userID, false); err != nil {
+			return err
+		}
+
+		isActive := true
+
+		job := models.GenericJob[*UpdateMemberParams]{
+			JobType:  models.JobTypeUpdateMember,
+			Priority: models.JobPriorityHigh,
+			Params: &UpdateMemberParams{
+				ChannelID: channel.ChannelID,
+				UserID:    userID,
+				IsActive:  &isActive,
+			},
+			ExecAt:         resumeAt.Add(-skipRoundResumeLead),
+			IdempotencyKey: skipRoundIdempotencyKey(channel.ChannelID, userID),
+		}
+
+		if err := QueueJob(ctx, tx, job); err != nil {
+			message := "failed to add UPDATE_MEMBER job to the queue"
+			logger.Error(message, "error", err)
+			return errors.Wrap(err, message)
+		}
+
+		return nil
+	})
+
+	return resumeAt, err
+}
+
+// rouletteHistory handles "/roulette history"
+func rouletteHistory(ctx context.Context, db *gorm.DB, lang i18n.Language, channel *models.Channel, userID, appURL string) (string, error) {
+	matches, err := getMemberMatches(ctx, db, channel.ChannelID, userID, rouletteHistoryLimit)
+	if err != nil {
+		return "", err
+	}
+
+	if len(matches) == 0 {
+		return i18n.T(lang, "roulette.history.none", channel.ChannelID), nil
+	}
+
+	var b strings.Builder
+
+	b.WriteString(i18n.T(lang, "roulette.history.title", channel.ChannelID))
+
+	for _, m := range matches {
+		status := i18n.T(lang, "roulette.history.not_met")
+		switch {
+		case m.HasMet:
+			status = i18n.T(lang, "roulette.history.met")
+		case !m.HasEnded:
+			status = i18n.T(lang, "roulette.history.in_progress")
+		}
+
+		fmt.Fprintf(&b, "\n• *%s*: %s (%s)", i18n.FormatDate(lang, m.StartedAt), mentionUsers(lang, m.Partners), status)
+	}
+
+	b.WriteString("\n\n" + i18n.T(lang, "roulette.history.full", appURL, channel.ChannelID))
+
+	return b.String(), nil
+}
+
+// rouletteBlock handles "/roulette block @user"
+func rouletteBlock(ctx context.Context, db *gorm.DB, lang i18n.Language, userID string, args []string) (string, error) {
+	logger := hclog.FromContext(ctx)
+
+	if len(args) != 1 {
+		return i18n.T(lang, "roulette.block.usage"), nil
+	}
+
+	memberID, ok := parseUserMention(args[0])
+	if !ok {
+		return i18n.T(lang, "roulette.block.invalid", args[0]), nil
+	}
+
+	p := &BlockMemberParams{
+		UserID:   userID,
+		MemberID: memberID,
+	}
+
+	if err := p.Validate(); err != nil {
+		return i18n.T(lang, "roulette.block.self"), nil
+	}
+
+	if err := QueueBlockMemberJob(ctx, db, p); err != nil {
+		message := "failed to add BLOCK_MEMBER job to the queue"
+		logger.Error(message, "error", err)
+		return "", errors.Wrap(err, message)
+	}
+
+	return i18n.T(lang, "roulette.block.done", memberID), nil
+}
+
+// rouletteSettings handles "/roulette settings"
+func rouletteSettings(lang i18n.Language, channel *models.Channel, appURL string) string {
+	lines := []string{
+		i18n.T(lang, "roulette.settings.title", channel.ChannelID),
+		i18n.T(lang, "roulette.settings.schedule", formatSchedule(lang, channel.Interval, channel.NextRound), i18n.FormatHour(lang, channel.Hour)),
+		i18n.T(lang, "roulette.settings.connection_mode", i18n.T(lang, "connection_mode."+channel.ConnectionMode.String())),
+		i18n.T(lang, "roulette.settings.language", i18n.Resolve(channel.Language).Name()),
+		i18n.T(lang, "roulette.settings.next_round", i18n.FormatLongDate(lang, channel.NextRound)),
+		"",
+		i18n.T(lang, "roulette.settings.change", appURL, channel.ChannelID),
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// rouletteStartRound handles "/roulette start-round [days]" by
+// queueing a CREATE_ROUND job for an ad-hoc round.
+func rouletteStartRound(ctx context.Context, db *gorm.DB, lang i18n.Language, channel *models.Channel, args []string) (string, error) {
+	logger := hclog.FromContext(ctx)
+
+	days := rouletteDefaultRoundDays
+	if len(args) > 0 {
+		v, err := strconv.Atoi(args[0])
+		if err != nil {
+			return i18n.T(lang, "roulette.start_round.invalid", args[0]), nil
+		}
+		days = v
+	}
+
+	endsAt := time.Now().UTC().AddDate(0, 0, days)
+
+	if err := validation.Validate(endsAt, validation.By(isx.AdHocRoundEndDate)); err != nil {
+		return i18n.T(lang, "roulette.start_round.duration"), nil
+	}
+
+	p := &CreateRoundParams{
+		ChannelID: channel.ChannelID,
+		NextRound: time.Now().UTC(),
+		Interval:  channel.Interval.String(),
+		AdHoc: &AdHocRoundParams{
+			EndsAt: endsAt,
+		},
+	}
+
+	if err := QueueCreateRoundJob(ctx, db, p); err != nil {
+		message := "failed to add CREATE_ROUND job to the queue"
+		logger.Error(message, "error", err)
+		return "", errors.Wrap(err, message)
+	}
+
+	return i18n.T(lang, "roulette.start_round.started", channel.ChannelID, i18n.FormatLongDate(lang, endsAt)), nil
+}
+
+// rouletteReport handles "/roulette report"
+func rouletteReport(ctx context.Context, db *gorm.DB, lang i18n.Language, channel *models.Channel) (string, error) {
+	logger := hclog.FromContext(ctx)
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var round models.Round
+	result := db.WithContext(dbCtx).
+		Where("channel_id = ?", channel.ChannelID).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&round)
+
+	if result.Error != nil {
+		message := "failed to retrieve latest chat-roulette round"
+		logger.Error(message, "error", result.Error)
+		return "", errors.Wrap(result.Error, message)
+	}
+
+	if result.RowsAffected == 0 {
+		return i18n.T(lang, "roulette.report.none", channel.ChannelID), nil
+	}
+
+	var stats roundStats
+
+	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result = db.WithContext(dbCtx).
+		Model(&models.Match{}).
+		Select(`
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE has_met) AS met
+		`).
+		Where("round_id = ?", round.ID).
+		Find(&stats)
+
+	if result.Error != nil {
+		message := "failed to retrieve match results"
+		logger.Error(message, "error", result.Error)
+		return "", errors.Wrap(result.Error, message)
+	}
+
+	state := "roulette.report.in_progress"
+	if round.HasEnded {
+		state = "roulette.report.ended"
+	}
+
+	var percent float64
+	if stats.Total > 0 {
+		percent = (float64(stats.Met) / float64(stats.Total)) * 100
+	}
+
+	lines := []string{
+		i18n.T(lang, state, channel.ChannelID, i18n.FormatLongDate(lang, round.CreatedAt)),
+		i18n.T(lang, "roulette.report.intros", stats.Total),
+		i18n.T(lang, "roulette.report.met", stats.Met, templatex.PrettyPercent(percent)),
+		i18n.T(lang, "roulette.report.inactive", round.InactiveParticipants),
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// setRouletteParticipation queues an UPDATE_MEMBER job to pause or resume a member.
+// Any pending UPDATE_MEMBER job queued by "/roulette skip" is canceled, so that
+// it does not override this change.
+func setRouletteParticipation(ctx context.Context, db *gorm.DB, channelID, userID string, isActive bool) error {
+	logger := hclog.FromContext(ctx)
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Model(&models.Job{}).
+		Where("idempotency_key = ?", skipRoundIdempotencyKey(channelID, userID)).
+		Where("is_completed = false").
+		Updates(&models.Job{IsCompleted: true, Status: models.JobStatusCanceled})
+
+	if result.Error != nil {
+		message := "failed to cancel pending UPDATE_MEMBER job for skipped round"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	p := &UpdateMemberParams{
+		ChannelID: channelID,
+		UserID:    userID,
+		IsActive:  &isActive,
+	}
+
+	if err := QueueUpdateMemberJob(ctx, db, p); err != nil {
+		message := "failed to add UPDATE_MEMBER job to the queue"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	return nil
+}
+
+// skipRoundIdempotencyKey returns the idempotency key of the
+// UPDATE_MEMBER job that resumes a member after a skipped round
+func skipRoundIdempotencyKey(channelID, userID string) string {
+	return models.IdempotencyKey(models.JobTypeUpdateMember, channelID, userID, "skip")
+}
+
+// getSkippedRoundResumeDate returns the start of the round that a member who skipped
+// the next round will be matched in again, or the zero time if the member has not skipped a round.
+func getSkippedRoundResumeDate(ctx context.Context, db *gorm.DB, channelID, userID string) (time.Time, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var jobs []models.Job
+
+	result := db.WithContext(dbCtx).
+		Select("exec_at").
+		Where("idempotency_key = ?", skipRoundIdempotencyKey(channelID, userID)).
+		Where("is_completed = false").
+		Limit(1).
+		Find(&jobs)
+
+	if result.Error != nil {
+		message := "failed to retrieve pending UPDATE_MEMBER job for skipped round"
+		hclog.FromContext(ctx).Error(message, "error", result.Error)
+		return time.Time{}, errors.Wrap(result.Error, message)
+	}
+
+	if len(jobs) == 0 {
+		return time.Time{}, nil
+	}
+
+	return jobs[0].ExecAt.Add(skipRoundResumeLead), nil
+}
+
+// getMemberMatches retrieves the most recent matches for a member of a chat-roulette channel
+func getMemberMatches(ctx context.Context, db *gorm.DB, channelID, userID string, limit int) ([]memberMatch, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var matches []memberMatch
+
+	result := db.WithContext(dbCtx).
+		Table("matches").
+		Select(`
+			matches.id AS match_id,
+			matches.mpim_id,
+			matches.has_met,
+			rounds.has_ended,
+			rounds.created_at AS started_at,
+			array_agg(partners.user_id) AS partners
+		`).
+		Joins("JOIN rounds ON rounds.id = matches.round_id").
+		Joins("JOIN pairings ON pairings.match_id = matches.id").
+		Joins("JOIN members ON members.id = pairings.member_id").
+		Joins("JOIN pairings partner_pairings ON partner_pairings.match_id = matches.id AND partner_pairings.member_id <> pairings.member_id").
+		Joins("JOIN members partners ON partners.id = partner_pairings.member_id").
+		Where("rounds.channel_id = ?", channelID).
+		Where("members.user_id = ?", userID).
+		Group("matches.id, rounds.id").
+		Order("rounds.created_at DESC").
+		Limit(limit).
+		Scan(&matches)
+
+	if result.Error != nil {
+		message := "failed to retrieve matches for the member"
+		hclog.FromContext(ctx).Error(message, "error", result.Error)
+		return nil, errors.Wrap(result.Error, message)
+	}
+
+	return matches, nil
+}
+
+// parseUserMention extracts the Slack user ID from an escaped user mention
+func parseUserMention(s string) (string, bool) {
+	m := userMentionRegex.FindStringSubmatch(s)
+	if m == nil {
+		return "", false
+	}
+
+	return m[1], true
+}
+
+// mentionUsers formats a list of Slack users as mentions, eg: <@U0123456789> and <@U9876543210>
+func mentionUsers(lang i18n.Language, userIDs []string) string {
+	mentions := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		mentions[i] = fmt.Sprintf("<@%s>", id)
+	}
+
+	return strings.Join(mentions, " "+i18n.T(lang, "common.and")+" ")
+}
+
+// rouletteCommandUsage returns the usage message for the /roulette slash command
+func rouletteCommandUsage(lang i18n.Language) string {
+	lines := make([]string, len(rouletteCommandUsageKeys))
+	for i, key := range rouletteCommandUsageKeys {
+		if key != "" {
+			lines[i] = i18n.T(lang, key)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,244 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bincyber/go-sqlcrypter"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y"
+)
+
+func Test_HandleRouletteCommand(t *testing.T) {
+	r := require.New(t)
+
+	logger, _ := o11y.NewBufferedLogger()
+	ctx := hclog.WithContext(context.Background(), logger)
+
+	resource, databaseURL, err := database.NewTestPostgresDB(false)
+	r.NoError(err)
+	defer resource.Close()
+
+	r.NoError(database.Migrate(databaseURL))
+
+	db, err := database.NewGormDB(databaseURL)
+	r.NoError(err)
+
+	sqlcrypter.Init(database.NoOpCrypter{})
+
+	channelID := "C0123456789"
+	admin := "U9876543210"
+	userID := "U0123456789"
+	partnerID := "U1111111111"
+
+	nextRound := time.Now().UTC().Add(72 * time.Hour)
+
+	db.Create(&models.Channel{
+		ChannelID:      channelID,
+		Inviter:        admin,
+		ConnectionMode: models.ConnectionModeVirtual,
+		Interval:       models.Weekly,
+		Weekday:        nextRound.Weekday(),
+		Hour:           12,
+		NextRound:      nextRound,
+	})
+
+	isActive := true
+	var memberIDs []int32
+	for _, id := range []string{userID, partnerID} {
+		member := &models.Member{
+			ChannelID:           channelID,
+			UserID:              id,
+			IsActive:            &isActive,
+			HasGenderPreference: new(bool),
+		}
+		db.Create(member)
+		memberIDs = append(memberIDs, member.ID)
+	}
+
+	round := &models.Round{ChannelID: channelID}
+	db.Create(round)
+
+	match := &models.Match{RoundID: round.ID}
+	db.Create(match)
+
+	for _, id := range memberIDs {
+		db.Create(&models.Pairing{MatchID: match.ID, MemberID: id})
+	}
+
+	handleIn := func(channelID, userID, text string) string {
+		text, err := HandleRouletteCommand(ctx, db, &RouletteCommandParams{
+			AppURL:    "https://bot.chat-roulette.com",
+			ChannelID: channelID,
+			UserID:    userID,
+			Text:      text,
+		})
+		r.NoError(err)
+		return text
+	}
+
+	handle := func(userID, text string) string {
+		return handleIn(channelID, userID, text)
+	}
+
+	t.Run("status", func(t *testing.T) {
+		text := handle(userID, "status")
+		assert.Contains(t, text, "You've been matched with <@U1111111111>")
+		assert.Contains(t, text, "The next round begins on")
+	})
+
+	t.Run("history", func(t *testing.T) {
+		text := handle(userID, "history")
+		assert.Contains(t, text, "<@U1111111111> (:hourglass_flowing_sand: in progress)")
+		assert.Contains(t, text, "https://bot.chat-roulette.com/history/C0123456789")
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		text := handle(userID, "skip")
+		assert.Contains(t, text, "you'll sit out the round")
+
+		var count int64
+		db.Model(&models.Job{}).
+			Where("idempotency_key = ?", skipRoundIdempotencyKey(channelID, userID)).
+			Where("is_completed = false").
+			Count(&count)
+		assert.Equal(t, int64(1), count)
+
+		// Resuming cancels the pending job for the skipped round
+		handle(userID, "resume")
+
+		db.Model(&models.Job{}).
+			Where("idempotency_key = ?", skipRoundIdempotencyKey(channelID, userID)).
+			Where("is_completed = false").
+			Count(&count)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		text := handle(userID, "block <@U1111111111|partner>")
+		assert.Equal(t, "Done. You won't be matched with <@U1111111111> in Chat Roulette.", text)
+
+		text = handle(userID, "block <@U0123456789|me>")
+		assert.Contains(t, text, "You can't block yourself")
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		text := handle(userID, "settings")
+		assert.Equal(t, "Only the admin of a Chat Roulette channel can use `/roulette settings`.", text)
+
+		text = handle(admin, "settings")
+		assert.Contains(t, text, fmt.Sprintf("*Weekly* on *%ss* at 12 PM", nextRound.Weekday()))
+		assert.Contains(t, text, "https://bot.chat-roulette.com/channel/C0123456789")
+	})
+
+	t.Run("report", func(t *testing.T) {
+		text := handle(admin, "report")
+		assert.Contains(t, text, "Intros made: *1*")
+		assert.Contains(t, text, "Groups met: *0* (0%)")
+	})
+
+	t.Run("start-round", func(t *testing.T) {
+		text := handle(admin, "start-round 1")
+		assert.Contains(t, text, "must run for at least 2 days")
+
+		text = handle(admin, "start-round 3")
+		assert.Contains(t, text, "Starting an ad-hoc round")
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		text := handle("U2222222222", "status")
+		assert.Contains(t, text, "You're not a member of this Chat Roulette channel")
+
+		text = handleIn("D0123456789", "U2222222222", "status")
+		assert.Contains(t, text, "You're not a member of any Chat Roulette channels")
+	})
+
+	t.Run("admin of another channel", func(t *testing.T) {
+		otherAdmin := "U3333333333"
+
+		db.Create(&models.Channel{
+			ChannelID:      "C2222222222",
+			Inviter:        otherAdmin,
+			ConnectionMode: models.ConnectionModeVirtual,
+			Interval:       models.Weekly,
+			Weekday:        nextRound.Weekday(),
+			Hour:           12,
+			NextRound:      nextRound,
+		})
+
+		// Admin commands used in a channel the user is not the admin of
+		// do not fall back to the channel they are the admin of
+		text := handle(otherAdmin, "settings")
+		assert.Equal(t, "Only the admin of a Chat Roulette channel can use `/roulette settings`.", text)
+
+		// Admin commands used outside of any chat-roulette channel do
+		text = handleIn("D0123456789", otherAdmin, "settings")
+		assert.Contains(t, text, "https://bot.chat-roulette.com/channel/C2222222222")
+	})
+}
+
+func Test_HandleRouletteCommand_Usage(t *testing.T) {
+	r := require.New(t)
+
+	db, mock := database.NewMockedGormDB()
+
+	p := &RouletteCommandParams{ChannelID: "C0123456789", UserID: "U0123456789"}
+
+	mockMemberLanguage(mock, p.ChannelID, p.UserID, "")
+
+	text, err := HandleRouletteCommand(context.Background(), db, p)
+	r.NoError(err)
+	r.Equal(rouletteCommandUsage(i18n.English), text)
+
+	mockMemberLanguage(mock, p.ChannelID, p.UserID, "")
+
+	p.Text = "dance"
+	text, err = HandleRouletteCommand(context.Background(), db, p)
+	r.NoError(err)
+	r.Contains(text, "I don't know how to `dance`")
+
+	mockMemberLanguage(mock, p.ChannelID, p.UserID, "fr")
+
+	p.Text = "help"
+	text, err = HandleRouletteCommand(context.Background(), db, p)
+	r.NoError(err)
+	r.Equal(rouletteCommandUsage(i18n.French), text)
+	r.Contains(text, "Voici ce que vous pouvez faire avec `/roulette`")
+
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func Test_parseUserMention(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"<@U0123456789|bincyber>", "U0123456789", true},
+		{"<@U0123456789>", "U0123456789", true},
+		{"@bincyber", "", false},
+		{"<#C0123456789|general>", "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			actual, ok := parseUserMention(tc.value)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_mentionUsers(t *testing.T) {
+	assert.Equal(t, "<@U0123456789>", mentionUsers(i18n.English, []string{"U0123456789"}))
+	assert.Equal(t, "<@U0123456789> and <@U9876543210>", mentionUsers(i18n.English, []string{"U0123456789", "U9876543210"}))
+	assert.Equal(t, "<@U0123456789> et <@U9876543210>", mentionUsers(i18n.French, []string{"U0123456789", "U9876543210"}))
+}
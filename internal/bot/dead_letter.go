@@ -0,0 +1,330 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+	"github.com/segmentio/ksuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+)
+
+const (
+	// DefaultDeadLetterLimit is the default number of dead-lettered jobs to list
+	DefaultDeadLetterLimit = 100
+
+	// MaxDeadLetterLimit is the maximum number of dead-lettered jobs to list
+	MaxDeadLetterLimit = 500
+)
+
+var (
+	// deadLetterStatuses are the statuses of jobs in the dead-letter queue
+	deadLetterStatuses = []string{
+		models.JobStatusFailed.String(),
+		models.JobStatusCanceled.String(),
+	}
+
+	// ErrInvalidJobData is returned when replaying a job with params that are not a JSON object
+	ErrInvalidJobData = errors.New("job data must be a JSON object")
+)
+
+// DeadLetterFilter filters the jobs listed from the dead-letter queue.
+type DeadLetterFilter struct {
+	// ChannelID restricts the jobs to a Slack channel
+	ChannelID string
+
+	// Inviter restricts the jobs to the Slack channels this user is the inviter of
+	Inviter string
+
+	// JobType restricts the jobs to a job type
+	JobType string
+
+	// Since restricts the jobs to those that ended at or after this time
+	Since time.Time
+
+	// Until restricts the jobs to those that ended before this time
+	Until time.Time
+
+	// Limit is the maximum number of jobs to list
+	Limit int
+}
+
+// DeadLetterAttempt is a failed attempt in the history of a dead-lettered job.
+type DeadLetterAttempt struct {
+	Attempt   int       `json:"attempt"`
+	Replay    int       `json:"replay"`
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DeadLetterJob is a job that ended FAILED or CANCELED.
+type DeadLetterJob struct {
+	JobID     string              `json:"job_id"`
+	JobType   string              `json:"job_type"`
+	Status    string              `json:"status"`
+	Priority  int                 `json:"priority"`
+	ChannelID string              `json:"channel_id,omitempty"`
+	Data      json.RawMessage     `json:"data"`
+	Attempts  int                 `json:"attempts"`
+	Replays   int                 `json:"replays"`
+	LastError string              `json:"last_error"`
+	History   []DeadLetterAttempt `json:"history"`
+	ExecAt    time.Time           `json:"exec_at"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// ListDeadLetterJobs lists the jobs in the dead-letter queue, most recent first,
+// along with the history of their failed attempts.
+func ListDeadLetterJobs(ctx context.Context, db *gorm.DB, f *DeadLetterFilter) ([]DeadLetterJob, error) {
+
+	logger := hclog.FromContext(ctx)
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = DefaultDeadLetterLimit
+	}
+
+	if limit > MaxDeadLetterLimit {
+		limit = MaxDeadLetterLimit
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	query := db.WithContext(dbCtx).
+		Model(&models.Job{}).
+		Where("is_completed = true").
+		Where("status IN ?", deadLetterStatuses)
+
+	if f.ChannelID != "" {
+		query = query.Where("data->>'channel_id' = ?", f.ChannelID)
+	}
+
+	if f.Inviter != "" {
+		query = query.Where("data->>'channel_id' IN (?)",
+			db.Model(&models.Channel{}).Select("channel_id").Where("inviter = ?", f.Inviter),
+		)
+	}
+
+	if f.JobType != "" {
+		jobType, err := models.ParseJobType(f.JobType)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid job type")
+		}
+
+		query = query.Where("job_type = ?", jobType.String())
+	}
+
+	if !f.Since.IsZero() {
+		query = query.Where("updated_at >= ?", f.Since.UTC())
+	}
+
+	if !f.Until.IsZero() {
+		query = query.Where("updated_at < ?", f.Until.UTC())
+	}
+
+	var jobs []models.Job
+
+	if err := query.Order("updated_at DESC").Limit(limit).Find(&jobs).Error; err != nil {
+		message := "failed to retrieve dead-lettered jobs"
+		logger.Error(message, "error", err)
+		return nil, errors.Wrap(err, message)
+	}
+
+	return withAttemptHistory(ctx, db, jobs)
+}
+
+// GetDeadLetterJob retrieves a job from the dead-letter queue.
+func GetDeadLetterJob(ctx context.Context, db *gorm.DB, jobID ksuid.KSUID) (*DeadLetterJob, error) {
+
+	logger := hclog.FromContext(ctx).With(attributes.JobID, jobID.String())
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var job models.Job
+
+	result := db.WithContext(dbCtx).
+		Where("job_id = ?", jobID).
+		Where("is_completed = true").
+		Where("status IN ?", deadLetterStatuses).
+		First(&job)
+
+	if result.Error != nil {
+		message := "failed to retrieve dead-lettered job"
+		logger.Error(message, "error", result.Error)
+		return nil, errors.Wrap(result.Error, message)
+	}
+
+	jobs, err := withAttemptHistory(ctx, db, []models.Job{job})
+	if err != nil {
+		return nil, err
+	}
+
+	return &jobs[0], nil
+}
+
+// ReplayDeadLetterJob returns a job in the dead-letter queue to the job queue
+// to be executed again immediately. If data is set, it replaces the params of the job.
+//
+// The job is attempted again as many times as a new job would be. Its attempt
+// history is kept, and the attempts after the replay are recorded with the replay.
+// The links to its parent jobs are cleared, since a job is canceled when one of its
+// parents does not succeed and would otherwise never be executed.
+func ReplayDeadLetterJob(ctx context.Context, db *gorm.DB, jobID ksuid.KSUID, data json.RawMessage) error {
+
+	logger := hclog.FromContext(ctx).With(attributes.JobID, jobID.String())
+
+	updates := map[string]interface{}{
+		"status":        models.JobStatusPending,
+		"is_completed":  false,
+		"attempts":      0,
+		"replays":       gorm.Expr("replays + 1"),
+		"last_error":    "",
+		"next_retry_at": nil,
+		"exec_at":       time.Now().UTC(),
+		"parent_ids":    gorm.Expr("'{}'"),
+	}
+
+	if len(data) > 0 {
+		var params map[string]interface{}
+		if err := json.Unmarshal(data, &params); err != nil || params == nil {
+			return ErrInvalidJobData
+		}
+
+		updates["data"] = datatypes.JSON(data)
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Model(&models.Job{}).
+		Where("job_id = ?", jobID).
+		Where("is_completed = true").
+		Where("status IN ?", deadLetterStatuses).
+		Updates(updates)
+
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = gorm.ErrRecordNotFound
+	}
+
+	if result.Error != nil {
+		message := "failed to replay dead-lettered job"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	logger.Info("replayed dead-lettered job", "edited", len(data) > 0)
+
+	return nil
+}
+
+// DiscardDeadLetterJob permanently deletes a job in the dead-letter queue
+// along with the history of its failed attempts.
+func DiscardDeadLetterJob(ctx context.Context, db *gorm.DB, jobID ksuid.KSUID) error {
+
+	logger := hclog.FromContext(ctx).With(attributes.JobID, jobID.String())
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Where("job_id = ?", jobID).
+		Where("is_completed = true").
+		Where("status IN ?", deadLetterStatuses).
+		Delete(&models.Job{})
+
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = gorm.ErrRecordNotFound
+	}
+
+	if result.Error != nil {
+		message := "failed to discard dead-lettered job"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	logger.Info("discarded dead-lettered job")
+
+	return nil
+}
+
+// withAttemptHistory converts jobs to dead-lettered jobs and retrieves the history of their failed attempts.
+func withAttemptHistory(ctx context.Context, db *gorm.DB, jobs []models.Job) ([]DeadLetterJob, error) {
+
+	logger := hclog.FromContext(ctx)
+
+	deadLetterJobs := make([]DeadLetterJob, 0, len(jobs))
+	if len(jobs) == 0 {
+		return deadLetterJobs, nil
+	}
+
+	ids := make([]int32, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var attempts []models.JobAttempt
+
+	result := db.WithContext(dbCtx).
+		Where("job_id IN ?", ids).
+		Order("created_at").
+		Find(&attempts)
+
+	if result.Error != nil {
+		message := "failed to retrieve the attempt history for jobs"
+		logger.Error(message, "error", result.Error)
+		return nil, errors.Wrap(result.Error, message)
+	}
+
+	history := make(map[int32][]DeadLetterAttempt)
+	for _, a := range attempts {
+		history[a.JobID] = append(history[a.JobID], DeadLetterAttempt{
+			Attempt:   a.Attempt,
+			Replay:    a.Replay,
+			Error:     a.Error,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+
+	for _, job := range jobs {
+		var data struct {
+			ChannelID string `json:"channel_id"`
+		}
+		json.Unmarshal(job.Data, &data) //nolint:errcheck
+
+		h, ok := history[job.ID]
+		if !ok {
+			h = []DeadLetterAttempt{}
+		}
+
+		deadLetterJobs = append(deadLetterJobs, DeadLetterJob{
+			JobID:     job.JobID.String(),
+			JobType:   job.JobType.String(),
+			Status:    job.Status.String(),
+			Priority:  job.Priority,
+			ChannelID: data.ChannelID,
+			Data:      json.RawMessage(job.Data),
+			Attempts:  job.Attempts,
+			Replays:   job.Replays,
+			LastError: job.LastError,
+			History:   h,
+			ExecAt:    job.ExecAt,
+			CreatedAt: job.CreatedAt,
+			UpdatedAt: job.UpdatedAt,
+		})
+	}
+
+	return deadLetterJobs, nil
+}
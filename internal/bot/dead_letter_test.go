@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/go-hclog"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y"
+)
+
+type DeadLetterSuite struct {
+	suite.Suite
+	ctx    context.Context
+	mock   sqlmock.Sqlmock
+	db     *gorm.DB
+	logger hclog.Logger
+	buffer *bytes.Buffer
+}
+
+func (s *DeadLetterSuite) SetupTest() {
+	s.logger, s.buffer = o11y.NewBufferedLogger()
+	s.ctx = hclog.WithContext(context.Background(), s.logger)
+	s.db, s.mock = database.NewMockedGormDB()
+}
+
+func (s *DeadLetterSuite) AfterTest(_, _ string) {
+	require.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *DeadLetterSuite) Test_ListDeadLetterJobs() {
+	r := require.New(s.T())
+
+	jobID := ksuid.New()
+	since := time.Now().UTC().Add(-24 * time.Hour)
+
+	s.mock.ExpectQuery(`SELECT \* FROM "jobs" WHERE is_completed = true AND status IN \(\$1,\$2\) AND data->>'channel_id' = \$3 AND job_type = \$4 AND updated_at >= \$5 ORDER BY updated_at DESC LIMIT \$6`).
+		WithArgs(
+			models.JobStatusFailed.String(),
+			models.JobStatusCanceled.String(),
+			"C0123456789",
+			models.JobTypeCreateRound.String(),
+			since,
+			DefaultDeadLetterLimit,
+		).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "job_id", "job_type", "status", "data", "attempts", "last_error"}).
+				AddRow(1, jobID.String(), models.JobTypeCreateRound.String(), models.JobStatusFailed.String(), []byte(`{"channel_id":"C0123456789"}`), 2, "boom"),
+		)
+
+	s.mock.ExpectQuery(`SELECT \* FROM "job_attempts" WHERE job_id IN \(\$1\) ORDER BY created_at`).
+		WithArgs(1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "job_id", "attempt", "error"}).
+				AddRow(1, 1, 1, "bang").
+				AddRow(2, 1, 2, "boom"),
+		)
+
+	jobs, err := ListDeadLetterJobs(s.ctx, s.db, &DeadLetterFilter{
+		ChannelID: "C0123456789",
+		JobType:   "create_round",
+		Since:     since,
+	})
+	r.NoError(err)
+	r.Len(jobs, 1)
+	r.Equal(jobID.String(), jobs[0].JobID)
+	r.Equal("C0123456789", jobs[0].ChannelID)
+	r.Equal(models.JobStatusFailed.String(), jobs[0].Status)
+	r.JSONEq(`{"channel_id":"C0123456789"}`, string(jobs[0].Data))
+	r.Len(jobs[0].History, 2)
+	r.Equal("boom", jobs[0].History[1].Error)
+}
+
+func (s *DeadLetterSuite) Test_ListDeadLetterJobs_InvalidJobType() {
+	r := require.New(s.T())
+
+	_, err := ListDeadLetterJobs(s.ctx, s.db, &DeadLetterFilter{JobType: "qwerty"})
+	r.Error(err)
+	r.Contains(err.Error(), "invalid job type")
+}
+
+func (s *DeadLetterSuite) Test_ReplayDeadLetterJob() {
+	r := require.New(s.T())
+
+	jobID := ksuid.New()
+	data := []byte(`{"channel_id":"C0123456789","interval":"weekly"}`)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`UPDATE "jobs" SET "attempts"=\$1,"data"=\$2,"exec_at"=\$3,"is_completed"=\$4,"last_error"=\$5,"next_retry_at"=\$6,"parent_ids"='\{\}',"replays"=replays \+ 1,"status"=\$7,"updated_at"=\$8 WHERE job_id = \$9 AND is_completed = true AND status IN \(\$10,\$11\)`).
+		WithArgs(0, string(data), database.AnyTime(), false, "", nil, models.JobStatusPending, database.AnyTime(), jobID, models.JobStatusFailed.String(), models.JobStatusCanceled.String()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectCommit()
+
+	err := ReplayDeadLetterJob(s.ctx, s.db, jobID, data)
+	r.NoError(err)
+	r.Contains(s.buffer.String(), "replayed dead-lettered job")
+}
+
+func (s *DeadLetterSuite) Test_ReplayDeadLetterJob_InvalidData() {
+	r := require.New(s.T())
+
+	err := ReplayDeadLetterJob(s.ctx, s.db, ksuid.New(), []byte(`["C0123456789"]`))
+	r.ErrorIs(err, ErrInvalidJobData)
+}
+
+func (s *DeadLetterSuite) Test_DiscardDeadLetterJob_NotFound() {
+	r := require.New(s.T())
+
+	jobID := ksuid.New()
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`DELETE FROM "jobs" WHERE job_id = \$1 AND is_completed = true AND status IN \(\$2,\$3\)`).
+		WithArgs(jobID, models.JobStatusFailed.String(), models.JobStatusCanceled.String()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectCommit()
+
+	err := DiscardDeadLetterJob(s.ctx, s.db, jobID)
+	r.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func Test_DeadLetter_suite(t *testing.T) {
+	suite.Run(t, new(DeadLetterSuite))
+}
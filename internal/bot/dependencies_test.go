@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+)
+
+func Test_jobDependencies(t *testing.T) {
+	r := require.New(t)
+
+	resource, databaseURL, err := database.NewTestPostgresDB(false)
+	r.NoError(err)
+	defer resource.Close()
+
+	db, err := database.NewGormDB(databaseURL)
+	r.NoError(err)
+	r.NoError(database.Migrate(databaseURL))
+
+	// queueJobs queues a SYNC_MEMBERS job with a CREATE_MATCHES job that depends on it,
+	// and a REPORT_MATCHES job that depends on the CREATE_MATCHES job
+	queueJobs := func(channelID string) (*models.Job, *models.Job, *models.Job) {
+		data := []byte(fmt.Sprintf(`{"channel_id":"%s"}`, channelID))
+
+		parent := models.NewJob(models.JobTypeSyncMembers, data)
+		parent.ExecAt = time.Now().UTC().Add(time.Hour)
+		r.NoError(db.Create(parent).Error)
+
+		child := models.NewJob(models.JobTypeCreateMatches, data)
+		child.ParentIDs = []int32{parent.ID}
+		r.NoError(db.Create(child).Error)
+
+		grandchild := models.NewJob(models.JobTypeReportMatches, data)
+		grandchild.ParentIDs = []int32{child.ID}
+		r.NoError(db.Create(grandchild).Error)
+
+		return parent, child, grandchild
+	}
+
+	claimNextJob := func() []models.Job {
+		var jobs []models.Job
+		r.NoError(db.Raw("SELECT * FROM ClaimNextJob(?, ?)", "test", 60).Scan(&jobs).Error)
+		return jobs
+	}
+
+	t.Run("parent succeeded", func(t *testing.T) {
+		parent, child, _ := queueJobs("C0123456789")
+
+		// The child is not claimed while its parent is pending
+		r.Empty(claimNextJob())
+
+		r.NoError(db.Model(parent).Updates(map[string]interface{}{
+			"status":       models.JobStatusSucceeded,
+			"is_completed": true,
+		}).Error)
+
+		jobs := claimNextJob()
+		r.Len(jobs, 1)
+		r.Equal(child.ID, jobs[0].ID)
+
+		r.NoError(db.Model(child).Updates(map[string]interface{}{
+			"status":       models.JobStatusSucceeded,
+			"is_completed": true,
+		}).Error)
+
+		r.Len(claimNextJob(), 1)
+	})
+
+	for _, status := range []string{models.JobStatusFailed.String(), models.JobStatusCanceled.String()} {
+		t.Run(fmt.Sprintf("parent %s", status), func(t *testing.T) {
+			parent, child, grandchild := queueJobs("C9876543210")
+
+			r.NoError(db.Model(parent).Updates(map[string]interface{}{
+				"status":       status,
+				"is_completed": true,
+			}).Error)
+
+			// The descendants of the parent are canceled rather than executed
+			r.Empty(claimNextJob())
+
+			for _, job := range []*models.Job{child, grandchild} {
+				r.NoError(db.First(job, job.ID).Error)
+				r.Equal(models.JobStatusCanceled, job.Status)
+				r.True(job.IsCompleted)
+				r.Contains(job.LastError, "did not succeed")
+			}
+
+			// A replayed child is no longer held back by its parent
+			r.NoError(ReplayDeadLetterJob(context.Background(), db, child.JobID, nil))
+
+			jobs := claimNextJob()
+			r.Len(jobs, 1)
+			r.Equal(child.ID, jobs[0].ID)
+			r.Empty(jobs[0].ParentIDs)
+		})
+	}
+}
@@ -5,15 +5,21 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
 const (
 	appHomeTemplateFilename = "app_home.json.tmpl"
+
+	// appHomeHistoryLimit is the number of past matches listed in the App Home for each channel
+	appHomeHistoryLimit = 3
 )
 
 // AppHomeParams is the parameters for handling app_home_opened events
@@ -24,10 +30,35 @@ type AppHomeParams struct {
 }
 
 type appHomeTemplate struct {
-	BotUserID string
-	AppURL    string
-	Channels  []models.Channel
-	IsAppUser bool
+	BotUserID   string
+	AppURL      string
+	Memberships []appHomeMembership
+	Channels    []models.Channel
+	IsAppUser   bool
+}
+
+// appHomeMembership is a chat-roulette channel that the user is a member of
+type appHomeMembership struct {
+	Channel  models.Channel
+	IsActive bool
+
+	// ResumesAt is the start of the round that a member who skipped
+	// the next round will be matched in again. It is the zero time otherwise.
+	ResumesAt time.Time
+
+	// Match is the member's match in the current round, if any
+	Match *appHomeMatch
+
+	// History is the member's past matches, most recent first
+	History []appHomeMatch
+}
+
+// appHomeMatch is a match listed in the App Home
+type appHomeMatch struct {
+	memberMatch
+
+	// Mentions are the partners in the match formatted as Slack user mentions
+	Mentions string
 }
 
 // HandleAppHomeEvent handles the app_home_opened event and publishes the view for the App Home.
@@ -41,19 +72,49 @@ func HandleAppHomeEvent(ctx context.Context, client *slack.Client, db *gorm.DB,
 		return errors.Wrap(err, "failed to retrieve chat roulette channels")
 	}
 
-	// Check if the user exists in the database (ie, user is a member of a Chat Roulette channel). Ignore errors
-	var count int64
-	_ = db.WithContext(dbCtx).Model(&models.Member{}).Where("user_id = ?", p.UserID).Count(&count)
+	// Retrieve the chat-roulette channels that the user is a member of
+	var members []models.Member
+	if err := db.WithContext(dbCtx).Where("user_id = ?", p.UserID).Find(&members).Error; err != nil {
+		return errors.Wrap(err, "failed to retrieve the user's chat roulette memberships")
+	}
+
+	// Retrieve the language of the user. Ignore errors
+	lang, _ := memberLanguage(ctx, db, "", p.UserID)
+
+	memberOf := make(map[string]models.Member, len(members))
+	for _, m := range members {
+		memberOf[m.ChannelID] = m
+	}
+
+	// List the channels that the user is a member of separately from the channels they can join
+	var memberships []appHomeMembership
+	var joinable []models.Channel
+
+	for _, channel := range channels {
+		member, ok := memberOf[channel.ChannelID]
+		if !ok {
+			joinable = append(joinable, channel)
+			continue
+		}
+
+		membership, err := getAppHomeMembership(ctx, db, lang, channel, member)
+		if err != nil {
+			return err
+		}
+
+		memberships = append(memberships, *membership)
+	}
 
 	// Render template
 	t := appHomeTemplate{
-		BotUserID: p.BotUserID,
-		AppURL:    p.URL,
-		Channels:  channels,
-		IsAppUser: count > 0,
+		BotUserID:   p.BotUserID,
+		AppURL:      p.URL,
+		Memberships: memberships,
+		Channels:    joinable,
+		IsAppUser:   len(members) > 0,
 	}
 
-	content, err := renderTemplate(appHomeTemplateFilename, t)
+	content, err := renderTemplate(lang, appHomeTemplateFilename, t)
 	if err != nil {
 		return errors.Wrap(err, "failed to render template")
 	}
@@ -74,3 +135,143 @@ func HandleAppHomeEvent(ctx context.Context, client *slack.Client, db *gorm.DB,
 
 	return nil
 }
+
+// getAppHomeMembership retrieves the current match, past matches, and
+// participation status of a member of a chat-roulette channel.
+func getAppHomeMembership(ctx context.Context, db *gorm.DB, lang i18n.Language, channel models.Channel, member models.Member) (*appHomeMembership, error) {
+	membership := &appHomeMembership{
+		Channel:  channel,
+		IsActive: member.IsActive != nil && *member.IsActive,
+	}
+
+	// The most recent match is the current match if its round has not ended yet
+	matches, err := getMemberMatches(ctx, db, channel.ChannelID, member.UserID, appHomeHistoryLimit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range matches {
+		match := appHomeMatch{
+			memberMatch: m,
+			Mentions:    mentionUsers(lang, m.Partners),
+		}
+
+		if !m.HasEnded && membership.Match == nil {
+			membership.Match = &match
+			continue
+		}
+
+		if len(membership.History) < appHomeHistoryLimit {
+			membership.History = append(membership.History, match)
+		}
+	}
+
+	// A paused member may have skipped the next round
+	if !membership.IsActive {
+		resumesAt, err := getSkippedRoundResumeDate(ctx, db, channel.ChannelID, member.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		membership.ResumesAt = resumesAt
+	}
+
+	return membership, nil
+}
+
+// appURLContextKey is the context key for the base URL of the chat-roulette web app
+type appURLContextKey struct{}
+
+// ContextWithAppURL returns a copy of the context with the base URL of the chat-roulette
+// web app. Jobs executed with the context refresh the App Home of the users they change.
+func ContextWithAppURL(ctx context.Context, appURL string) context.Context {
+	return context.WithValue(ctx, appURLContextKey{}, appURL)
+}
+
+// appURLFromContext returns the base URL of the chat-roulette web app in the context,
+// or an empty string if it is not set.
+func appURLFromContext(ctx context.Context) string {
+	appURL, _ := ctx.Value(appURLContextKey{}).(string)
+	return appURL
+}
+
+// refreshAppHome publishes the App Home of a Slack user again after their
+// chat-roulette settings changed, so that it does not show stale information.
+//
+// The App Home is only refreshed when the Slack client and the base URL of the
+// web app are available. Errors are logged rather than returned since the
+// App Home is published again when the user next opens it.
+func refreshAppHome(ctx context.Context, db *gorm.DB, client *slack.Client, userID string) {
+	appURL := appURLFromContext(ctx)
+	if client == nil || appURL == "" {
+		return
+	}
+
+	logger := hclog.FromContext(ctx).With(attributes.SlackUserID, userID)
+
+	botUserID, err := GetBotUserID(ctx, client)
+	if err != nil {
+		logger.Warn("failed to retrieve the user ID of the chat-roulette Slack bot", "error", err)
+		return
+	}
+
+	p := &AppHomeParams{
+		BotUserID: botUserID,
+		URL:       appURL,
+		UserID:    userID,
+	}
+
+	if err := HandleAppHomeEvent(ctx, client, db, p); err != nil {
+		logger.Warn("failed to refresh the App Home", "error", err)
+		return
+	}
+
+	logger.Info("refreshed the App Home")
+}
+
+// HandleAppHomeButtons handles the buttons in the App Home for pausing, resuming,
+// and skipping the next round of a chat-roulette channel. The value of each button
+// is the ID of the channel.
+//
+// The App Home is refreshed by the UPDATE_MEMBER job queued for the change.
+func HandleAppHomeButtons(ctx context.Context, db *gorm.DB, interaction *slack.InteractionCallback) error {
+	if len(interaction.ActionCallback.BlockActions) == 0 {
+		return nil
+	}
+
+	action := interaction.ActionCallback.BlockActions[0]
+
+	channelID := action.Value
+	userID := interaction.User.ID
+
+	logger := hclog.FromContext(ctx).With(
+		attributes.SlackChannelID, channelID,
+		attributes.SlackUserID, userID,
+	)
+	ctx = hclog.WithContext(ctx, logger)
+
+	switch action.ActionID {
+	case "UPDATE_MEMBER|pause":
+		return setRouletteParticipation(ctx, db, channelID, userID, false)
+
+	case "UPDATE_MEMBER|resume":
+		return setRouletteParticipation(ctx, db, channelID, userID, true)
+
+	case "UPDATE_MEMBER|skip":
+		dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+		defer cancel()
+
+		var channel models.Channel
+		if err := db.WithContext(dbCtx).Where("channel_id = ?", channelID).First(&channel).Error; err != nil {
+			message := "failed to retrieve chat-roulette channel"
+			logger.Error(message, "error", err)
+			return errors.Wrap(err, message)
+		}
+
+		_, err := skipRound(ctx, db, &channel, userID)
+		return err
+
+	default:
+		return errors.Errorf("invalid App Home action: %s", action.ActionID)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	goldie "github.com/sebdah/goldie/v2"
 	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/chat-roulettte/chat-roulette/internal/database"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 )
 
 func Test_HandleAppHomeEvent(t *testing.T) {
@@ -90,17 +92,20 @@ func Test_HandleAppHomeEvent(t *testing.T) {
 
 		err := json.NewDecoder(r.Body).Decode(&req)
 		assert.Nil(t, err)
-		assert.Len(t, req.View.Blocks.BlockSet, 20)
+		assert.Len(t, req.View.Blocks.BlockSet, 23)
 
 		// Assert that the response matches the right template
 		template := appHomeTemplate{
 			BotUserID: params.BotUserID,
 			AppURL:    params.URL,
-			Channels:  []models.Channel{*channel1, *channel2},
+			Memberships: []appHomeMembership{
+				{Channel: *channel1, IsActive: true},
+				{Channel: *channel2, IsActive: true},
+			},
 			IsAppUser: true,
 		}
 
-		content, err := renderTemplate(appHomeTemplateFilename, template)
+		content, err := renderTemplate(i18n.English, appHomeTemplateFilename, template)
 		assert.Nil(t, err)
 
 		var view slack.HomeTabViewRequest
@@ -122,6 +127,25 @@ func Test_appHomeTemplate(t *testing.T) {
 	g := goldie.New(t)
 
 	nextRound := time.Date(2021, time.January, 4, 12, 0, 0, 0, time.UTC)
+	startedAt := time.Date(2020, time.December, 21, 12, 0, 0, 0, time.UTC)
+
+	channel1 := models.Channel{
+		ChannelID:      "C0123456789",
+		Inviter:        "U0123456789",
+		Interval:       models.Biweekly,
+		Weekday:        time.Monday,
+		ConnectionMode: models.ConnectionModeVirtual,
+		NextRound:      nextRound,
+	}
+
+	channel2 := models.Channel{
+		ChannelID:      "C9876543210",
+		Inviter:        "U0123456789",
+		Interval:       models.Monthly,
+		Weekday:        time.Monday,
+		ConnectionMode: models.ConnectionModeHybrid,
+		NextRound:      nextRound,
+	}
 
 	type test struct {
 		name       string
@@ -138,19 +162,29 @@ func Test_appHomeTemplate(t *testing.T) {
 			data: appHomeTemplate{
 				BotUserID: "U0123456789",
 				AppURL:    "https://chat-roulette-for-slack.com",
-				Channels: []models.Channel{
+				Memberships: []appHomeMembership{
 					{
-						ChannelID:      "C0123456789",
-						Inviter:        "U0123456789",
-						Interval:       models.Biweekly,
-						Weekday:        time.Monday,
-						ConnectionMode: models.ConnectionModeVirtual,
-						NextRound:      nextRound,
+						Channel:  channel1,
+						IsActive: true,
+						Match: &appHomeMatch{
+							memberMatch: memberMatch{MpimID: "G0123456789", StartedAt: nextRound},
+							Mentions:    "<@U9876543210>",
+						},
+						History: []appHomeMatch{
+							{
+								memberMatch: memberMatch{HasMet: true, HasEnded: true, StartedAt: startedAt},
+								Mentions:    "<@U1111111111>",
+							},
+							{
+								memberMatch: memberMatch{HasEnded: true, StartedAt: startedAt.AddDate(0, 0, -14)},
+								Mentions:    "<@U2222222222> and <@U3333333333>",
+							},
+						},
 					},
 				},
 				IsAppUser: true,
 			},
-			blocks: 19,
+			blocks: 21,
 			isErr:  false,
 		},
 		{
@@ -159,22 +193,26 @@ func Test_appHomeTemplate(t *testing.T) {
 			data: appHomeTemplate{
 				BotUserID: "U0123456789",
 				AppURL:    "https://chat-roulette-for-slack.com",
-				Channels: []models.Channel{
+				Memberships: []appHomeMembership{
 					{
-						ChannelID:      "C0123456789",
-						Inviter:        "U0123456789",
-						Interval:       models.Biweekly,
-						Weekday:        time.Monday,
-						ConnectionMode: models.ConnectionModeVirtual,
-						NextRound:      nextRound,
+						Channel:   channel1,
+						ResumesAt: nextRound.AddDate(0, 0, 14),
 					},
+				},
+				Channels:  []models.Channel{channel2},
+				IsAppUser: true,
+			},
+			blocks: 24,
+		},
+		{
+			name:       "paused app user",
+			goldenFile: "app_home_paused.json",
+			data: appHomeTemplate{
+				BotUserID: "U0123456789",
+				AppURL:    "https://chat-roulette-for-slack.com",
+				Memberships: []appHomeMembership{
 					{
-						ChannelID:      "C9876543210",
-						Inviter:        "U0123456789",
-						Interval:       models.Monthly,
-						Weekday:        time.Monday,
-						ConnectionMode: models.ConnectionModeHybrid,
-						NextRound:      nextRound,
+						Channel: channel2,
 					},
 				},
 				IsAppUser: true,
@@ -197,7 +235,7 @@ func Test_appHomeTemplate(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			content, err := renderTemplate(appHomeTemplateFilename, tc.data)
+			content, err := renderTemplate(i18n.English, appHomeTemplateFilename, tc.data)
 			if tc.isErr {
 				r.Equal("foo", content)
 				r.Error(err)
@@ -214,3 +252,64 @@ func Test_appHomeTemplate(t *testing.T) {
 		})
 	}
 }
+
+func Test_HandleAppHomeButtons(t *testing.T) {
+	channelID := "C0123456789"
+	userID := "U0123456789"
+
+	newInteraction := func(actionID string) *slack.InteractionCallback {
+		interaction := &slack.InteractionCallback{
+			User: slack.User{ID: userID},
+		}
+		interaction.ActionCallback.BlockActions = []*slack.BlockAction{
+			{ActionID: actionID, Value: channelID},
+		}
+		return interaction
+	}
+
+	tests := []struct {
+		name     string
+		actionID string
+		isActive bool
+	}{
+		{"pause", "UPDATE_MEMBER|pause", false},
+		{"resume", "UPDATE_MEMBER|resume", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			db, mock := database.NewMockedGormDB()
+
+			// Mock canceling the pending job for a skipped round
+			mock.ExpectBegin()
+			mock.ExpectExec(`UPDATE "jobs" SET "status"=(.+),"is_completed"=(.+),"updated_at"=(.+) WHERE idempotency_key = (.+) AND is_completed = false`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectCommit()
+
+			isActive := tc.isActive
+			database.MockQueueJob(
+				mock,
+				&UpdateMemberParams{
+					ChannelID: channelID,
+					UserID:    userID,
+					IsActive:  &isActive,
+				},
+				models.JobTypeUpdateMember.String(),
+				models.JobPriorityHigh,
+			)
+
+			err := HandleAppHomeButtons(context.Background(), db, newInteraction(tc.actionID))
+			r.NoError(err)
+			r.NoError(mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("invalid action", func(t *testing.T) {
+		db, _ := database.NewMockedGormDB()
+
+		err := HandleAppHomeButtons(context.Background(), db, newInteraction("UPDATE_MEMBER|invalid"))
+		require.Error(t, err)
+	})
+}
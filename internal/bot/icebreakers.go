@@ -0,0 +1,410 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+)
+
+const (
+	// DefaultIcebreakerCategory is the category of icebreakers that are added without one
+	DefaultIcebreakerCategory = "general"
+
+	// MaxIcebreakersImport is the maximum number of icebreakers that can be imported at once
+	MaxIcebreakersImport = 500
+
+	// MaxIcebreakerLength is the maximum length of an icebreaker question
+	MaxIcebreakerLength = 300
+
+	// MaxIcebreakerCategoryLength is the maximum length of an icebreaker category
+	MaxIcebreakerCategoryLength = 50
+
+	// IcebreakerRetireMinRatings is the minimum number of ratings in a Slack channel before an icebreaker can be retired there
+	IcebreakerRetireMinRatings = 10
+
+	// IcebreakerRetireMaxApproval is the approval rating below which an icebreaker is retired
+	IcebreakerRetireMaxApproval = 0.25
+
+	// MaxIcebreakerRankings is the maximum number of icebreakers ranked for a Slack channel
+	MaxIcebreakerRankings = 100
+)
+
+var (
+	// ErrInvalidIcebreakersCSV is returned when importing icebreakers from a malformed CSV file
+	ErrInvalidIcebreakersCSV = errors.New("invalid CSV file")
+)
+
+// IcebreakerParams is a custom icebreaker question for a Slack channel.
+type IcebreakerParams struct {
+	Question string `json:"question"`
+	Category string `json:"category,omitempty"`
+}
+
+// NormalizeIcebreakerCategory returns the category in lowercase, without surrounding whitespace.
+func NormalizeIcebreakerCategory(category string) string {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if category == "" {
+		return DefaultIcebreakerCategory
+	}
+
+	return category
+}
+
+// ParseIcebreakersCSV parses icebreakers from a CSV file with the columns: question, category.
+// The category column is optional, and the header row is skipped if there is one.
+func ParseIcebreakersCSV(r io.Reader) ([]IcebreakerParams, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var icebreakers []IcebreakerParams
+
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidIcebreakersCSV, err.Error())
+		}
+
+		// Quoted fields can span several lines, so errors
+		// refer to the line that the record starts on
+		line, _ := reader.FieldPos(0)
+
+		question := strings.TrimSpace(record[0])
+
+		if n == 1 && strings.EqualFold(question, "question") {
+			continue
+		}
+
+		if question == "" {
+			return nil, errors.Wrap(ErrInvalidIcebreakersCSV, fmt.Sprintf("line %d: question cannot be blank", line))
+		}
+
+		if len(question) > MaxIcebreakerLength {
+			return nil, errors.Wrap(ErrInvalidIcebreakersCSV, fmt.Sprintf("line %d: question must be at most %d characters", line, MaxIcebreakerLength))
+		}
+
+		p := IcebreakerParams{
+			Question: question,
+		}
+
+		if len(record) > 1 {
+			p.Category = record[1]
+		}
+
+		p.Category = NormalizeIcebreakerCategory(p.Category)
+
+		if len(p.Category) > MaxIcebreakerCategoryLength {
+			return nil, errors.Wrap(ErrInvalidIcebreakersCSV, fmt.Sprintf("line %d: category must be at most %d characters", line, MaxIcebreakerCategoryLength))
+		}
+
+		icebreakers = append(icebreakers, p)
+
+		if len(icebreakers) > MaxIcebreakersImport {
+			return nil, errors.Wrap(ErrInvalidIcebreakersCSV, fmt.Sprintf("must have at most %d icebreakers", MaxIcebreakersImport))
+		}
+	}
+
+	if len(icebreakers) == 0 {
+		return nil, errors.Wrap(ErrInvalidIcebreakersCSV, "must have at least 1 icebreaker")
+	}
+
+	return icebreakers, nil
+}
+
+// AddIcebreakers adds custom icebreakers for a Slack channel, skipping
+// any questions the channel already has. It returns the number added.
+func AddIcebreakers(ctx context.Context, db *gorm.DB, channelID string, icebreakers []IcebreakerParams) (int64, error) {
+	logger := hclog.FromContext(ctx).With(attributes.SlackChannelID, channelID)
+
+	rows := make([]models.Icebreaker, 0, len(icebreakers))
+	for _, i := range icebreakers {
+		rows = append(rows, models.Icebreaker{
+			ChannelID: channelID,
+			Question:  strings.TrimSpace(i.Question),
+			Category:  NormalizeIcebreakerCategory(i.Category),
+		})
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&rows)
+
+	if result.Error != nil {
+		message := "failed to add icebreakers to the database"
+		logger.Error(message, "error", result.Error)
+		return 0, errors.Wrap(result.Error, message)
+	}
+
+	logger.Info("added icebreakers for the Slack channel", "count", result.RowsAffected)
+
+	return result.RowsAffected, nil
+}
+
+// DeleteIcebreaker deletes a custom icebreaker of a Slack channel.
+func DeleteIcebreaker(ctx context.Context, db *gorm.DB, channelID string, icebreakerID int32) error {
+	logger := hclog.FromContext(ctx).With(attributes.SlackChannelID, channelID)
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Where("id = ?", icebreakerID).
+		Where("channel_id = ?", channelID).
+		Delete(&models.Icebreaker{})
+
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = gorm.ErrRecordNotFound
+	}
+
+	if result.Error != nil {
+		message := "failed to delete icebreaker from the database"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	logger.Info("deleted icebreaker for the Slack channel", "icebreaker_id", icebreakerID)
+
+	return nil
+}
+
+// ListIcebreakers lists the custom icebreakers of a Slack channel.
+func ListIcebreakers(ctx context.Context, db *gorm.DB, channelID string) ([]models.Icebreaker, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var icebreakers []models.Icebreaker
+
+	if err := db.WithContext(dbCtx).
+		Where("channel_id = ?", channelID).
+		Order("category, question").
+		Find(&icebreakers).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve icebreakers from the database")
+	}
+
+	return icebreakers, nil
+}
+
+// ListIcebreakerCategories lists the categories of the icebreakers
+// that can be shared with pairs in a Slack channel.
+func ListIcebreakerCategories(ctx context.Context, db *gorm.DB, channelID string) ([]string, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var categories []string
+
+	if err := db.WithContext(dbCtx).
+		Model(&models.Icebreaker{}).
+		Distinct("category").
+		Where("channel_id IS NULL OR channel_id = ?", channelID).
+		Order("category").
+		Pluck("category", &categories).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve icebreaker categories from the database")
+	}
+
+	return categories, nil
+}
+
+// RateIcebreakerParams are the parameters for rating the icebreaker shared with a match.
+type RateIcebreakerParams struct {
+	MatchID      int32
+	IcebreakerID int32
+	UserID       string
+	IsPositive   bool
+}
+
+// RateIcebreaker records a user's rating of the icebreaker shared with their match,
+// replacing any previous rating by the user. The icebreaker is retired in the
+// Slack channel of the match if it has been consistently rated poorly there.
+func RateIcebreaker(ctx context.Context, db *gorm.DB, p *RateIcebreakerParams) error {
+	logger := hclog.FromContext(ctx).With(
+		attributes.MatchID, p.MatchID,
+		attributes.SlackUserID, p.UserID,
+		"icebreaker_id", p.IcebreakerID,
+	)
+
+	// Retrieve the Slack channel of the match, verifying that the icebreaker
+	// was shared with it and that the user is one of its participants
+	var channelID string
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Model(&models.Round{}).
+		Select("rounds.channel_id").
+		Joins("JOIN matches ON matches.round_id = rounds.id").
+		Joins("JOIN pairings ON pairings.match_id = matches.id").
+		Joins("JOIN members ON members.id = pairings.member_id").
+		Where("matches.id = ?", p.MatchID).
+		Where("matches.icebreaker_id = ?", p.IcebreakerID).
+		Where("members.user_id = ?", p.UserID).
+		First(&channelID)
+
+	if result.Error != nil {
+		message := "failed to retrieve the Slack channel of the match"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	rating := &models.IcebreakerRating{
+		IcebreakerID: p.IcebreakerID,
+		MatchID:      p.MatchID,
+		ChannelID:    channelID,
+		UserID:       p.UserID,
+		IsPositive:   p.IsPositive,
+	}
+
+	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result = db.WithContext(dbCtx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "icebreaker_id"}, {Name: "match_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"is_positive", "updated_at"}),
+		}).
+		Create(rating)
+
+	if result.Error != nil {
+		message := "failed to add icebreaker rating to the database"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	logger.Info("rated icebreaker", "is_positive", p.IsPositive)
+
+	// Retire the icebreaker in the Slack channel if it is consistently rated poorly there
+	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result = db.WithContext(dbCtx).Exec(`
+		INSERT INTO retired_icebreakers (channel_id, icebreaker_id)
+		SELECT channel_id, icebreaker_id
+		FROM icebreaker_ratings
+		WHERE channel_id = @channel_id AND icebreaker_id = @icebreaker_id
+		GROUP BY channel_id, icebreaker_id
+		HAVING COUNT(*) >= @min_ratings AND AVG(is_positive::int) < @max_approval
+		ON CONFLICT DO NOTHING`,
+		sql.Named("channel_id", channelID),
+		sql.Named("icebreaker_id", p.IcebreakerID),
+		sql.Named("min_ratings", IcebreakerRetireMinRatings),
+		sql.Named("max_approval", IcebreakerRetireMaxApproval),
+	)
+
+	if result.Error != nil {
+		message := "failed to retire icebreaker"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	if result.RowsAffected > 0 {
+		logger.Info("retired icebreaker for being rated poorly")
+	}
+
+	return nil
+}
+
+// IcebreakerRanking is an icebreaker with its engagement stats in a Slack channel.
+type IcebreakerRanking struct {
+	ID        int32
+	Question  string
+	Category  string
+	IsCustom  bool
+	IsRetired bool
+
+	// Shared is the number of matches in the channel that the icebreaker was shared with
+	Shared int64
+
+	// Met is the number of those matches that met
+	Met int64
+
+	// Upvotes and Downvotes are the ratings of the icebreaker in the channel
+	Upvotes   int64
+	Downvotes int64
+}
+
+// Approval returns the share of ratings of the icebreaker that are positive.
+func (i IcebreakerRanking) Approval() float64 {
+	if i.Upvotes+i.Downvotes == 0 {
+		return 0
+	}
+
+	return float64(i.Upvotes) / float64(i.Upvotes+i.Downvotes)
+}
+
+// MetRate returns the share of matches that met after the icebreaker was shared with them.
+func (i IcebreakerRanking) MetRate() float64 {
+	if i.Shared == 0 {
+		return 0
+	}
+
+	return float64(i.Met) / float64(i.Shared)
+}
+
+// RankIcebreakers ranks the icebreakers that have been shared with
+// matches in a Slack channel by their rating in that channel.
+func RankIcebreakers(ctx context.Context, db *gorm.DB, channelID string) ([]IcebreakerRanking, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var rankings []IcebreakerRanking
+
+	result := db.WithContext(dbCtx).Raw(`
+		WITH ratings AS (
+			SELECT
+				icebreaker_id,
+				COUNT(*) FILTER (WHERE is_positive) AS upvotes,
+				COUNT(*) FILTER (WHERE NOT is_positive) AS downvotes
+			FROM icebreaker_ratings
+			WHERE channel_id = @channel_id
+			GROUP BY icebreaker_id
+		)
+		SELECT
+			i.id,
+			i.question,
+			i.category,
+			i.channel_id IS NOT NULL AS is_custom,
+			i.id IN (SELECT icebreaker_id FROM retired_icebreakers WHERE channel_id = @channel_id) AS is_retired,
+			COUNT(mt.id) AS shared,
+			COUNT(mt.id) FILTER (WHERE mt.has_met) AS met,
+			COALESCE(rt.upvotes, 0) AS upvotes,
+			COALESCE(rt.downvotes, 0) AS downvotes
+		FROM icebreakers i
+		LEFT JOIN matches mt ON mt.icebreaker_id = i.id
+			AND mt.round_id IN (SELECT id FROM rounds WHERE channel_id = @channel_id)
+		LEFT JOIN ratings rt ON rt.icebreaker_id = i.id
+		WHERE i.channel_id IS NULL OR i.channel_id = @channel_id
+		GROUP BY i.id, rt.icebreaker_id, rt.upvotes, rt.downvotes
+		HAVING COUNT(mt.id) > 0 OR rt.icebreaker_id IS NOT NULL
+		ORDER BY
+			(COALESCE(rt.upvotes, 0) + 1.0) / (COALESCE(rt.upvotes, 0) + COALESCE(rt.downvotes, 0) + 2.0) DESC,
+			shared DESC
+		LIMIT @limit`,
+		sql.Named("channel_id", channelID),
+		sql.Named("limit", MaxIcebreakerRankings),
+	).Scan(&rankings)
+
+	if result.Error != nil {
+		return nil, errors.Wrap(result.Error, "failed to rank icebreakers")
+	}
+
+	return rankings, nil
+}
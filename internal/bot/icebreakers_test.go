@@ -0,0 +1,210 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y"
+)
+
+func Test_ParseIcebreakersCSV(t *testing.T) {
+	testCases := []struct {
+		name     string
+		csv      string
+		expected []IcebreakerParams
+		err      string
+	}{
+		{
+			name: "with header",
+			csv:  "question,category\nWhat's your favorite book?,Books\n\"Tea, or coffee?\", food ",
+			expected: []IcebreakerParams{
+				{Question: "What's your favorite book?", Category: "books"},
+				{Question: "Tea, or coffee?", Category: "food"},
+			},
+		},
+		{
+			name: "without category",
+			csv:  "What's your favorite book?\nWhat did you have for breakfast?,",
+			expected: []IcebreakerParams{
+				{Question: "What's your favorite book?", Category: DefaultIcebreakerCategory},
+				{Question: "What did you have for breakfast?", Category: DefaultIcebreakerCategory},
+			},
+		},
+		{
+			name: "blank question",
+			csv:  "question,category\n,books",
+			err:  "line 2: question cannot be blank",
+		},
+		{
+			name: "blank question after multiline question",
+			csv:  "question,category\n\n\"What's your\nfavorite book?\",books\n,books",
+			err:  "line 5: question cannot be blank",
+		},
+		{
+			name: "empty",
+			csv:  "question,category\n",
+			err:  "must have at least 1 icebreaker",
+		},
+		{
+			name: "malformed",
+			csv:  "\"What's your favorite book?",
+			err:  ErrInvalidIcebreakersCSV.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			icebreakers, err := ParseIcebreakersCSV(strings.NewReader(tc.csv))
+			if tc.err != "" {
+				assert.ErrorIs(t, err, ErrInvalidIcebreakersCSV)
+				assert.Contains(t, err.Error(), tc.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, icebreakers)
+		})
+	}
+}
+
+type IcebreakersSuite struct {
+	suite.Suite
+	ctx    context.Context
+	mock   sqlmock.Sqlmock
+	db     *gorm.DB
+	logger hclog.Logger
+	buffer *bytes.Buffer
+}
+
+func (s *IcebreakersSuite) SetupTest() {
+	s.logger, s.buffer = o11y.NewBufferedLogger()
+	s.ctx = hclog.WithContext(context.Background(), s.logger)
+	s.db, s.mock = database.NewMockedGormDB()
+}
+
+func (s *IcebreakersSuite) AfterTest(_, _ string) {
+	require.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IcebreakersSuite) Test_AddIcebreakers() {
+	r := require.New(s.T())
+
+	channelID := "C0123456789"
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(`INSERT INTO "icebreakers" (.+) VALUES (.+) ON CONFLICT DO NOTHING RETURNING "id"`).
+		WithArgs(
+			"What's your favorite book?",
+			"books",
+			0,
+			database.AnyTime(),
+			database.AnyTime(),
+			channelID,
+			"Tea or coffee?",
+			DefaultIcebreakerCategory,
+			0,
+			database.AnyTime(),
+			database.AnyTime(),
+			channelID,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectCommit()
+
+	added, err := AddIcebreakers(s.ctx, s.db, channelID, []IcebreakerParams{
+		{Question: "What's your favorite book?", Category: " Books"},
+		{Question: "Tea or coffee?"},
+	})
+	r.NoError(err)
+	r.Equal(int64(1), added)
+	r.Contains(s.buffer.String(), "added icebreakers for the Slack channel")
+}
+
+func (s *IcebreakersSuite) Test_DeleteIcebreaker() {
+	r := require.New(s.T())
+
+	channelID := "C0123456789"
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`DELETE FROM "icebreakers" WHERE id = \$1 AND channel_id = \$2`).
+		WithArgs(7, channelID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	err := DeleteIcebreaker(s.ctx, s.db, channelID, 7)
+	r.NoError(err)
+}
+
+func (s *IcebreakersSuite) Test_DeleteIcebreaker_NotFound() {
+	r := require.New(s.T())
+
+	channelID := "C0123456789"
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`DELETE FROM "icebreakers" WHERE id = \$1 AND channel_id = \$2`).
+		WithArgs(7, channelID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectCommit()
+
+	err := DeleteIcebreaker(s.ctx, s.db, channelID, 7)
+	r.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *IcebreakersSuite) Test_RateIcebreaker() {
+	r := require.New(s.T())
+
+	channelID := "C0123456789"
+
+	s.mock.ExpectQuery(`SELECT rounds.channel_id FROM "rounds" JOIN matches ON matches.round_id = rounds.id JOIN pairings ON pairings.match_id = matches.id JOIN members ON members.id = pairings.member_id WHERE matches.id = \$1 AND matches.icebreaker_id = \$2 AND members.user_id = \$3`).
+		WithArgs(42, 7, "U0123456789", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"channel_id"}).AddRow(channelID))
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(`INSERT INTO "icebreaker_ratings" (.+) VALUES (.+) ON CONFLICT \("icebreaker_id","match_id","user_id"\) DO UPDATE SET "is_positive"="excluded"."is_positive","updated_at"="excluded"."updated_at" RETURNING "id"`).
+		WithArgs(7, 42, channelID, "U0123456789", false, database.AnyTime(), database.AnyTime()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectCommit()
+
+	s.mock.ExpectExec(`INSERT INTO retired_icebreakers \(channel_id, icebreaker_id\) SELECT (.+) FROM icebreaker_ratings WHERE channel_id = \$1 AND icebreaker_id = \$2 GROUP BY (.+) HAVING COUNT\(\*\) >= \$3 AND AVG\(is_positive::int\) < \$4 ON CONFLICT DO NOTHING`).
+		WithArgs(channelID, 7, IcebreakerRetireMinRatings, IcebreakerRetireMaxApproval).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := RateIcebreaker(s.ctx, s.db, &RateIcebreakerParams{
+		MatchID:      42,
+		IcebreakerID: 7,
+		UserID:       "U0123456789",
+		IsPositive:   false,
+	})
+	r.NoError(err)
+	r.Contains(s.buffer.String(), "rated icebreaker")
+	r.Contains(s.buffer.String(), "retired icebreaker for being rated poorly")
+}
+
+func (s *IcebreakersSuite) Test_RateIcebreaker_NotShared() {
+	r := require.New(s.T())
+
+	s.mock.ExpectQuery(`SELECT rounds.channel_id FROM "rounds" JOIN matches ON matches.round_id = rounds.id JOIN pairings ON pairings.match_id = matches.id JOIN members ON members.id = pairings.member_id WHERE matches.id = \$1 AND matches.icebreaker_id = \$2 AND members.user_id = \$3`).
+		WithArgs(42, 7, "U0123456789", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"channel_id"}))
+
+	err := RateIcebreaker(s.ctx, s.db, &RateIcebreakerParams{
+		MatchID:      42,
+		IcebreakerID: 7,
+		UserID:       "U0123456789",
+		IsPositive:   true,
+	})
+	r.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func Test_Icebreakers_suite(t *testing.T) {
+	suite.Run(t, new(IcebreakersSuite))
+}
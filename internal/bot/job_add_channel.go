@@ -23,6 +23,7 @@ type AddChannelParams struct {
 	Weekday        string    `json:"weekday"`
 	Hour           int       `json:"hour"`
 	NextRound      time.Time `json:"next_round"`
+	Language       string    `json:"language,omitempty"`
 }
 
 // AddChannel adds a Slack channel to the database.
@@ -48,12 +49,15 @@ func AddChannel(ctx context.Context, db *gorm.DB, client *slack.Client, p *AddCh
 
 	newChannel := &models.Channel{
 		ChannelID:      p.ChannelID,
+		TeamID:         TeamIDFromContext(ctx),
+		EnterpriseID:   EnterpriseIDFromContext(ctx),
 		Inviter:        p.Inviter,
 		ConnectionMode: connectionMode,
 		Interval:       interval,
 		Weekday:        weekday,
 		Hour:           p.Hour,
 		NextRound:      p.NextRound,
+		Language:       p.Language,
 	}
 
 	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
@@ -53,7 +53,7 @@ func (s *AddChannelSuite) Test_AddChannel() {
 		WillReturnRows(sqlmock.NewRows(nil))
 
 	s.mock.ExpectBegin()
-	s.mock.ExpectExec(`INSERT INTO "channels" (.+) VALUES (.+)`).
+	s.mock.ExpectQuery(`INSERT INTO "channels" (.+) VALUES (.+) RETURNING "team_id"`).
 		WithArgs(
 			channelID,
 			inviter,
@@ -62,10 +62,21 @@ func (s *AddChannelSuite) Test_AddChannel() {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			database.AnyTime(),
+			true,
+			24,
+			true,
+			50,
+			true,
+			18,
+			true,
+			1,
+			true,
+			5,
+			"en",
 			database.AnyTime(),
 			database.AnyTime(),
 		).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WillReturnRows(sqlmock.NewRows([]string{"team_id"}).AddRow(nil))
 	s.mock.ExpectCommit()
 
 	createRoundParams := CreateRoundParams{
@@ -10,7 +10,9 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+	"github.com/chat-roulettte/chat-roulette/internal/slackclient"
 )
 
 // AddMemberParams are the parameters for the ADD_MEMBER job.
@@ -28,17 +30,59 @@ func AddMember(ctx context.Context, db *gorm.DB, client *slack.Client, p *AddMem
 		attributes.SlackUserID, p.UserID,
 	)
 
-	// Skip bot users as they cannot participate in chat-roulette
-	// This checks for all bot users in the channel and not only the chat-roulette bot
-	if isBot, err := isUserASlackBot(ctx, client, p.UserID); err != nil {
+	// The profile of a member from another org in a Slack Connect channel may be restricted
+	user, err := client.GetUserInfoContext(ctx, p.UserID)
+	isRestricted := slackclient.IsRestrictedUser(err)
+
+	if err != nil && !isRestricted {
 		message := "failed to check if this Slack user is a bot"
 		logger.Error(message, "error", err)
 		return errors.Wrap(err, message)
-	} else if isBot {
+	}
+
+	// Skip bot users as they cannot participate in chat-roulette
+	// This checks for all bot users in the channel and not only the chat-roulette bot
+	if !isRestricted && user.IsBot {
 		logger.Debug("skipping because this Slack user is a bot")
 		return nil
 	}
 
+	// Check if the Slack user is an external member of a Slack Connect channel
+	isExternal := isRestricted
+	if !isRestricted {
+		teamID, err := getBotTeamID(ctx, client)
+		if err != nil {
+			message := "failed to retrieve the Slack workspace of the chat-roulette bot"
+			logger.Error(message, "error", err)
+			return errors.Wrap(err, message)
+		}
+
+		isExternal = isExternalUser(user, teamID, EnterpriseIDFromContext(ctx))
+	}
+
+	if isExternal {
+		logger = logger.With("is_external", true)
+
+		var channel models.Channel
+
+		dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+		defer cancel()
+
+		if err := db.WithContext(dbCtx).
+			Select("include_external_members").
+			Where("channel_id = ?", p.ChannelID).
+			First(&channel).Error; err != nil {
+			message := "failed to retrieve the Slack channel from the database"
+			logger.Error(message, "error", err)
+			return errors.Wrap(err, message)
+		}
+
+		if !isEnabled(channel.IncludeExternalMembers) {
+			logger.Debug("skipping because external members are excluded from this Slack channel")
+			return nil
+		}
+	}
+
 	// Add the Slack user to the database
 	logger.Info("adding Slack user to the database")
 
@@ -46,11 +90,22 @@ func AddMember(ctx context.Context, db *gorm.DB, client *slack.Client, p *AddMem
 
 	newMember := &models.Member{
 		ChannelID:           p.ChannelID,
+		TeamID:              TeamIDFromContext(ctx),
+		EnterpriseID:        EnterpriseIDFromContext(ctx),
 		UserID:              p.UserID,
 		ConnectionMode:      models.ConnectionModeHybrid,
 		Gender:              models.Male,
 		IsActive:            &isActive,
 		HasGenderPreference: new(bool),
+		IsExternal:          isExternal,
+		IsExternalChecked:   true,
+	}
+
+	// Send messages in the language of the Slack user if it is supported
+	if !isRestricted {
+		if lang, ok := i18n.Parse(user.Locale); ok {
+			newMember.Language = lang.String()
+		}
 	}
 
 	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
@@ -101,6 +156,8 @@ func QueueAddMemberJob(ctx context.Context, db *gorm.DB, p *AddMemberParams) err
 		JobType:  models.JobTypeAddMember,
 		Priority: models.JobPriorityHigh,
 		Params:   p,
+		// Slack retries events that were not acknowledged
+		IdempotencyKey: models.IdempotencyKey(models.JobTypeAddMember, p.ChannelID, p.UserID),
 	}
 
 	return QueueJob(ctx, db, job)
@@ -11,14 +11,15 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/hashicorp/go-hclog"
-	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
@@ -101,6 +102,7 @@ func BlockMember(ctx context.Context, db *gorm.DB, client *slack.Client, p *Bloc
 
 		blockedMember := &models.BlockedMember{
 			ChannelID: channel,
+			TeamID:    TeamIDFromContext(ctx),
 			UserID:    p.UserID,
 			MemberID:  p.MemberID,
 		}
@@ -108,21 +110,22 @@ func BlockMember(ctx context.Context, db *gorm.DB, client *slack.Client, p *Bloc
 		dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
 		defer cancel()
 
-		result := db.WithContext(dbCtx).Create(blockedMember)
+		// Dont error if the member is already blocked by this user
+		result := db.WithContext(dbCtx).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(blockedMember)
 
 		if result.Error != nil {
-			// Dont error if the member is already blocked by this user
-			var pgErr *pgconn.PgError
-			if errors.Is(result.Error, gorm.ErrDuplicatedKey) || (errors.As(result.Error, &pgErr) && pgErr.Code == "23505") {
-				l.Debug("Slack member is already blocked by this user")
-				return nil
-			}
-
 			message := "failed to add new blocked member to the database"
 			l.Error(message, "error", result.Error)
 			return errors.Wrap(result.Error, message)
 		}
 
+		if result.RowsAffected == 0 {
+			l.Debug("Slack member is already blocked by this user")
+			return nil
+		}
+
 		l.Info("successfully blocked Slack member from matching with this user")
 	}
 
@@ -167,7 +170,10 @@ func HandleBlockMemberButton(ctx context.Context, baseURL string, client *slack.
 			ImageURL:        u.String(),
 		}
 
-		content, err := renderTemplate(blockMemberTemplateFilename, t)
+		// The language of the user is the value of the button in the App Home
+		lang := i18n.Resolve(interaction.ActionCallback.BlockActions[0].Value)
+
+		content, err := renderTemplate(lang, blockMemberTemplateFilename, t)
 		if err != nil {
 			return errors.Wrap(err, "failed to render template")
 		}
@@ -13,6 +13,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
@@ -61,7 +62,19 @@ func CheckPair(ctx context.Context, db *gorm.DB, client *slack.Client, p *CheckP
 		IsMidRound:  p.IsMidRound,
 	}
 
-	content, err := renderTemplate(checkPairTemplateFilename, templateParams)
+	override, err := loadChannelTemplate(ctx, db, p.ChannelID, checkPairTemplateFilename)
+	if err != nil {
+		logger.Error("failed to retrieve the channel's message template", "error", err)
+		return err
+	}
+
+	lang, err := pairLanguage(ctx, db, p.ChannelID, p.Participant, p.Partner)
+	if err != nil {
+		logger.Error("failed to retrieve the language of the pair", "error", err)
+		return err
+	}
+
+	content, err := renderChannelTemplate(ctx, lang, override, checkPairTemplateFilename, templateParams)
 	if err != nil {
 		message := "failed to render template"
 		logger.Error(message, "error", err, "template", checkPairTemplateFilename)
@@ -111,6 +124,7 @@ type checkPairButtonValue struct {
 	MatchID     int32  `json:"match_id"`
 	HasMet      bool   `json:"has_met"`
 	IsMidRound  bool   `json:"is_mid_round"`
+	Language    string `json:"language"`
 }
 
 func (v *checkPairButtonValue) Encode() string {
@@ -149,7 +163,7 @@ func HandleCheckPairButtons(ctx context.Context, client *http.Client, db *gorm.D
 			IsMidRound:  value.IsMidRound,
 		}
 
-		content, err := renderTemplate(checkPairResponseTemplateFilename, t)
+		content, err := renderTemplate(i18n.Resolve(value.Language), checkPairResponseTemplateFilename, t)
 		if err != nil {
 			return errors.Wrap(err, "failed to render template")
 		}
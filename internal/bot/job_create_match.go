@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
-	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -34,14 +33,14 @@ func CreateMatch(ctx context.Context, db *gorm.DB, client *slack.Client, p *Crea
 	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
 	defer cancel()
 
-	var roundID int32
+	var round models.Round
 	result := db.WithContext(dbCtx).
-		Model(&models.Round{}).
-		Select("id").
+		Select("id", "created_at").
 		Where("channel_id = ?", p.ChannelID).
 		Where("has_ended = false").
+		Where("is_adhoc = false").
 		Order("id DESC").
-		First(&roundID)
+		First(&round)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -54,6 +53,8 @@ func CreateMatch(ctx context.Context, db *gorm.DB, client *slack.Client, p *Crea
 		return errors.Wrap(result.Error, message)
 	}
 
+	logger = logger.With(attributes.RoundID, round.ID)
+
 	// Check if the current round has enough time remaining
 	// There must be more than half of the time remaining in the round
 	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
@@ -72,26 +73,7 @@ func CreateMatch(ctx context.Context, db *gorm.DB, client *slack.Client, p *Crea
 		return errors.Wrap(result.Error, message)
 	}
 
-	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
-	defer cancel()
-
-	var currentRound time.Time
-	result = db.WithContext(dbCtx).
-		Model(&models.Job{}).
-		Select("exec_at").
-		Where("status = ?", models.JobStatusSucceeded).
-		Where("is_completed = true").
-		Where("job_type = ?", models.JobTypeCreateRound.String()).
-		Where(datatypes.JSONQuery("data").Equals(p.ChannelID, "channel_id")).
-		First(&currentRound)
-
-	if result.Error != nil {
-		message := "failed to retrieve timestamp of current Chat Roulette round"
-		logger.Error(message, "error", result.Error)
-		return errors.Wrap(result.Error, message)
-	}
-
-	t, err := timex.MidPoint(currentRound, nextRound)
+	t, err := timex.MidPoint(round.CreatedAt, nextRound)
 	if err != nil {
 		message := "failed to determine mid point between current Chat Roulette round and next round"
 		logger.Error(message, "error", result.Error)
@@ -121,7 +103,7 @@ func CreateMatch(ctx context.Context, db *gorm.DB, client *slack.Client, p *Crea
 	subQuery := db.Table("pairings").
 		Select("pairings.member_id").
 		Joins("JOIN matches matches ON pairings.match_id = matches.id").
-		Where("matches.round_id = ?", roundID)
+		Where("matches.round_id = ?", round.ID)
 
 	query := db.WithContext(dbCtx).
 		Model(&models.Member{}).
@@ -150,63 +132,16 @@ func CreateMatch(ctx context.Context, db *gorm.DB, client *slack.Client, p *Crea
 		return errors.Wrap(err, message)
 	}
 
-	// Verify that the selected partner has not already been matched
-	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
-	defer cancel()
-
-	var count int64
-	result = db.WithContext(dbCtx).
-		Model(&models.Job{}).
-		Where(
-			db.Where(
-				datatypes.JSONQuery("data").Equals(p.Participant, "participant")).
-				Or(datatypes.JSONQuery("data").Equals(partner, "participant")).
-				Or(datatypes.JSONQuery("data").Equals(p.Participant, "partner")).
-				Or(datatypes.JSONQuery("data").Equals(partner, "partner"))).
-		Where("status = ?", models.JobStatusPending).
-		Where("is_completed = false").
-		Where("job_type = ?", models.JobTypeCreatePair.String()).Count(&count)
-
-	if result.Error != nil {
-		message := "failed to check if partner has already been matched"
-		logger.Error(message, "error", result.Error)
-		return errors.Wrap(result.Error, "failed to check if partner has already been matched")
-	}
-
-	if count > 0 {
-		logger.Warn("unable to match participant: partner has already been matched")
-		return nil
-	}
-
-	// Create a new match for this pair
-	newMatch := &models.Match{
-		RoundID: roundID,
-	}
-
-	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
-	defer cancel()
-
-	if err := db.WithContext(dbCtx).Create(newMatch).Error; err != nil {
-		message := "failed to create new match record in the database"
-		logger.Error(message, "error", err)
-		return errors.Wrap(err, message)
-	}
-
-	logger.Debug("added new match to the database", attributes.MatchID, newMatch.ID)
-
-	// Queue a CREATE_PAIR job for this pair
-	params := &CreatePairParams{
-		MatchID:     newMatch.ID,
-		ChannelID:   p.ChannelID,
-		Participant: p.Participant,
-		Partner:     partner,
-	}
-
-	dbCtx, cancel = context.WithTimeout(ctx, 500*time.Millisecond)
-	defer cancel()
+	// Create a new match for this pair. This fails if the partner
+	// was paired in this round since they were selected.
+	newMatch, err := createPairedMatch(ctx, db, p.ChannelID, round.ID, p.Participant, partner)
+	if err != nil {
+		if errors.Is(err, errAlreadyPaired) {
+			logger.Warn("unable to match participant: partner has already been matched")
+			return nil
+		}
 
-	if err := QueueCreatePairJob(dbCtx, db, params); err != nil {
-		message := "failed to add CREATE_PAIR job to the queue"
+		message := "failed to create new match"
 		logger.Error(message, "error", err)
 		return errors.Wrap(err, message)
 	}
@@ -223,6 +158,8 @@ func QueueCreateMatchJob(ctx context.Context, db *gorm.DB, p *CreateMatchParams)
 		Priority: models.JobPriorityLow,
 		Params:   p,
 		ExecAt:   time.Now().UTC().Add(10 * time.Minute), // 10 minute delay on execution
+		// A participant only needs to be matched once
+		IdempotencyKey: models.IdempotencyKey(models.JobTypeCreateMatch, p.ChannelID, p.Participant),
 	}
 
 	return QueueJob(ctx, db, job)
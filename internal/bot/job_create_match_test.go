@@ -3,13 +3,11 @@ package bot
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/ory/dockertest"
-	"github.com/segmentio/ksuid"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/datatypes"
@@ -102,25 +100,6 @@ func (s *CreateMatchSuite) Test_CreateMatch() {
 	})
 	r.NoError(err)
 
-	// Add record for the future CREATE_ROUND job
-	p := &CreateRoundParams{
-		ChannelID: channelID,
-		NextRound: time.Now().Add(336 * time.Hour),
-		Interval:  models.Biweekly.String(),
-	}
-
-	data, _ := json.Marshal(p)
-
-	s.db.Create(&models.Job{
-		JobID:       ksuid.New(),
-		JobType:     models.JobTypeCreateRound,
-		Priority:    models.JobPriorityStandard,
-		Status:      models.JobStatusSucceeded,
-		IsCompleted: true,
-		Data:        data,
-		ExecAt:      time.Now().UTC().Add(-(24 * time.Hour)),
-	})
-
 	// Ensure pairings table is populated with rows
 	var jobs []models.Job
 
@@ -216,25 +195,7 @@ func (s *CreateMatchSuite) Test_CreateMatch_ExceededMidPointInActiveRound() {
 	s.db.Create(&models.Round{
 		ChannelID: channelID,
 		HasEnded:  false,
-	})
-
-	// Add record for past CREATE_ROUND job
-	p := &CreateRoundParams{
-		ChannelID: channelID,
-		NextRound: time.Now().Add(24 * time.Hour),
-		Interval:  models.Biweekly.String(),
-	}
-
-	data, _ := json.Marshal(p)
-
-	s.db.Create(&models.Job{
-		JobID:       ksuid.New(),
-		JobType:     models.JobTypeCreateRound,
-		Priority:    models.JobPriorityStandard,
-		Status:      models.JobStatusSucceeded,
-		IsCompleted: true,
-		Data:        data,
-		ExecAt:      time.Now().Add(-(336 * time.Hour)), // 14 days ago
+		CreatedAt: time.Now().Add(-(336 * time.Hour)), // 14 days ago
 	})
 
 	err := CreateMatch(s.ctx, s.db, nil, &CreateMatchParams{
@@ -299,25 +260,6 @@ func (s *CreateMatchSuite) Test_CreateMatch_HasGenderPreference() {
 	})
 	r.NoError(err)
 
-	// Add record for the CREATE_ROUND job
-	createRoundParams := &CreateRoundParams{
-		ChannelID: channelID,
-		NextRound: time.Now().Add(336 * time.Hour),
-		Interval:  models.Biweekly.String(),
-	}
-
-	data, _ := json.Marshal(createRoundParams)
-
-	s.db.Create(&models.Job{
-		JobID:       ksuid.New(),
-		JobType:     models.JobTypeCreateRound,
-		Priority:    models.JobPriorityStandard,
-		Status:      models.JobStatusSucceeded,
-		IsCompleted: true,
-		Data:        data,
-		ExecAt:      time.Now().UTC().Add(-(24 * time.Hour)),
-	})
-
 	// Ensure pairings table is populated with rows
 	var jobs []models.Job
 
@@ -410,25 +352,6 @@ func (s *CreateMatchSuite) Test_CreateMatch_NoMatchFound() {
 	})
 	r.NoError(err)
 
-	// Add record for the CREATE_ROUND job
-	createRoundParams := &CreateRoundParams{
-		ChannelID: channelID,
-		NextRound: time.Now().Add(336 * time.Hour),
-		Interval:  models.Biweekly.String(),
-	}
-
-	data, _ := json.Marshal(createRoundParams)
-
-	s.db.Create(&models.Job{
-		JobID:       ksuid.New(),
-		JobType:     models.JobTypeCreateRound,
-		Priority:    models.JobPriorityStandard,
-		Status:      models.JobStatusSucceeded,
-		IsCompleted: true,
-		Data:        data,
-		ExecAt:      time.Now().UTC().Add(-(24 * time.Hour)),
-	})
-
 	// Ensure pairings table is populated with rows
 	var jobs []models.Job
 
@@ -464,35 +387,17 @@ func (s *CreateMatchSuite) Test_CreateMatch_NoMatchFound() {
 	r.NoError(err)
 	r.Contains(s.buffer.String(), "unable to match participant: no suitable partner found")
 
-	// Test when the selected partner has already been matched
-	err = s.db.Model(&models.Member{}).Where("user_id = ?", "U8765432109").Update("is_active", true).Error
-	r.NoError(err)
-
-	createPairParams := &CreatePairParams{
-		ChannelID:   channelID,
-		MatchID:     4,
-		Participant: "U8765432109",
-		Partner:     "U9261442153",
-	}
+	// Test when the selected partner was paired by another job in the meantime
+	_, err = createPairedMatch(s.ctx, s.db, channelID, 1, newParticipant.UserID, "U3234567890")
+	r.ErrorIs(err, errAlreadyPaired)
 
-	data, _ = json.Marshal(createPairParams)
-
-	s.db.Create(&models.Job{
-		JobID:       ksuid.New(),
-		JobType:     models.JobTypeCreatePair,
-		Priority:    models.JobPriorityStandard,
-		Status:      models.JobStatusPending,
-		IsCompleted: false,
-		Data:        data,
-		ExecAt:      time.Now().UTC().Add((30 * time.Second)),
-	})
-
-	err = CreateMatch(s.ctx, s.db, nil, &CreateMatchParams{
-		ChannelID:   newParticipant.ChannelID,
-		Participant: newParticipant.UserID,
-	})
-	r.NoError(err)
-	r.Contains(s.buffer.String(), "unable to match participant: partner has already been matched")
+	var count int64
+	result := s.db.Model(&models.Pairing{}).
+		Joins("JOIN members ON members.id = pairings.member_id").
+		Where("members.user_id = ?", newParticipant.UserID).
+		Count(&count)
+	r.NoError(result.Error)
+	r.Zero(count)
 }
 
 func (s *CreateMatchSuite) Test_QueueCreateMatchJob() {
@@ -505,7 +410,7 @@ func (s *CreateMatchSuite) Test_QueueCreateMatchJob() {
 
 	db, mock := database.NewMockedGormDB()
 
-	database.MockQueueJob(mock, p, models.JobTypeCreateMatch.String(), models.JobPriorityLow)
+	database.MockQueueJobWithKey(mock, p, models.JobTypeCreateMatch.String(), models.JobPriorityLow, "CREATE_MATCH:C0123456789:U9261442153")
 
 	err := QueueCreateMatchJob(s.ctx, db, p)
 	r.NoError(err)
@@ -5,16 +5,21 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 
+	"github.com/chat-roulettte/chat-roulette/internal/database"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
+// errAlreadyPaired is returned when creating a match for a member who has already been paired in the round
+var errAlreadyPaired = errors.New("member has already been paired in this round")
+
 // chatRoulettePair is a pair of participants for chat-roulette
 type chatRoulettePair struct {
 	Participant string
@@ -25,6 +30,8 @@ type chatRoulettePair struct {
 type CreateMatchesParams struct {
 	ChannelID string `json:"channel_id"`
 	RoundID   int32  `json:"round_id"`
+	// Participants restricts matching to a subset of the channel's members for ad-hoc rounds
+	Participants []string `json:"participants,omitempty"`
 }
 
 // CreateMatches creates matches between active participants for a round of chat-roulette.
@@ -35,10 +42,17 @@ func CreateMatches(ctx context.Context, db *gorm.DB, client *slack.Client, p *Cr
 		attributes.RoundID, p.RoundID,
 	)
 
-	// Wait for member jobs to completed before retrieving participants
-	logger.Info("waiting up to 30 seconds for in-flight member jobs to complete")
-	if err := waitOnMemberJobs(ctx, db, p.ChannelID); err != nil {
-		return err
+	// A previous attempt of this job may have matched some of the participants
+	// before it failed, so only the participants who were not paired are matched
+	participants, pairsCount, err := unpairedParticipants(ctx, db, p)
+	if err != nil {
+		message := "failed to retrieve the participants who have not been paired"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	if pairsCount > 0 {
+		logger.Info("resuming matching for this round of chat-roulette", "pairs", pairsCount)
 	}
 
 	// Retrieve matches for this round of chat-roulette
@@ -49,7 +63,7 @@ func CreateMatches(ctx context.Context, db *gorm.DB, client *slack.Client, p *Cr
 	var matches []chatRoulettePair
 
 	result := db.WithContext(dbCtx).
-		Raw("SELECT * FROM GetRandomMatchesV4(?)", p.ChannelID).
+		Raw("SELECT * FROM GetRandomMatchesV5(?, ?::VARCHAR[])", p.ChannelID, pq.StringArray(participants)).
 		Scan(&matches)
 
 	if result.Error != nil {
@@ -59,103 +73,210 @@ func CreateMatches(ctx context.Context, db *gorm.DB, client *slack.Client, p *Cr
 	}
 	logger.Debug("retrieved matches for chat-roulette", "matches", result.RowsAffected)
 
-	var unpaired int
+	var unpaired []string
 	for _, pair := range matches {
-		//  Queue a NOTIFY_MEMBER job for any participants who did not get matched
 		if pair.Partner == "" {
+			unpaired = append(unpaired, pair.Participant)
+			continue
+		}
+
+		// Create a database record in the matches table for each pair and queue a CREATE_PAIR job
+		newMatch, err := createPairedMatch(ctx, db, p.ChannelID, p.RoundID, pair.Participant, pair.Partner)
+		if err != nil {
+			message := "failed to create new match"
+			logger.Error(message, "error", err)
+			return errors.Wrap(err, message)
+		}
+
+		logger.Info("queued CREATE_PAIR job for this match", attributes.MatchID, newMatch.ID)
+		pairsCount++
+	}
+
+	participantsCount := (pairsCount*2 + len(unpaired))
+
+	logger.Info("paired active participants for chat-roulette", "participants", participantsCount, "pairs", pairsCount, "unpaired", len(unpaired))
+
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int("participants", participantsCount),
+		attribute.Int("pairs", pairsCount),
+		attribute.Int("unpaired", len(unpaired)),
+	)
+
+	roundParticipants.Record(ctx, int64(participantsCount))
+	roundMatches.Record(ctx, int64(pairsCount))
+
+	// Queue a NOTIFY_MEMBER job for any participants who did not get matched
+	// and a REPORT_MATCHES job in a single transaction, so that a retry of
+	// this job does not notify the participants or report the matches twice
+	dbCtx, cancel = context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	err = db.WithContext(dbCtx).Transaction(func(tx *gorm.DB) error {
+		for _, participant := range unpaired {
 			params := &NotifyMemberParams{
 				ChannelID: p.ChannelID,
-				UserID:    pair.Participant,
+				UserID:    participant,
 			}
 
-			dbCtx, cancel = context.WithTimeout(ctx, 500*time.Millisecond)
-			defer cancel()
-
-			if err := QueueNotifyMemberJob(dbCtx, db, params); err != nil {
-				message := "failed to add CREATE_PAIR job to the queue"
-				logger.Error(message, "error", result.Error)
-				return errors.Wrap(result.Error, message)
+			if err := QueueNotifyMemberJob(ctx, tx, params); err != nil {
+				return errors.Wrap(err, "failed to add NOTIFY_MEMBER job to the queue")
 			}
-			logger.Info("queued NOTIFY_MEMBER job for this unmatched participant")
-
-			unpaired++
+		}
 
-			continue
+		params := &ReportMatchesParams{
+			ChannelID:    p.ChannelID,
+			RoundID:      p.RoundID,
+			Participants: participantsCount,
+			Pairs:        pairsCount,
+			Unpaired:     len(unpaired),
 		}
 
-		// Create a database record in the matches table for each pair and queue a CREATE_PAIR job
-		newMatch := &models.Match{
-			RoundID: p.RoundID,
+		if err := QueueReportMatchesJob(ctx, tx, params); err != nil {
+			return errors.Wrap(err, "failed to add REPORT_MATCHES job to the queue")
 		}
 
-		dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
-		defer cancel()
+		return nil
+	})
 
-		if err := db.WithContext(dbCtx).Create(newMatch).Error; err != nil {
-			logger.Error("failed to create new match record in the database", "error", err)
-			return err
-		}
+	if err != nil {
+		logger.Error("failed to queue the jobs for the matches", "error", err)
+		return err
+	}
 
-		logger.Info("added new match to the database", attributes.MatchID, newMatch.ID)
+	logger.Info("queued NOTIFY_MEMBER jobs for the unmatched participants", "unpaired", len(unpaired))
 
-		params := &CreatePairParams{
-			MatchID:     newMatch.ID,
-			ChannelID:   p.ChannelID,
-			Participant: pair.Participant,
-			Partner:     pair.Partner,
-		}
+	return nil
+}
 
-		dbCtx, cancel = context.WithTimeout(ctx, 500*time.Millisecond)
-		defer cancel()
+// unpairedParticipants returns the participants to match for a round of chat-roulette,
+// along with the number of matches that were already created for the round. A nil
+// slice is returned if all active members of the Slack channel are to be matched.
+func unpairedParticipants(ctx context.Context, db *gorm.DB, p *CreateMatchesParams) ([]string, int, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
 
-		if err := QueueCreatePairJob(dbCtx, db, params); err != nil {
-			message := "failed to add CREATE_PAIR job to the queue"
-			logger.Error(message, "error", err)
-			return errors.Wrap(err, message)
-		}
+	var count int64
 
-		logger.Info("queued CREATE_PAIR job for this match", attributes.MatchID, newMatch.ID)
+	result := db.WithContext(dbCtx).
+		Model(&models.Match{}).
+		Where("round_id = ?", p.RoundID).
+		Count(&count)
+
+	if result.Error != nil {
+		return nil, 0, result.Error
 	}
 
-	pairsCount := len(matches) - unpaired
-	participantsCount := (pairsCount*2 + unpaired)
+	if count == 0 {
+		return p.Participants, 0, nil
+	}
 
-	logger.Info("paired active participants for chat-roulette", "participants", participantsCount, "pairs", pairsCount, "unpaired", unpaired)
+	paired := db.Model(&models.Pairing{}).
+		Select("member_id").
+		Where("round_id = ?", p.RoundID)
 
-	trace.SpanFromContext(ctx).SetAttributes(
-		attribute.Int("participants", participantsCount),
-		attribute.Int("pairs", pairsCount),
-		attribute.Int("unpaired", unpaired),
-	)
+	query := db.WithContext(dbCtx).
+		Model(&models.Member{}).
+		Where("channel_id = ?", p.ChannelID).
+		Where("is_active = true").
+		Not("id IN (?)", paired)
 
-	// Queue a REPORT_MATCHES job
-	params := &ReportMatchesParams{
-		ChannelID:    p.ChannelID,
-		RoundID:      p.RoundID,
-		Participants: participantsCount,
-		Pairs:        pairsCount,
-		Unpaired:     unpaired,
+	if p.Participants != nil {
+		query = query.Where("user_id IN ?", p.Participants)
 	}
 
-	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
-	defer cancel()
+	var participants []string
+	if err := query.Pluck("user_id", &participants).Error; err != nil {
+		return nil, 0, err
+	}
 
-	if err := QueueReportMatchesJob(dbCtx, db, params); err != nil {
-		message := "failed to add REPORT_MATCHES job to the queue"
-		logger.Error(message, "error", err)
-		return errors.Wrap(err, message)
+	// An empty slice restricts matching to none of the members of the Slack channel
+	if participants == nil {
+		participants = []string{}
 	}
 
-	return nil
+	return participants, int(count), nil
 }
 
 // QueueCreateMatchesJob adds a new CREATE_MATCHES job to the queue.
-func QueueCreateMatchesJob(ctx context.Context, db *gorm.DB, p *CreateMatchesParams) error {
+// The job is not executed until its parent jobs, if any, have completed.
+func QueueCreateMatchesJob(ctx context.Context, db *gorm.DB, p *CreateMatchesParams, parentIDs ...int32) error {
 	job := models.GenericJob[*CreateMatchesParams]{
-		JobType:  models.JobTypeCreateMatches,
-		Priority: models.JobPriorityLow,
-		Params:   p,
+		JobType:   models.JobTypeCreateMatches,
+		Priority:  models.JobPriorityLow,
+		Params:    p,
+		ParentIDs: parentIDs,
 	}
 
 	return QueueJob(ctx, db, job)
 }
+
+// createPairedMatch creates a match between 2 members of a channel in a round of chat-roulette,
+// pairs the members in the match, and queues a CREATE_PAIR job for the match in a single transaction.
+//
+// A member can only be paired once in each round, so errAlreadyPaired is returned if
+// either member was already paired in the round, such as by a job running concurrently.
+func createPairedMatch(ctx context.Context, db *gorm.DB, channelID string, roundID int32, participant, partner string) (*models.Match, error) {
+	newMatch := &models.Match{
+		RoundID: roundID,
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	err := db.WithContext(dbCtx).Transaction(func(tx *gorm.DB) error {
+		var memberIDs []int32
+
+		result := tx.Model(&models.Member{}).
+			Select("id").
+			Where("channel_id = ?", channelID).
+			Where("user_id IN ?", []string{participant, partner}).
+			Find(&memberIDs)
+
+		if result.Error != nil {
+			return errors.Wrap(result.Error, "failed to retrieve member IDs")
+		}
+
+		if len(memberIDs) != 2 {
+			return errors.New("failed to retrieve member IDs: members not found")
+		}
+
+		if err := tx.Create(newMatch).Error; err != nil {
+			return errors.Wrap(err, "failed to create new match record in the database")
+		}
+
+		for _, id := range memberIDs {
+			pairing := &models.Pairing{
+				MatchID:  newMatch.ID,
+				MemberID: id,
+				RoundID:  &roundID,
+			}
+
+			if err := tx.Create(pairing).Error; err != nil {
+				if database.IsUniqueViolation(err) {
+					return errAlreadyPaired
+				}
+
+				return errors.Wrap(err, "failed to add a new pair record to the database")
+			}
+		}
+
+		params := &CreatePairParams{
+			MatchID:     newMatch.ID,
+			ChannelID:   channelID,
+			Participant: participant,
+			Partner:     partner,
+		}
+
+		if err := QueueCreatePairJob(ctx, tx, params); err != nil {
+			return errors.Wrap(err, "failed to add CREATE_PAIR job to the queue")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newMatch, nil
+}
@@ -132,6 +132,73 @@ func (s *CreateMatchesSuite) Test_CreateMatches() {
 	r.Equal(int64(1), count)
 }
 
+func (s *CreateMatchesSuite) Test_CreateMatches_Resume() {
+	r := require.New(s.T())
+
+	channelID := "C0123456789"
+
+	// Write channel to the database
+	s.db.Create(&models.Channel{
+		ChannelID:      channelID,
+		Inviter:        "U9876543210",
+		ConnectionMode: models.ConnectionModeVirtual,
+		Interval:       models.Biweekly,
+		Weekday:        time.Friday,
+		Hour:           12,
+		NextRound:      time.Now().Add(24 * time.Hour),
+	})
+
+	// Add members to the database
+	isActive := true
+	hasGenderPreference := false
+
+	for _, userID := range []string{"U0123456789", "U3234567890", "U7812309456", "U0487326159"} {
+		s.db.Create(&models.Member{
+			ChannelID:           channelID,
+			UserID:              userID,
+			Gender:              models.Male,
+			IsActive:            &isActive,
+			HasGenderPreference: &hasGenderPreference,
+		})
+	}
+
+	// Write a record in the rounds table
+	s.db.Create(&models.Round{
+		ChannelID: channelID,
+	})
+
+	// A previous attempt of the job paired two of the members before it failed
+	_, err := createPairedMatch(s.ctx, s.db, channelID, 1, "U0123456789", "U3234567890")
+	r.NoError(err)
+
+	// Test
+	err = CreateMatches(s.ctx, s.db, nil, &CreateMatchesParams{
+		ChannelID: channelID,
+		RoundID:   1,
+	})
+	r.NoError(err)
+	r.Contains(s.buffer.String(), "resuming matching for this round of chat-roulette")
+	r.Contains(s.buffer.String(), "participants=4")
+	r.Contains(s.buffer.String(), "pairs=2")
+	r.Contains(s.buffer.String(), "unpaired=0")
+
+	// Verify the remaining members were paired with each other
+	var count int64
+	result := s.db.Model(&models.Job{}).
+		Where("job_type = ?", models.JobTypeCreatePair).
+		Where(
+			s.db.Where(datatypes.JSONQuery("data").Equals("U7812309456", "participant")).
+				Or(datatypes.JSONQuery("data").Equals("U7812309456", "partner")),
+		).
+		Count(&count)
+	r.NoError(result.Error)
+	r.Equal(int64(1), count)
+
+	result = s.db.Model(&models.Job{}).Where("job_type = ?", models.JobTypeCreatePair).Count(&count)
+	r.NoError(result.Error)
+	r.Equal(int64(2), count)
+}
+
 func (s *CreateMatchesSuite) Test_CreateMatches_ConnectionModes() {
 	r := require.New(s.T())
 
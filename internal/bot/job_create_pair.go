@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
@@ -52,41 +53,49 @@ func CreatePair(ctx context.Context, db *gorm.DB, client *slack.Client, p *Creat
 		return errors.Wrap(result.Error, message)
 	}
 
-	// Create new records in the pairings table for the pair
-	for _, id := range memberIDs {
-		newPair := &models.Pairing{
-			MatchID:  p.MatchID,
-			MemberID: id,
+	// Create new records in the pairings table for the pair and queue a NOTIFY_PAIR job
+	// in a single transaction. The pairs of matches created by CREATE_MATCHES and
+	// CREATE_MATCH jobs already exist, since members are paired when their match is created.
+	dbCtx, cancel = context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	err := db.WithContext(dbCtx).Transaction(func(tx *gorm.DB) error {
+		for _, id := range memberIDs {
+			newPair := &models.Pairing{
+				MatchID:  p.MatchID,
+				MemberID: id,
+			}
+
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "match_id"}, {Name: "member_id"}},
+				DoNothing: true,
+			}).Create(newPair).Error
+
+			if err != nil {
+				return errors.Wrap(err, "failed to add a new pair record to the database")
+			}
 		}
 
-		dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
-		defer cancel()
+		params := &NotifyPairParams{
+			ChannelID:   p.ChannelID,
+			MatchID:     p.MatchID,
+			Participant: p.Participant,
+			Partner:     p.Partner,
+		}
 
-		if err := db.WithContext(dbCtx).Create(newPair).Error; err != nil {
-			message := "failed to add a new pair record to the database"
-			logger.Error(message, "error", result.Error)
-			return errors.Wrap(result.Error, message)
+		if err := QueueNotifyPairJob(ctx, tx, params); err != nil {
+			return errors.Wrap(err, "failed to add NOTIFY_PAIR job to the queue")
 		}
-	}
 
-	logger.Debug("created chat-roulette pair")
+		return nil
+	})
 
-	// Queue a NOTIFY_PAIR job
-	params := &NotifyPairParams{
-		ChannelID:   p.ChannelID,
-		MatchID:     p.MatchID,
-		Participant: p.Participant,
-		Partner:     p.Partner,
+	if err != nil {
+		logger.Error("failed to create chat-roulette pair", "error", err)
+		return err
 	}
 
-	dbCtx, cancel = context.WithTimeout(ctx, 500*time.Millisecond)
-	defer cancel()
-
-	if err := QueueNotifyPairJob(dbCtx, db, params); err != nil {
-		message := "failed to add NOTIFY_PAIR job to the queue"
-		logger.Error(message, "error", err)
-		return errors.Wrap(err, message)
-	}
+	logger.Debug("created chat-roulette pair")
 
 	logger.Info("queued NOTIFY_PAIR job for this match")
 
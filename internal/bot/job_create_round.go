@@ -18,9 +18,24 @@ type CreateRoundParams struct {
 	ChannelID string    `json:"channel_id"`
 	NextRound time.Time `json:"next_round"`
 	Interval  string
+	AdHoc     *AdHocRoundParams `json:"adhoc,omitempty"`
+}
+
+// AdHocRoundParams are the parameters for a one-off chat-roulette round
+// that is started outside of the channel's regular schedule.
+type AdHocRoundParams struct {
+	// EndsAt is when the ad-hoc round ends
+	EndsAt time.Time `json:"ends_at"`
+
+	// UserGroupID restricts the round to members of a Slack user group
+	UserGroupID string `json:"user_group_id,omitempty"`
+
+	// Participants restricts the round to a hand-picked list of Slack users
+	Participants []string `json:"participants,omitempty"`
 }
 
 // CreateRound adds a new chat roulette round for a Slack channel to the database.
+// The CREATE_ROUND job for the following round is queued by RECONCILE_SCHEDULES.
 func CreateRound(ctx context.Context, db *gorm.DB, client *slack.Client, p *CreateRoundParams) error {
 
 	logger := hclog.FromContext(ctx).With(attributes.SlackChannelID, p.ChannelID)
@@ -55,6 +70,24 @@ func CreateRound(ctx context.Context, db *gorm.DB, client *slack.Client, p *Crea
 		return nil
 	}
 
+	// Ad-hoc rounds do not disturb the regular schedule for the channel
+	if p.AdHoc != nil {
+		return createAdHocRound(ctx, db, client, p)
+	}
+
+	// The round and the jobs of its pipeline are created in a single transaction,
+	// so that a failed attempt does not leave a round behind without its jobs
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return startRegularRound(ctx, tx, p)
+	})
+}
+
+// startRegularRound starts the next chat-roulette round in the regular schedule
+// for a Slack channel and queues the jobs for the round.
+func startRegularRound(ctx context.Context, db *gorm.DB, p *CreateRoundParams) error {
+
+	logger := hclog.FromContext(ctx).With(attributes.SlackChannelID, p.ChannelID)
+
 	logger.Info("starting new chat-roulette round")
 
 	// Start a new chat-roulette round only if the last round has ended
@@ -66,7 +99,7 @@ func CreateRound(ctx context.Context, db *gorm.DB, client *slack.Client, p *Crea
 	defer cancel()
 
 	result := db.WithContext(dbCtx).
-		Where("channel_id = ? AND has_ended = false", newRound.ChannelID).
+		Where("channel_id = ? AND has_ended = false AND is_adhoc = false", newRound.ChannelID).
 		FirstOrCreate(newRound)
 
 	if result.Error != nil {
@@ -130,15 +163,109 @@ func CreateRound(ctx context.Context, db *gorm.DB, client *slack.Client, p *Crea
 		return errors.Wrap(result.Error, message)
 	}
 
-	// Queue a CREATE_ROUND job for the next round of chat-roulette
-	createRoundParams := &CreateRoundParams{
+	// Queue a SYNC_MEMBERS job before matching participants
+	syncMembersParams := &SyncMembersParams{
 		ChannelID: p.ChannelID,
-		NextRound: nextRound,
-		Interval:  p.Interval,
 	}
 
-	if err := QueueCreateRoundJob(ctx, db, createRoundParams); err != nil {
-		message := "failed to add CREATE_ROUND job to the queue"
+	syncMembersJob, err := queueSyncMembersJob(ctx, db, syncMembersParams)
+	if err != nil {
+		message := "failed to add SYNC_MEMBERS job to the queue"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	// Queue a CREATE_MATCHES job for the current round of chat roulette,
+	// which is executed once the members of the channel have been synced
+	createMatchesParams := &CreateMatchesParams{
+		ChannelID: p.ChannelID,
+		RoundID:   newRound.ID,
+	}
+
+	if err := QueueCreateMatchesJob(ctx, db, createMatchesParams, syncMembersJob.ID); err != nil {
+		message := "failed to add CREATE_MATCHES job to the queue"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	return nil
+}
+
+// createAdHocRound starts a one-off chat-roulette round for a Slack channel,
+// optionally restricted to a subset of the channel's members. Unlike regular
+// rounds, the next_round for the channel is left untouched and no CREATE_ROUND
+// job is queued for the following round.
+func createAdHocRound(ctx context.Context, db *gorm.DB, client *slack.Client, p *CreateRoundParams) error {
+
+	logger := hclog.FromContext(ctx).With(attributes.SlackChannelID, p.ChannelID)
+
+	logger.Info("starting new ad-hoc chat-roulette round", "ends_at", p.AdHoc.EndsAt)
+
+	// Resolve the participants for this round, if it is restricted to a subset of the channel
+	participants, err := resolveAdHocParticipants(ctx, client, p.AdHoc)
+	if err != nil {
+		message := "failed to resolve participants for ad-hoc chat-roulette round"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	if participants != nil && len(participants) < 2 {
+		logger.Warn("skipping ad-hoc chat-roulette round: not enough participants", "participants", len(participants))
+		return nil
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return startAdHocRound(ctx, tx, p, participants)
+	})
+}
+
+// startAdHocRound starts an ad-hoc chat-roulette round for the participants
+// of a Slack channel and queues the jobs for the round.
+func startAdHocRound(ctx context.Context, db *gorm.DB, p *CreateRoundParams, participants []string) error {
+
+	logger := hclog.FromContext(ctx).With(attributes.SlackChannelID, p.ChannelID)
+
+	endsAt := p.AdHoc.EndsAt
+
+	newRound := &models.Round{
+		ChannelID: p.ChannelID,
+		IsAdHoc:   true,
+		EndsAt:    &endsAt,
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	if err := db.WithContext(dbCtx).Create(newRound).Error; err != nil {
+		message := "failed to start new ad-hoc chat-roulette round"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	logger.Info("started new ad-hoc chat-roulette round", attributes.RoundID, newRound.ID)
+
+	// Queue an END_ROUND job for this specific round
+	endRoundParams := &EndRoundParams{
+		ChannelID: p.ChannelID,
+		NextRound: endsAt,
+		RoundID:   newRound.ID,
+	}
+
+	if err := QueueEndRoundJob(ctx, db, endRoundParams); err != nil {
+		message := "failed to add END_ROUND job to the queue"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	// Queue a REPORT_STATS job for this round
+	reportStatsParams := &ReportStatsParams{
+		ChannelID: p.ChannelID,
+		RoundID:   newRound.ID,
+		NextRound: endsAt,
+	}
+
+	if err := QueueReportStatsJob(ctx, db, reportStatsParams); err != nil {
+		message := "failed to add REPORT_STATS job to the queue"
 		logger.Error(message, "error", err)
 		return errors.Wrap(err, message)
 	}
@@ -148,19 +275,22 @@ func CreateRound(ctx context.Context, db *gorm.DB, client *slack.Client, p *Crea
 		ChannelID: p.ChannelID,
 	}
 
-	if err := QueueSyncMembersJob(ctx, db, syncMembersParams); err != nil {
+	syncMembersJob, err := queueSyncMembersJob(ctx, db, syncMembersParams)
+	if err != nil {
 		message := "failed to add SYNC_MEMBERS job to the queue"
 		logger.Error(message, "error", err)
 		return errors.Wrap(err, message)
 	}
 
-	// Queue a CREATE_MATCHES job for the current round of chat roulette
+	// Queue a CREATE_MATCHES job restricted to the participants of this round,
+	// which is executed once the members of the channel have been synced
 	createMatchesParams := &CreateMatchesParams{
-		ChannelID: p.ChannelID,
-		RoundID:   newRound.ID,
+		ChannelID:    p.ChannelID,
+		RoundID:      newRound.ID,
+		Participants: participants,
 	}
 
-	if err := QueueCreateMatchesJob(ctx, db, createMatchesParams); err != nil {
+	if err := QueueCreateMatchesJob(ctx, db, createMatchesParams, syncMembersJob.ID); err != nil {
 		message := "failed to add CREATE_MATCHES job to the queue"
 		logger.Error(message, "error", err)
 		return errors.Wrap(err, message)
@@ -169,6 +299,42 @@ func CreateRound(ctx context.Context, db *gorm.DB, client *slack.Client, p *Crea
 	return nil
 }
 
+// resolveAdHocParticipants returns the Slack users that an ad-hoc round is restricted to.
+// A nil slice is returned if the round is open to all members of the Slack channel.
+func resolveAdHocParticipants(ctx context.Context, client *slack.Client, p *AdHocRoundParams) ([]string, error) {
+	if p.UserGroupID == "" && len(p.Participants) == 0 {
+		return nil, nil
+	}
+
+	users := append([]string{}, p.Participants...)
+
+	if p.UserGroupID != "" {
+		slackCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		defer cancel()
+
+		members, err := client.GetUserGroupMembersContext(slackCtx, p.UserGroupID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to retrieve members of Slack user group")
+		}
+
+		users = append(users, members...)
+	}
+
+	// Remove duplicates while preserving order
+	seen := make(map[string]struct{}, len(users))
+	participants := make([]string, 0, len(users))
+
+	for _, u := range users {
+		if _, ok := seen[u]; ok {
+			continue
+		}
+		seen[u] = struct{}{}
+		participants = append(participants, u)
+	}
+
+	return participants, nil
+}
+
 // QueueCreateRoundJob adds a new CREATE_ROUND job to the queue.
 func QueueCreateRoundJob(ctx context.Context, db *gorm.DB, p *CreateRoundParams) error {
 	job := models.GenericJob[*CreateRoundParams]{
@@ -52,6 +52,8 @@ func QueueDeleteMemberJob(ctx context.Context, db *gorm.DB, p *DeleteMemberParam
 		JobType:  models.JobTypeDeleteMember,
 		Priority: models.JobPriorityHigh,
 		Params:   p,
+		// Slack retries events that were not acknowledged
+		IdempotencyKey: models.IdempotencyKey(models.JobTypeDeleteMember, p.ChannelID, p.UserID),
 	}
 
 	return QueueJob(ctx, db, job)
@@ -106,11 +106,12 @@ func (s *DeleteMemberSuite) Test_QueueDeleteMemberJob() {
 		UserID:    "U1111111111",
 	}
 
-	database.MockQueueJob(
+	database.MockQueueJobWithKey(
 		s.mock,
 		p,
 		models.JobTypeDeleteMember.String(),
 		models.JobPriorityHigh,
+		"DELETE_MEMBER:C0123456789:U1111111111",
 	)
 
 	err := QueueDeleteMemberJob(s.ctx, s.db, p)
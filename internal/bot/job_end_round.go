@@ -17,6 +17,8 @@ import (
 type EndRoundParams struct {
 	ChannelID string    `json:"channel_id"`
 	NextRound time.Time `json:"next_round"`
+	// RoundID is only set to end a specific ad-hoc round
+	RoundID int32 `json:"round_id,omitempty"`
 }
 
 // EndRound concludes a running chat-roulette round for a Slack channel.
@@ -32,11 +34,19 @@ func EndRound(ctx context.Context, db *gorm.DB, client *slack.Client, p *EndRoun
 	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
 	defer cancel()
 
-	result := db.WithContext(dbCtx).
+	query := db.WithContext(dbCtx).
 		Model(&models.Round{}).
 		Where("channel_id = ? ", p.ChannelID).
-		Where("has_ended = false").
-		Update("has_ended", true)
+		Where("has_ended = false")
+
+	// Ad-hoc rounds run alongside the regular schedule, so they are ended individually
+	if p.RoundID != 0 {
+		query = query.Where("id = ?", p.RoundID)
+	} else {
+		query = query.Where("is_adhoc = false")
+	}
+
+	result := query.Update("has_ended", true)
 
 	if result.Error != nil {
 		message := "failed to end current chat-roulette round"
@@ -59,6 +59,31 @@ func (s *EndRoundSuite) Test_EndRound() {
 	r.Contains(s.buffer.String(), "ended the current chat-roulette round")
 }
 
+func (s *EndRoundSuite) Test_EndRound_AdHoc() {
+	r := require.New(s.T())
+
+	p := &EndRoundParams{
+		ChannelID: "C030DJ523N3",
+		NextRound: time.Now().Add(24 * time.Hour),
+		RoundID:   5,
+	}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`UPDATE "rounds" SET "has_ended"=(.+),"updated_at"=(.+) WHERE channel_id = (.+) AND has_ended = false AND id = (.+)`).
+		WithArgs(
+			true,
+			database.AnyTime(),
+			p.ChannelID,
+			p.RoundID,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectCommit()
+
+	err := EndRound(s.ctx, s.db, nil, p)
+	r.NoError(err)
+	r.Contains(s.buffer.String(), "ended the current chat-roulette round")
+}
+
 func (s *EndRoundSuite) Test_QueueEndRoundJob() {
 	r := require.New(s.T())
 
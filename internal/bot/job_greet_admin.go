@@ -3,7 +3,6 @@ package bot
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/url"
 	"path"
@@ -17,6 +16,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
@@ -47,13 +47,16 @@ func GreetAdmin(ctx context.Context, db *gorm.DB, client *slack.Client, p *Greet
 		attributes.SlackUserID, p.Inviter,
 	)
 
-	// Render template
+	// Render template in the language of the admin,
+	// since the Slack channel has not been configured yet
 	t := greetAdminTemplate{
 		ChannelID: p.ChannelID,
 		UserID:    p.Inviter,
 	}
 
-	content, err := renderTemplate(greetAdminTemplateFilename, t)
+	lang := slackUserLanguage(ctx, client, p.Inviter)
+
+	content, err := renderTemplate(lang, greetAdminTemplateFilename, t)
 	if err != nil {
 		return errors.Wrap(err, "failed to render template")
 	}
@@ -103,6 +106,8 @@ func QueueGreetAdminJob(ctx context.Context, db *gorm.DB, p *GreetAdminParams) e
 		JobType:  models.JobTypeGreetAdmin,
 		Priority: models.JobPriorityHigh,
 		Params:   p,
+		// Slack retries events that were not acknowledged
+		IdempotencyKey: models.IdempotencyKey(models.JobTypeGreetAdmin, p.ChannelID),
 	}
 
 	return QueueJob(ctx, db, job)
@@ -126,11 +131,12 @@ func HandleGreetAdminButton(ctx context.Context, client *slack.Client, interacti
 			attribute.String(attributes.SlackActionID, string(interaction.ActionCallback.BlockActions[0].Type)),
 		)
 
-		// ChannelID and ResponseURL will be stored in the private_metadata field
+		// ChannelID, ResponseURL, and the language of the admin will be stored in the private_metadata field
 		pm := &privateMetadata{
 			ChannelID:   interaction.ActionCallback.BlockActions[0].Value,
 			ResponseURL: interaction.ResponseURL,
 			Blocks:      interaction.Message.Blocks,
+			Language:    slackUserLanguage(ctx, client, interaction.User.ID),
 		}
 
 		s, err := pm.Encode()
@@ -145,7 +151,7 @@ func HandleGreetAdminButton(ctx context.Context, client *slack.Client, interacti
 			IsAdmin:         true,
 		}
 
-		content, err := renderTemplate(onboardingModalTemplateFilename, t)
+		content, err := renderTemplate(pm.Language, onboardingModalTemplateFilename, t)
 		if err != nil {
 			return errors.Wrap(err, "failed to render template")
 		}
@@ -192,7 +198,7 @@ func RenderOnboardingChannelView(ctx context.Context, interaction *slack.Interac
 		ImageURL:        u.String(),
 	}
 
-	content, err := renderTemplate(onboardingChannelTemplateFilename, t)
+	content, err := renderTemplate(i18n.Resolve(pm.Language.String()), onboardingChannelTemplateFilename, t)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render template")
 	}
@@ -220,6 +226,8 @@ func UpsertChannelSettings(ctx context.Context, db *gorm.DB, interaction *slack.
 	datetime := interaction.View.State.Values["onboarding-channel-datetime"]["onboarding-channel-datetime"].SelectedDateTime
 	firstRound := time.Unix(datetime, 0).UTC()
 
+	language := interaction.View.State.Values["onboarding-channel-language"]["onboarding-channel-language"].SelectedOption.Value
+
 	// Schedule an ADD_CHANNEL job to onboard the new Slack channel
 	p := &AddChannelParams{
 		ChannelID:      pm.ChannelID,
@@ -229,6 +237,7 @@ func UpsertChannelSettings(ctx context.Context, db *gorm.DB, interaction *slack.
 		Weekday:        firstRound.Weekday().String(),
 		Hour:           firstRound.Hour(),
 		NextRound:      firstRound,
+		Language:       i18n.Resolve(language).String(),
 	}
 
 	if err := QueueAddChannelJob(ctx, db, p); err != nil {
@@ -253,14 +262,14 @@ func RespondGreetAdminWebhook(ctx context.Context, client *http.Client, interact
 		return errors.Wrap(err, "failed to decode base64 string to privateMetadata")
 	}
 
-	confirmationText := `*Chat Roulette is now enabled! I hope you enjoy using this app* :grin:`
+	confirmationText := i18n.T(pm.Language, "greet_admin.enabled")
 
 	text := slack.NewTextBlockObject("mrkdwn", confirmationText, false, false)
 	section := slack.NewSectionBlock(text, nil, nil)
 
 	deepLink := generateAppHomeDeepLink(interaction.Team.ID, interaction.APIAppID)
 
-	visitAppHomeText := fmt.Sprintf(":pushpin:  You can always visit me in <%s|App Home>", deepLink)
+	visitAppHomeText := i18n.T(pm.Language, "common.visit_app_home", deepLink)
 
 	element := slack.NewTextBlockObject("mrkdwn", visitAppHomeText, false, false)
 	contextBlock := slack.NewContextBlock("AppHome", element)
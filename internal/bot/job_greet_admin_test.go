@@ -21,6 +21,7 @@ import (
 
 	"github.com/chat-roulettte/chat-roulette/internal/database"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y"
 )
 
@@ -100,11 +101,12 @@ func (s *GreetAdminSuite) Test_GreetAdminJob() {
 		Inviter:   "U1111111111",
 	}
 
-	database.MockQueueJob(
+	database.MockQueueJobWithKey(
 		s.mock,
 		p,
 		models.JobTypeGreetAdmin.String(),
 		models.JobPriorityHigh,
+		"GREET_ADMIN:"+p.ChannelID,
 	)
 
 	err := QueueGreetAdminJob(s.ctx, s.db, p)
@@ -124,7 +126,7 @@ func Test_greetAdminTemplate(t *testing.T) {
 		UserID:    "U9876543210",
 	}
 
-	content, err := renderTemplate(greetAdminTemplateFilename, p)
+	content, err := renderTemplate(i18n.English, greetAdminTemplateFilename, p)
 	assert.Nil(t, err)
 
 	g.Assert(t, "greet_admin.json", []byte(content))
@@ -221,6 +223,11 @@ func Test_HandleGreetAdminButton(t *testing.T) {
 	assert.Nil(t, interaction.UnmarshalJSON(raw))
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users.info" {
+			w.Write([]byte(`{"ok": true, "user": {"id": "U0123456789", "locale": "fr-FR"}}`))
+			return
+		}
+
 		type openViewRequest struct {
 			TriggerID string                 `json:"trigger_id"`
 			View      slack.ModalViewRequest `json:"view"`
@@ -234,6 +241,7 @@ func Test_HandleGreetAdminButton(t *testing.T) {
 		assert.NotNil(t, request.View.PrivateMetadata)
 		var pm privateMetadata
 		assert.Nil(t, pm.Decode(request.View.PrivateMetadata))
+		assert.Equal(t, i18n.French, pm.Language)
 
 		w.Write([]byte(`{}`))
 	}))
@@ -302,6 +310,13 @@ b3N0L2FjdGlvbnMvYS9iL2MifQ==`,
 							SelectedDateTime: 1704362400,
 						},
 					},
+					"onboarding-channel-language": {
+						"onboarding-channel-language": slack.BlockAction{
+							SelectedOption: slack.OptionBlockObject{
+								Value: "fr",
+							},
+						},
+					},
 				},
 			},
 		},
@@ -319,6 +334,7 @@ b3N0L2FjdGlvbnMvYS9iL2MifQ==`,
 			Weekday:        time.Thursday.String(),
 			Hour:           10,
 			NextRound:      firstRound,
+			Language:       "fr",
 		},
 		models.JobTypeAddChannel.String(),
 		models.JobPriorityHighest,
@@ -20,6 +20,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/isx"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 	"github.com/chat-roulettte/chat-roulette/internal/templatex"
@@ -73,17 +74,23 @@ func GreetMember(ctx context.Context, db *gorm.DB, client *slack.Client, p *Gree
 		return errors.Wrap(err, message)
 	}
 
+	lang, err := memberLanguage(ctx, db, p.ChannelID, p.UserID)
+	if err != nil {
+		logger.Error("failed to retrieve the language of the Slack member", "error", err)
+		return err
+	}
+
 	// Render template
 	t := greetMemberTemplate{
 		ChannelID:      p.ChannelID,
 		Inviter:        channel.Inviter,
 		UserID:         p.UserID,
 		NextRound:      channel.NextRound,
-		When:           formatSchedule(channel.Interval, channel.NextRound),
+		When:           formatSchedule(lang, channel.Interval, channel.NextRound),
 		ConnectionMode: channel.ConnectionMode.String(),
 	}
 
-	content, err := renderTemplate(greetMemberTemplateFilename, t)
+	content, err := renderTemplate(lang, greetMemberTemplateFilename, t)
 	if err != nil {
 		return errors.Wrap(err, "failed to render template")
 	}
@@ -144,7 +151,7 @@ func QueueGreetMemberJob(ctx context.Context, db *gorm.DB, p *GreetMemberParams)
 // submission, a response is sent to Slack overwriting the button in the original message,
 // so that it cannot be clicked multiple times. Since this interaction only contains
 // a single button, we do not need to parse the action.
-func HandleGreetMemberButton(ctx context.Context, client *slack.Client, interaction *slack.InteractionCallback) error {
+func HandleGreetMemberButton(ctx context.Context, db *gorm.DB, client *slack.Client, interaction *slack.InteractionCallback) error {
 	// Start new span
 	tracer := otel.Tracer("")
 	ctx, span := tracer.Start(ctx, "handle.button.GREET_MEMBER")
@@ -156,11 +163,19 @@ func HandleGreetMemberButton(ctx context.Context, client *slack.Client, interact
 			attribute.String(attributes.SlackActionID, string(interaction.ActionCallback.BlockActions[0].Type)),
 		)
 
-		// ChannelID and ResponseURL will be stored in the private_metadata field
+		channelID := interaction.ActionCallback.BlockActions[0].Value
+
+		lang, err := memberLanguage(ctx, db, channelID, interaction.User.ID)
+		if err != nil {
+			return err
+		}
+
+		// ChannelID, ResponseURL, and the language of the member will be stored in the private_metadata field
 		pm := &privateMetadata{
-			ChannelID:   interaction.ActionCallback.BlockActions[0].Value,
+			ChannelID:   channelID,
 			ResponseURL: interaction.ResponseURL,
 			Blocks:      interaction.Message.Blocks,
+			Language:    lang,
 		}
 
 		s, err := pm.Encode()
@@ -175,7 +190,7 @@ func HandleGreetMemberButton(ctx context.Context, client *slack.Client, interact
 			IsAdmin:         false,
 		}
 
-		content, err := renderTemplate(onboardingModalTemplateFilename, t)
+		content, err := renderTemplate(pm.Language, onboardingModalTemplateFilename, t)
 		if err != nil {
 			return errors.Wrap(err, "failed to render template")
 		}
@@ -215,7 +230,7 @@ func RenderOnboardingLocationView(ctx context.Context, interaction *slack.Intera
 		ImageURL:        u.String(),
 	}
 
-	content, err := renderTemplate(onboardingLocationTemplateFilename, t)
+	content, err := renderTemplate(extractLanguageFromPrivateMetadata(interaction), onboardingLocationTemplateFilename, t)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render template")
 	}
@@ -291,7 +306,7 @@ func RenderOnboardingTimezoneView(ctx context.Context, interaction *slack.Intera
 		Zones:           country.Zones,
 	}
 
-	content, err := renderTemplate(onboardingTimezoneTemplateFilename, t)
+	content, err := renderTemplate(extractLanguageFromPrivateMetadata(interaction), onboardingTimezoneTemplateFilename, t)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render template")
 	}
@@ -360,7 +375,7 @@ func RenderOnboardingConnectionModeView(ctx context.Context, interaction *slack.
 		ImageURL:        u.String(),
 	}
 
-	content, err := renderTemplate(onboardingConnectionModeTemplateFilename, t)
+	content, err := renderTemplate(extractLanguageFromPrivateMetadata(interaction), onboardingConnectionModeTemplateFilename, t)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render template")
 	}
@@ -422,7 +437,7 @@ func RenderOnboardingGenderView(ctx context.Context, interaction *slack.Interact
 		ImageURL:        u.String(),
 	}
 
-	content, err := renderTemplate(onboardingGenderTemplateFilename, t)
+	content, err := renderTemplate(extractLanguageFromPrivateMetadata(interaction), onboardingGenderTemplateFilename, t)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render template")
 	}
@@ -490,7 +505,7 @@ func RenderOnboardingProfileView(ctx context.Context, interaction *slack.Interac
 		ImageURL:        u.String(),
 	}
 
-	content, err := renderTemplate(onboardingProfileTemplateFilename, t)
+	content, err := renderTemplate(extractLanguageFromPrivateMetadata(interaction), onboardingProfileTemplateFilename, t)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render template")
 	}
@@ -618,7 +633,7 @@ func RenderOnboardingCalendlyView(ctx context.Context, interaction *slack.Intera
 		ImageURL:        u.String(),
 	}
 
-	content, err := renderTemplate(onboardingCalendlyTemplateFilename, t)
+	content, err := renderTemplate(extractLanguageFromPrivateMetadata(interaction), onboardingCalendlyTemplateFilename, t)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render template")
 	}
@@ -695,7 +710,7 @@ func RespondGreetMemberWebhook(ctx context.Context, client *http.Client, interac
 	}
 
 	// Modify and append to the existing message
-	confirmationText := `*Thank you for choosing to participate in Chat Roulette!*`
+	confirmationText := i18n.T(pm.Language, "greet_member.thanks")
 	sectionBlock := slack.NewSectionBlock(
 		slack.NewTextBlockObject("mrkdwn", confirmationText, false, false),
 		nil,
@@ -704,7 +719,7 @@ func RespondGreetMemberWebhook(ctx context.Context, client *http.Client, interac
 
 	deepLink := generateAppHomeDeepLink(interaction.Team.ID, interaction.APIAppID)
 
-	visitAppHomeText := fmt.Sprintf(":pushpin:  You can always visit me in <%s|App Home>", deepLink)
+	visitAppHomeText := i18n.T(pm.Language, "common.visit_app_home", deepLink)
 
 	element := slack.NewTextBlockObject("mrkdwn", visitAppHomeText, false, false)
 	contextBlock := slack.NewContextBlock("AppHome", element)
@@ -23,6 +23,7 @@ import (
 
 	"github.com/chat-roulettte/chat-roulette/internal/database"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y"
 )
 
@@ -79,6 +80,8 @@ func (s *GreetMemberSuite) Test_GreetMember() {
 		).
 		WillReturnRows(sqlmock.NewRows(columns).AddRow(row...))
 
+	mockMemberLanguage(s.mock, p.ChannelID, p.UserID, "")
+
 	// Mock Slack API calls
 	mux := http.NewServeMux()
 
@@ -170,9 +173,9 @@ func Test_greetMemberTemplate(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			p.ConnectionMode = tc.connectionMode
-			p.When = formatSchedule(tc.interval, nextRound)
+			p.When = formatSchedule(i18n.English, tc.interval, nextRound)
 
-			content, err := renderTemplate(greetMemberTemplateFilename, p)
+			content, err := renderTemplate(i18n.English, greetMemberTemplateFilename, p)
 			assert.Nil(t, err)
 
 			g.Assert(t, tc.goldenFile, []byte(content))
@@ -289,6 +292,7 @@ func Test_HandleGreetMemberButton(t *testing.T) {
 		assert.NotNil(t, request.View.PrivateMetadata)
 		var pm privateMetadata
 		assert.Nil(t, pm.Decode(request.View.PrivateMetadata))
+		assert.Equal(t, i18n.French, pm.Language)
 
 		w.Write([]byte(`{}`))
 	}))
@@ -299,8 +303,13 @@ func Test_HandleGreetMemberButton(t *testing.T) {
 
 	interaction.ResponseURL = url
 
-	err := HandleGreetMemberButton(context.Background(), client, &interaction)
+	db, mock := database.NewMockedGormDB()
+
+	mockMemberLanguage(mock, "C0123456789", "U0123456789", "fr")
+
+	err := HandleGreetMemberButton(context.Background(), db, client, &interaction)
 	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
 func Test_RenderOnboardingLocationView(t *testing.T) {
@@ -3,18 +3,21 @@ package bot
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"strconv"
 	"time"
 
 	rand "math/rand/v2"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
@@ -29,6 +32,8 @@ type kickoffPairTemplate struct {
 	Partner             string
 	NoMessagesExchanged bool
 	Icebreaker          string
+	IcebreakerID        int32
+	MatchID             int32
 }
 
 // KickoffPairParams are the parameters for the KICKOFF_PAIR job.
@@ -60,6 +65,12 @@ func KickoffPair(ctx context.Context, db *gorm.DB, client *slack.Client, p *Kick
 		return errors.Wrap(err, message)
 	}
 
+	// The icebreaker was already shared with the pair by a previous attempt of this job
+	if match.IcebreakerID != nil {
+		logger.Info("skipping because an icebreaker was already shared with the pair")
+		return nil
+	}
+
 	// Retrieve the timestamp of NOTIFY_PAIR job to determine when the bot sent message to pair
 	var timestamp time.Time
 
@@ -100,16 +111,24 @@ func KickoffPair(ctx context.Context, db *gorm.DB, client *slack.Client, p *Kick
 
 	logger.Debug("retrieved chat history from the Slack Group DM", "messages", len(history.Messages))
 
-	// Get a random icebreaker question from the database
+	// Get an icebreaker question for the pair from the database
 	var icebreaker models.Icebreaker
 
 	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
 	defer cancel()
 
-	if err := db.WithContext(dbCtx).Model(&models.Icebreaker{}).Order("RANDOM()").First(&icebreaker).Error; err != nil {
-		message := "failed to retrieve random icebreaker from the DB"
-		logger.Error(message, "error", err)
-		return errors.Wrap(err, message)
+	result = db.WithContext(dbCtx).
+		Raw("SELECT * FROM GetIcebreakerV4(?, ?::VARCHAR[])", p.ChannelID, pq.StringArray{p.Participant, p.Partner}).
+		Scan(&icebreaker)
+
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = gorm.ErrRecordNotFound
+	}
+
+	if result.Error != nil {
+		message := "failed to retrieve icebreaker from the DB"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
 	}
 
 	// Pick a volunteer and share the icebreaker
@@ -119,13 +138,27 @@ func KickoffPair(ctx context.Context, db *gorm.DB, client *slack.Client, p *Kick
 		Partner:             p.Partner,
 		NoMessagesExchanged: true,
 		Icebreaker:          icebreaker.Question,
+		IcebreakerID:        icebreaker.ID,
+		MatchID:             p.MatchID,
 	}
 
 	if len(history.Messages) > 1 {
 		t.NoMessagesExchanged = false
 	}
 
-	content, err := renderTemplate(kickoffPairTemplateFilename, t)
+	override, err := loadChannelTemplate(ctx, db, p.ChannelID, kickoffPairTemplateFilename)
+	if err != nil {
+		logger.Error("failed to retrieve the channel's message template", "error", err)
+		return err
+	}
+
+	lang, err := pairLanguage(ctx, db, p.ChannelID, p.Participant, p.Partner)
+	if err != nil {
+		logger.Error("failed to retrieve the language of the pair", "error", err)
+		return err
+	}
+
+	content, err := renderChannelTemplate(ctx, lang, override, kickoffPairTemplateFilename, t)
 	if err != nil {
 		message := "failed to render template"
 		logger.Error(message, "error", err, "template", kickoffPairTemplateFilename)
@@ -155,32 +188,92 @@ func KickoffPair(ctx context.Context, db *gorm.DB, client *slack.Client, p *Kick
 		return errors.Wrap(err, message)
 	}
 
-	// Increment the counter for how many times this icebreaker has been used
-	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+	// Record the icebreaker for the match, so that it is not repeated for the pair or shared again
+	// by a retry of this job, and increment the counter for how many times it has been used
+	dbCtx, cancel = context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancel()
 
-	err = db.WithContext(dbCtx).Model(&models.Icebreaker{}).Where("id = ?", icebreaker.ID).
-		UpdateColumn("usage_count", gorm.Expr("usage_count + ?", 1)).Error
+	err = db.WithContext(dbCtx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Match{}).
+			Where("id = ?", p.MatchID).
+			UpdateColumn("icebreaker_id", icebreaker.ID)
+
+		if result.Error != nil {
+			return errors.Wrap(result.Error, "failed to update icebreaker_id for the match")
+		}
+
+		result = tx.Model(&models.Icebreaker{}).
+			Where("id = ?", icebreaker.ID).
+			UpdateColumn("usage_count", gorm.Expr("usage_count + ?", 1))
+
+		return errors.Wrap(result.Error, "failed to increment random icebreaker from the DB")
+	})
+
 	if err != nil {
-		message := "failed to increment random icebreaker from the DB"
-		logger.Error(message, "error", err)
+		logger.Error("failed to record the icebreaker for the match", "error", err)
 	}
 
 	return nil
 }
 
 // QueueKickoffPairJob adds a new KICKOFF_PAIR job to the queue.
-func QueueKickoffPairJob(ctx context.Context, db *gorm.DB, p *KickoffPairParams) error {
+func QueueKickoffPairJob(ctx context.Context, db *gorm.DB, p *KickoffPairParams, timestamp time.Time) error {
 	job := models.GenericJob[*KickoffPairParams]{
 		JobType:  models.JobTypeKickoffPair,
 		Priority: models.JobPriorityLow,
 		Params:   p,
-		ExecAt:   time.Now().UTC().Add(24 * time.Hour),
+		ExecAt:   timestamp,
 	}
 
 	return QueueJob(ctx, db, job)
 }
 
+type kickoffPairButtonValue struct {
+	MatchID      int32  `json:"match_id"`
+	IcebreakerID int32  `json:"icebreaker_id"`
+	IsPositive   bool   `json:"is_positive"`
+	Language     string `json:"language"`
+}
+
+// HandleKickoffPairButtons processes the webhook sent by Slack when a user clicks on
+// the thumbs up or thumbs down button in the message sent by the KICKOFF_PAIR job to
+// rate the icebreaker. The original message is left as is so that both participants
+// can rate the icebreaker, and an ephemeral response thanks the user for the feedback.
+func HandleKickoffPairButtons(ctx context.Context, client *http.Client, db *gorm.DB, interaction *slack.InteractionCallback) error {
+	if len(interaction.ActionCallback.BlockActions) == 0 {
+		return nil
+	}
+
+	var value kickoffPairButtonValue
+	if err := json.Unmarshal([]byte(interaction.ActionCallback.BlockActions[0].Value), &value); err != nil {
+		return errors.Wrap(err, "failed to unmarshal button value")
+	}
+
+	p := &RateIcebreakerParams{
+		MatchID:      value.MatchID,
+		IcebreakerID: value.IcebreakerID,
+		UserID:       interaction.User.ID,
+		IsPositive:   value.IsPositive,
+	}
+
+	if err := RateIcebreaker(ctx, db, p); err != nil {
+		return err
+	}
+
+	webhookMessage := &slack.WebhookMessage{
+		Text:            i18n.T(i18n.Resolve(value.Language), "kickoff_pair.thanks"),
+		ResponseType:    slack.ResponseTypeEphemeral,
+		ReplaceOriginal: false,
+	}
+
+	// Send HTTP response for the webhook
+	if err := slack.PostWebhookCustomHTTPContext(ctx, interaction.ResponseURL, client, webhookMessage); err != nil {
+		return errors.Wrap(err, "failed to send Slack webhook")
+	}
+
+	return nil
+}
+
 func selectRandomParticipant(p1, p2 string) string {
 	pair := []string{p1, p2}
 	randomIndex := rand.IntN(len(pair)) //nolint:gosec
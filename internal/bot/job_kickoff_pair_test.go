@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/hashicorp/go-hclog"
 	"github.com/ory/dockertest"
 	"github.com/sebdah/goldie/v2"
@@ -22,6 +23,7 @@ import (
 
 	"github.com/chat-roulettte/chat-roulette/internal/database"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y"
 )
 
@@ -38,9 +40,11 @@ func Test_kickoffPairTemplate(t *testing.T) {
 		Volunteer:           "U9876543210",
 		NoMessagesExchanged: true,
 		Icebreaker:          testIceBreaker,
+		IcebreakerID:        7,
+		MatchID:             42,
 	}
 
-	content, err := renderTemplate(kickoffPairTemplateFilename, p)
+	content, err := renderTemplate(i18n.English, kickoffPairTemplateFilename, p)
 	assert.Nil(t, err)
 
 	g.Assert(t, "kickoff_pair.json", []byte(content))
@@ -241,7 +245,7 @@ func (s *KickoffPairSuite) Test_QueueKickoffPairJob() {
 		models.JobPriorityLow,
 	)
 
-	err := QueueKickoffPairJob(s.ctx, db, p)
+	err := QueueKickoffPairJob(s.ctx, db, p, time.Now().UTC().Add(24*time.Hour))
 	r.NoError(err)
 	r.Contains(s.buffer.String(), "added new job to the database")
 
@@ -251,3 +255,67 @@ func (s *KickoffPairSuite) Test_QueueKickoffPairJob() {
 func Test_KickoffPair_suite(t *testing.T) {
 	suite.Run(t, new(KickoffPairSuite))
 }
+
+func Test_HandleKickoffPairButtons(t *testing.T) {
+	raw := []byte(`
+{
+    "user": {
+        "id": "U0123456789",
+        "username": "testuser",
+        "name": "testuser",
+        "team_id": "T0123456789"
+    },
+    "response_url": "REPLACE ME",
+    "actions": [
+        {
+            "type": "button",
+            "block_id": "Xd4ny",
+            "action_id": "KICKOFF_PAIR|up",
+            "text": {
+                "type": "plain_text",
+                "text": ":thumbsup:",
+                "emoji": true
+            },
+            "value": "{\"match_id\":42,\"icebreaker_id\":7,\"is_positive\":true}",
+            "action_ts": "1638032136.985353"
+        }
+    ]
+}
+`)
+
+	var interaction slack.InteractionCallback
+	assert.Nil(t, interaction.UnmarshalJSON(raw))
+
+	db, mock := database.NewMockedGormDB()
+
+	mock.ExpectQuery(`SELECT rounds.channel_id FROM "rounds"`).
+		WithArgs(42, 7, "U0123456789", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"channel_id"}).AddRow("C0123456789"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "icebreaker_ratings"`).
+		WithArgs(7, 42, "C0123456789", "U0123456789", true, database.AnyTime(), database.AnyTime()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	mock.ExpectExec(`INSERT INTO retired_icebreakers`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var webhook *slack.WebhookMessage
+
+		err := json.NewDecoder(r.Body).Decode(&webhook)
+		assert.Nil(t, err)
+		assert.Equal(t, slack.ResponseTypeEphemeral, webhook.ResponseType)
+		assert.False(t, webhook.ReplaceOriginal)
+		assert.Contains(t, webhook.Text, "Thanks for the feedback")
+	}))
+	defer server.Close()
+
+	interaction.ResponseURL = server.URL
+
+	err := HandleKickoffPairButtons(context.Background(), http.DefaultClient, db, &interaction)
+	assert.Nil(t, err)
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
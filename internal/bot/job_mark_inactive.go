@@ -14,6 +14,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
@@ -61,6 +62,13 @@ func MarkInactive(ctx context.Context, db *gorm.DB, client *slack.Client, p *Mar
 		return errors.Wrap(result.Error, message)
 	}
 
+	// Pairs with an external member of a Slack Connect channel are introduced
+	// in a DM instead of a Group DM, so their activity cannot be observed
+	if strings.HasPrefix(match.MpimID, "D") {
+		logger.Info("skipping because the pair was introduced in a Slack DM")
+		return nil
+	}
+
 	// Retrieve messages from the group chat
 	slackCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
@@ -103,7 +111,13 @@ func MarkInactive(ctx context.Context, db *gorm.DB, client *slack.Client, p *Mar
 		builder.WriteString(" ") // Space to avoid word merging
 	}
 
-	re := regexp.MustCompile("Did you get a chance to connect")
+	// The CHECK_PAIR message may have been sent in any of the supported languages
+	questions := i18n.Translations("check_pair.question")
+	for i, q := range questions {
+		questions[i] = regexp.QuoteMeta(strings.Trim(q, "*"))
+	}
+
+	re := regexp.MustCompile(strings.Join(questions, "|"))
 	found := re.FindAllStringIndex(builder.String(), -1)
 
 	switch len(found) {
@@ -126,23 +140,38 @@ func MarkInactive(ctx context.Context, db *gorm.DB, client *slack.Client, p *Mar
 
 		logger = logger.With(attributes.SlackUserID, memberID)
 
-		// Mark member as inactive in the database
-		if err := db.Model(&models.Member{}).Where("channel_id = ? AND user_id = ?", p.ChannelID, memberID).Update("is_active", false).Error; err != nil {
-			message := "failed to mark Slack user as inactive in the database"
-			logger.Error(message, "error", err)
-			return errors.Wrap(err, message)
+		// Skip members who are already inactive, such as members who were
+		// marked inactive by a previous attempt of this job, so that they
+		// are not counted or sent the Slack direct message twice
+		var isActive bool
+
+		dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+		defer cancel()
+
+		result := db.WithContext(dbCtx).
+			Model(&models.Member{}).
+			Select("is_active").
+			Where("channel_id = ? AND user_id = ?", p.ChannelID, memberID).
+			First(&isActive)
+
+		if result.Error != nil {
+			message := "failed to retrieve the status of the Slack user"
+			logger.Error(message, "error", result.Error)
+			return errors.Wrap(result.Error, message)
 		}
-		logger.Debug("marked Slack user as inactive in the database")
-		marked++
 
-		// Increment the counter of users marked as inactive
-		if err := db.Model(&models.Round{}).Where("id = ?", match.RoundID).UpdateColumn("inactive_users", gorm.Expr("inactive_users + ?", 1)).Error; err != nil {
-			message := "failed to increment the count of users marked inactive"
-			logger.Error(message, "error", err)
-			return errors.Wrap(err, message)
+		if !isActive {
+			logger.Debug("skipping Slack user who is already inactive")
+			continue
 		}
 
 		// Render the template for the Slack message
+		lang, err := memberLanguage(ctx, db, p.ChannelID, memberID)
+		if err != nil {
+			logger.Error("failed to retrieve the language of the Slack user", "error", err)
+			return err
+		}
+
 		t := markInactiveTemplate{
 			ChannelID: p.ChannelID,
 			UserID:    memberID,
@@ -150,7 +179,7 @@ func MarkInactive(ctx context.Context, db *gorm.DB, client *slack.Client, p *Mar
 			AppHome:   appHome,
 		}
 
-		content, err := renderTemplate(markInactiveTemplateFilename, t)
+		content, err := renderTemplate(lang, markInactiveTemplateFilename, t)
 		if err != nil {
 			return errors.Wrap(err, "failed to render template")
 		}
@@ -194,6 +223,41 @@ func MarkInactive(ctx context.Context, db *gorm.DB, client *slack.Client, p *Mar
 			logger.Error(message, "error", err)
 			return errors.Wrap(err, message)
 		}
+
+		// Mark member as inactive in the database and increment the counter of users
+		// marked as inactive in a single transaction, unless they are already inactive
+		dbCtx, cancel = context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancel()
+
+		err = db.WithContext(dbCtx).Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&models.Member{}).
+				Where("channel_id = ? AND user_id = ?", p.ChannelID, memberID).
+				Where("is_active = true").
+				Update("is_active", false)
+
+			if result.Error != nil {
+				return errors.Wrap(result.Error, "failed to mark Slack user as inactive in the database")
+			}
+
+			// The member was marked as inactive since they were checked above
+			if result.RowsAffected == 0 {
+				return nil
+			}
+
+			result = tx.Model(&models.Round{}).
+				Where("id = ?", match.RoundID).
+				UpdateColumn("inactive_users", gorm.Expr("inactive_users + ?", 1))
+
+			return errors.Wrap(result.Error, "failed to increment the count of users marked inactive")
+		})
+
+		if err != nil {
+			logger.Error("failed to mark Slack user as inactive", "error", err)
+			return err
+		}
+
+		logger.Debug("marked Slack user as inactive in the database")
+		marked++
 	}
 
 	logger.Debug("marked Slack user(s) as inactive in the database", "count", marked)
@@ -21,6 +21,7 @@ import (
 
 	"github.com/chat-roulettte/chat-roulette/internal/database"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y"
 )
 
@@ -36,7 +37,7 @@ func Test_markInactiveTemplate(t *testing.T) {
 		AppHome:   "slack://app?id=A1234567890&tab=home&team=T1234567890",
 	}
 
-	content, err := renderTemplate(markInactiveTemplateFilename, p)
+	content, err := renderTemplate(i18n.English, markInactiveTemplateFilename, p)
 	assert.Nil(t, err)
 
 	g.Assert(t, "mark_inactive.json", []byte(content))
@@ -51,6 +51,12 @@ func NotifyMember(ctx context.Context, db *gorm.DB, client *slack.Client, p *Not
 		return errors.Wrap(err, message)
 	}
 
+	lang, err := memberLanguage(ctx, db, p.ChannelID, p.UserID)
+	if err != nil {
+		logger.Error("failed to retrieve the language of the Slack user", "error", err)
+		return err
+	}
+
 	// Render template
 	t := notifyMemberTemplate{
 		ChannelID: p.ChannelID,
@@ -58,7 +64,7 @@ func NotifyMember(ctx context.Context, db *gorm.DB, client *slack.Client, p *Not
 		NextRound: channel.NextRound,
 	}
 
-	content, err := renderTemplate(notifyMemberTemplateFilename, t)
+	content, err := renderTemplate(lang, notifyMemberTemplateFilename, t)
 	if err != nil {
 		return errors.Wrap(err, "failed to render template")
 	}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/chat-roulettte/chat-roulette/internal/database"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y"
 )
 
@@ -78,6 +79,8 @@ func (s *NotifyMemberSuite) Test_NotifyMember() {
 		).
 		WillReturnRows(sqlmock.NewRows(columns).AddRow(row...))
 
+	mockMemberLanguage(s.mock, p.ChannelID, p.UserID, "")
+
 	// Mock Slack API calls
 	mux := http.NewServeMux()
 
@@ -154,7 +157,7 @@ func Test_notifyMemberTemplate(t *testing.T) {
 		NextRound: nextRound,
 	}
 
-	content, err := renderTemplate(notifyMemberTemplateFilename, p)
+	content, err := renderTemplate(i18n.English, notifyMemberTemplateFilename, p)
 	assert.Nil(t, err)
 
 	g.Assert(t, "notify_member.json", []byte(content))
@@ -11,8 +11,8 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
-	"github.com/chat-roulettte/chat-roulette/internal/timex"
 	"github.com/chat-roulettte/chat-roulette/internal/tzx"
 )
 
@@ -24,12 +24,46 @@ const (
 type notifyPairTemplate struct {
 	ChannelID           string
 	Interval            string
-	Participant         models.Member
+	Participant         memberView
 	ParticipantTimezone string
-	Partner             models.Member
+	Partner             memberView
 	PartnerTimezone     string
 	// Suggestion          string
 	ConnectionMode string
+	// ExternalPartner is set when the pair could not be added to a group DM
+	ExternalPartner string
+}
+
+// memberView is the profile of a member in templates/notify_pair.json.tmpl. Only the
+// fields shown in the message are available to the templates supplied by channel admins.
+type memberView struct {
+	UserID       string
+	Country      memberText
+	City         memberText
+	ProfileType  memberText
+	ProfileLink  memberText
+	CalendlyLink memberText
+}
+
+// newMemberView returns the profile of a member for templates/notify_pair.json.tmpl.
+func newMemberView(member models.Member) memberView {
+	return memberView{
+		UserID:       member.UserID,
+		Country:      memberText(member.Country.String()),
+		City:         memberText(member.City.String()),
+		ProfileType:  memberText(member.ProfileType.String()),
+		ProfileLink:  memberText(member.ProfileLink.String()),
+		CalendlyLink: memberText(member.CalendlyLink.String()),
+	}
+}
+
+// memberText is a profile field of a member in templates/notify_pair.json.tmpl.
+// The String method is kept for templates that were written when the
+// fields were the encrypted fields of models.Member.
+type memberText string
+
+func (t memberText) String() string {
+	return string(t)
 }
 
 // NotifyPairParams are the parameters for the NOTIFY_PAIR job.
@@ -63,6 +97,47 @@ func NotifyPair(ctx context.Context, db *gorm.DB, client *slack.Client, p *Notif
 		return errors.Wrap(err, message)
 	}
 
+	// Ad-hoc rounds end independently of the channel's regular schedule
+	var round models.Round
+
+	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	if err := db.WithContext(dbCtx).
+		Joins("JOIN matches ON matches.round_id = rounds.id").
+		Where("matches.id = ?", p.MatchID).
+		First(&round).Error; err != nil {
+		message := "failed to retrieve the round for the match"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	roundEnd := channel.NextRound
+	if round.IsAdHoc && round.EndsAt != nil {
+		roundEnd = *round.EndsAt
+	}
+
+	// The pair was already notified by a previous attempt of this job
+	var wasNotified bool
+
+	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	if err := db.WithContext(dbCtx).
+		Model(&models.Match{}).
+		Select("was_notified").
+		Where("id = ?", p.MatchID).
+		First(&wasNotified).Error; err != nil {
+		message := "failed to retrieve the match"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	if wasNotified {
+		logger.Info("skipping because the pair was already notified")
+		return nil
+	}
+
 	// Create a Slack Group DM with the pair of participants and update the database
 	childCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
@@ -76,10 +151,19 @@ func NotifyPair(ctx context.Context, db *gorm.DB, client *slack.Client, p *Notif
 			},
 		})
 
+	// Slack Connect users from another organization cannot always be added
+	// to a group DM by the bot, so fall back to separate DMs with each of them
+	var externalPartner string
 	if err != nil {
-		message := "failed to create Slack Group DM"
-		logger.Error(message, "error", err)
-		return errors.Wrap(err, message)
+		var fallbackErr error
+		response, externalPartner, fallbackErr = openExternalPairFallback(ctx, db, client, p)
+		if fallbackErr != nil {
+			message := "failed to create Slack Group DM"
+			logger.Error(message, "error", err)
+			return errors.Wrap(err, message)
+		}
+
+		logger.Info("created Slack DM with the internal participant of the pair", "external_partner", externalPartner)
 	}
 
 	mpimID := response.ID
@@ -122,14 +206,23 @@ func NotifyPair(ctx context.Context, db *gorm.DB, client *slack.Client, p *Notif
 	templateParams := notifyPairTemplate{
 		ChannelID:           p.ChannelID,
 		Interval:            channel.Interval.String(),
-		Participant:         participant,
+		Participant:         newMemberView(participant),
 		ParticipantTimezone: tzx.GetAbbreviatedTimezone(participant.Timezone.String()),
-		Partner:             partner,
+		Partner:             newMemberView(partner),
 		PartnerTimezone:     tzx.GetAbbreviatedTimezone(partner.Timezone.String()),
 		ConnectionMode:      channel.ConnectionMode.String(),
+		ExternalPartner:     externalPartner,
+	}
+
+	override, err := loadChannelTemplate(ctx, db, p.ChannelID, notifyPairTemplateFilename)
+	if err != nil {
+		logger.Error("failed to retrieve the channel's message template", "error", err)
+		return err
 	}
 
-	content, err := renderTemplate(notifyPairTemplateFilename, templateParams)
+	lang := sharedLanguage(channel.Language, participant.Language, partner.Language)
+
+	content, err := renderChannelTemplate(ctx, lang, override, notifyPairTemplateFilename, templateParams)
 	if err != nil {
 		message := "failed to render template"
 		logger.Error(message, "error", err, "template", notifyPairTemplateFilename)
@@ -160,113 +253,222 @@ func NotifyPair(ctx context.Context, db *gorm.DB, client *slack.Client, p *Notif
 		return errors.Wrap(err, message)
 	}
 
-	// Update the was_notified column for the record in the matches table
-	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
-	defer cancel()
-
-	result = db.WithContext(dbCtx).
-		Model(&models.Match{}).
-		Where("id = ?", p.MatchID).
-		Update("was_notified", true)
-
-	if result.Error != nil {
-		message := "failed to update was_notified for the match"
-		logger.Error(message, "error", result.Error)
-		return errors.Wrap(result.Error, message)
+	// The external partner could not be added to the DM, so they are sent
+	// the introduction separately and asked to reach out to the participant
+	if externalPartner != "" {
+		internalParticipant := p.Participant
+		if externalPartner == p.Participant {
+			internalParticipant = p.Partner
+		}
+
+		templateParams.ExternalPartner = internalParticipant
+
+		if err := notifyExternalPartner(ctx, client, lang, override, templateParams, externalPartner); err != nil {
+			logger.Warn("failed to send the introduction to the external partner", "error", err, "external_partner", externalPartner)
+		} else {
+			logger.Info("sent the introduction to the external partner in a Slack DM", "external_partner", externalPartner)
+		}
 	}
 
-	logger.Info("updated was_notified for the match")
+	// Mark the pair as notified and queue the reminder jobs for the match in a
+	// single transaction, so that a retry of this job neither notifies the pair
+	// again nor leaves the match without its reminder jobs
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Update the was_notified column for the record in the matches table
+		dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+		defer cancel()
+
+		result := tx.WithContext(dbCtx).
+			Model(&models.Match{}).
+			Where("id = ?", p.MatchID).
+			Update("was_notified", true)
+
+		if result.Error != nil {
+			message := "failed to update was_notified for the match"
+			logger.Error(message, "error", result.Error)
+			return errors.Wrap(result.Error, message)
+		}
+
+		logger.Info("updated was_notified for the match")
+
+		reminders := channel.Reminders
+		notifiedAt := time.Now().UTC()
+
+		// Queue a KICKOFF_PAIR job to share an icebreaker with the pair
+		if isEnabled(reminders.KickoffEnabled) {
+			kickoffPairParams := &KickoffPairParams{
+				ChannelID:   p.ChannelID,
+				MatchID:     p.MatchID,
+				Participant: p.Participant,
+				Partner:     p.Partner,
+			}
+
+			dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+			defer cancel()
+
+			if err := QueueKickoffPairJob(dbCtx, tx, kickoffPairParams, kickoffPairTime(reminders, notifiedAt)); err != nil {
+				message := "failed to add KICKOFF_PAIR job to the queue"
+				logger.Error(message, "error", err)
+				return errors.Wrap(err, message)
+			}
+
+			logger.Info("queued KICKOFF_PAIR job for this match", "after_hours", reminders.KickoffAfterHours)
+		}
+
+		params := &CheckPairParams{
+			ChannelID:   p.ChannelID,
+			MatchID:     p.MatchID,
+			Participant: p.Participant,
+			Partner:     p.Partner,
+			NextRound:   roundEnd,
+			MpimID:      mpimID,
+		}
+
+		// Queue a CHECK_PAIR job for the middle of the round
+		if isEnabled(reminders.MidRoundCheckInEnabled) {
+			timestamp, err := midRoundCheckInTime(reminders, notifiedAt, roundEnd)
+			if err != nil {
+				message := "failed to add CHECK_PAIR job to the queue"
+				logger.Error(message, "error", err)
+				return errors.Wrap(err, message)
+			}
+
+			params.IsMidRound = true
+
+			dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+			defer cancel()
+
+			if err := QueueCheckPairJob(dbCtx, tx, params, timestamp); err != nil {
+				message := "failed to add CHECK_PAIR job to the queue"
+				logger.Error(message, "error", err)
+				return errors.Wrap(err, message)
+			}
+
+			logger.Info("queued CHECK_PAIR job for this match to run in the middle of the round")
+		}
+
+		// Queue a CHECK_PAIR job for the end of the round
+		if isEnabled(reminders.EndRoundCheckInEnabled) {
+			params.IsMidRound = false
+
+			dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+			defer cancel()
+
+			if err := QueueCheckPairJob(dbCtx, tx, params, endRoundCheckInTime(reminders, roundEnd)); err != nil {
+				message := "failed to add CHECK_PAIR job to the queue"
+				logger.Error(message, "error", err)
+				return errors.Wrap(err, message)
+			}
+
+			logger.Info("queued CHECK_PAIR job for this match to run at the end of the round")
+		}
+
+		// Queue a MARK_INACTIVE job for the end of the round
+		if isEnabled(reminders.MarkInactiveEnabled) {
+			mParams := &MarkInactiveParams{
+				ChannelID: p.ChannelID,
+				NextRound: roundEnd,
+				MatchID:   p.MatchID,
+				Participants: []string{
+					p.Participant,
+					p.Partner,
+				},
+			}
+
+			dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+			defer cancel()
+
+			if err := QueueMarkInactiveJob(dbCtx, tx, mParams, markInactiveTime(reminders, roundEnd)); err != nil {
+				message := "failed to add MARK_INACTIVE job to the queue"
+				logger.Error(message, "error", err)
+				return errors.Wrap(err, message)
+			}
+
+			logger.Info("queued MARK_INACTIVE job for this match to run before the start of the next round")
+		}
+
+		return nil
+	})
+}
 
-	// Queue a KICKOFF_PAIR job for the middle of the round
-	kickoffPairParams := &KickoffPairParams{
-		ChannelID:   p.ChannelID,
-		MatchID:     p.MatchID,
-		Participant: p.Participant,
-		Partner:     p.Partner,
-	}
+// openExternalPairFallback opens a Slack DM with the internal participant of a pair
+// whose partner is an external member of a Slack Connect channel. The external
+// partner's user ID is returned alongside the DM.
+func openExternalPairFallback(ctx context.Context, db *gorm.DB, client *slack.Client, p *NotifyPairParams) (*slack.Channel, string, error) {
+	var external []string
 
-	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
 	defer cancel()
 
-	if err := QueueKickoffPairJob(dbCtx, db, kickoffPairParams); err != nil {
-		message := "failed to add KICKOFF_PAIR job to the queue"
-		logger.Error(message, "error", err)
-		return errors.Wrap(err, message)
-	}
-
-	logger.Info("queued KICKOFF_PAIR job for this match to run after 24 hours")
+	result := db.WithContext(dbCtx).
+		Model(&models.Member{}).
+		Where("channel_id = ?", p.ChannelID).
+		Where("user_id IN ?", []string{p.Participant, p.Partner}).
+		Where("is_external = true").
+		Pluck("user_id", &external)
 
-	// Queue a CHECK_PAIR job for the middle of the round
-	params := &CheckPairParams{
-		ChannelID:   p.ChannelID,
-		MatchID:     p.MatchID,
-		Participant: p.Participant,
-		Partner:     p.Partner,
-		NextRound:   channel.NextRound,
-		MpimID:      mpimID,
+	if result.Error != nil {
+		return nil, "", result.Error
 	}
 
-	midpoint, err := timex.MidPoint(time.Now().UTC(), channel.NextRound)
-	if err != nil {
-		message := "failed to add CHECK_PAIR job to the queue"
-		logger.Error(message, "error", err)
-		return errors.Wrap(err, message)
+	if len(external) != 1 {
+		return nil, "", errors.New("pair must have exactly one external member")
 	}
 
-	params.IsMidRound = true
+	internal := p.Participant
+	if external[0] == p.Participant {
+		internal = p.Partner
+	}
 
-	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+	slackCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	if err := QueueCheckPairJob(dbCtx, db, params, midpoint); err != nil {
-		message := "failed to add CHECK_PAIR job to the queue"
-		logger.Error(message, "error", err)
-		return errors.Wrap(err, message)
+	response, _, _, err := client.OpenConversationContext(slackCtx,
+		&slack.OpenConversationParameters{
+			ReturnIM: true,
+			Users:    []string{internal},
+		})
+	if err != nil {
+		return nil, "", err
 	}
 
-	logger.Info("queued CHECK_PAIR job for this match to run in the middle of the round")
-
-	// Queue a CHECK_PAIR job for the end of the round
-	params.IsMidRound = false
-
-	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
-	defer cancel()
-
-	timestamp := channel.NextRound.Add(-(18 * time.Hour)) // 18 hours before the round ends
+	return response, external[0], nil
+}
 
-	if err := QueueCheckPairJob(dbCtx, db, params, timestamp); err != nil {
-		message := "failed to add CHECK_PAIR job to the queue"
-		logger.Error(message, "error", err)
-		return errors.Wrap(err, message)
+// notifyExternalPartner sends the introduction to a pair in a Slack DM with the external partner,
+// who could not be added to a group DM with the internal participant of the pair.
+func notifyExternalPartner(ctx context.Context, client *slack.Client, lang i18n.Language, override string, params notifyPairTemplate, externalPartner string) error {
+	content, err := renderChannelTemplate(ctx, lang, override, notifyPairTemplateFilename, params)
+	if err != nil {
+		return errors.Wrap(err, "failed to render template")
 	}
 
-	logger.Info("queued CHECK_PAIR job for this match to run at the end of the round")
-
-	// Queue a MARK_INACTIVE job for the end of the round
-	mParams := &MarkInactiveParams{
-		ChannelID: p.ChannelID,
-		NextRound: channel.NextRound,
-		MatchID:   p.MatchID,
-		Participants: []string{
-			p.Participant,
-			p.Partner,
-		},
+	var view slack.View
+	if err := json.Unmarshal([]byte(content), &view); err != nil {
+		return errors.Wrap(err, "failed to marshal JSON")
 	}
 
-	params.IsMidRound = false
-
-	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+	slackCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	timestamp = channel.NextRound.Add(-(1 * time.Hour)) // 1 hour before the round ends
-
-	if err := QueueMarkInactiveJob(dbCtx, db, mParams, timestamp); err != nil {
-		message := "failed to add MARK_INACTIVE job to the queue"
-		logger.Error(message, "error", err)
-		return errors.Wrap(err, message)
+	response, _, _, err := client.OpenConversationContext(slackCtx,
+		&slack.OpenConversationParameters{
+			ReturnIM: true,
+			Users:    []string{externalPartner},
+		})
+	if err != nil {
+		return errors.Wrap(err, "failed to create Slack DM")
 	}
 
-	logger.Info("queued MARK_INACTIVE job for this match to run before the start of the next round")
+	if _, _, err = client.PostMessageContext(
+		slackCtx,
+		response.ID,
+		slack.MsgOptionBlocks(view.Blocks.BlockSet...),
+		slack.MsgOptionDisableLinkUnfurl(),
+		slack.MsgOptionDisableMediaUnfurl(),
+	); err != nil {
+		return errors.Wrap(err, "failed to send Slack DM")
+	}
 
 	return nil
 }
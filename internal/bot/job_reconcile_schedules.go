@@ -0,0 +1,210 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+)
+
+const (
+	// reconcileSchedulesInterval is how often the RECONCILE_SCHEDULES job runs
+	reconcileSchedulesInterval = 15 * time.Minute
+
+	// scheduleTolerance is the maximum allowed difference between the execution
+	// time of a pending CREATE_ROUND job and the next_round for the channel
+	scheduleTolerance = 1 * time.Minute
+)
+
+// ReconcileSchedulesParams are the parameters for the RECONCILE_SCHEDULES job.
+type ReconcileSchedulesParams struct{}
+
+// ReconcileSchedules ensures that every chat-roulette channel has exactly one
+// pending CREATE_ROUND job scheduled for the channel's next round. Missing jobs
+// are queued, and duplicate or mis-scheduled jobs are canceled.
+//
+// The next RECONCILE_SCHEDULES job is queued before reconciling, so that the schedules
+// are still reconciled if this job fails. Retries of this job queue the same next job.
+func ReconcileSchedules(ctx context.Context, db *gorm.DB, client *slack.Client, p *ReconcileSchedulesParams) error {
+
+	logger := hclog.FromContext(ctx)
+	span := trace.SpanFromContext(ctx)
+
+	// Queue the next RECONCILE_SCHEDULES job for the next slot. The current time is rounded
+	// so that a job executed slightly before its own slot does not collide with itself.
+	nextSlot := time.Now().UTC().Round(reconcileSchedulesInterval).Add(reconcileSchedulesInterval)
+
+	if err := QueueReconcileSchedulesJob(ctx, db, p, nextSlot); err != nil {
+		message := "failed to add RECONCILE_SCHEDULES job to the queue"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	logger.Info("reconciling chat-roulette schedules")
+
+	// Retrieve all chat-roulette channels
+	var channels []models.Channel
+
+	dbCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	if err := db.WithContext(dbCtx).Model(&models.Channel{}).Find(&channels).Error; err != nil {
+		message := "failed to retrieve Slack channels from the database"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	var queued, canceled int
+
+	for _, channel := range channels {
+		logger := logger.With(attributes.SlackChannelID, channel.ChannelID)
+
+		// Retrieve pending CREATE_ROUND jobs for regular rounds of this channel
+		jobs, err := pendingCreateRoundJobs(ctx, db, channel.ChannelID)
+		if err != nil {
+			message := "failed to retrieve pending CREATE_ROUND jobs"
+			logger.Error(message, "error", err)
+			return errors.Wrap(err, message)
+		}
+
+		// Keep the first job scheduled for the next round and cancel the rest
+		var keep *models.Job
+		var cancelIDs []int32
+
+		for i := range jobs {
+			job := &jobs[i]
+
+			diff := job.ExecAt.Sub(channel.NextRound).Abs()
+			if keep == nil && diff <= scheduleTolerance {
+				keep = job
+				continue
+			}
+
+			cancelIDs = append(cancelIDs, job.ID)
+		}
+
+		if len(cancelIDs) > 0 {
+			if err := cancelJobs(ctx, db, cancelIDs); err != nil {
+				message := "failed to cancel duplicate CREATE_ROUND jobs"
+				logger.Error(message, "error", err)
+				return errors.Wrap(err, message)
+			}
+
+			logger.Warn("canceled duplicate or mis-scheduled CREATE_ROUND jobs", "jobs", len(cancelIDs))
+
+			span.AddEvent("canceled CREATE_ROUND jobs", trace.WithAttributes(
+				attribute.String(attributes.SlackChannelID, channel.ChannelID),
+				attribute.Int("jobs", len(cancelIDs)),
+			))
+
+			canceled += len(cancelIDs)
+		}
+
+		if keep != nil {
+			continue
+		}
+
+		// Queue the missing CREATE_ROUND job for the next round
+		createRoundParams := &CreateRoundParams{
+			ChannelID: channel.ChannelID,
+			NextRound: channel.NextRound,
+			Interval:  channel.Interval.String(),
+		}
+
+		// The job is executed with the Slack client of the channel's workspace
+		channelCtx := ContextWithEnterpriseID(ContextWithTeamID(ctx, channel.TeamID), channel.EnterpriseID)
+
+		if err := QueueCreateRoundJob(channelCtx, db, createRoundParams); err != nil {
+			message := "failed to add CREATE_ROUND job to the queue"
+			logger.Error(message, "error", err)
+			return errors.Wrap(err, message)
+		}
+
+		logger.Warn("queued missing CREATE_ROUND job", "next_round", channel.NextRound)
+
+		span.AddEvent("queued missing CREATE_ROUND job", trace.WithAttributes(
+			attribute.String(attributes.SlackChannelID, channel.ChannelID),
+			attribute.String("next_round", channel.NextRound.String()),
+		))
+
+		queued++
+	}
+
+	logger.Info("reconciled chat-roulette schedules", "channels", len(channels), "queued", queued, "canceled", canceled)
+
+	span.SetAttributes(
+		attribute.Int("channels", len(channels)),
+		attribute.Int("queued", queued),
+		attribute.Int("canceled", canceled),
+	)
+
+	return nil
+}
+
+// QueueStartupReconcileSchedulesJob adds a new RECONCILE_SCHEDULES job to the queue to be
+// executed immediately when the app starts. Processes that start at the same time only
+// queue one job between them.
+func QueueStartupReconcileSchedulesJob(ctx context.Context, db *gorm.DB) error {
+	job := models.GenericJob[*ReconcileSchedulesParams]{
+		JobType:        models.JobTypeReconcileSchedules,
+		Priority:       models.JobPriorityLow,
+		Params:         &ReconcileSchedulesParams{},
+		ExecAt:         time.Now().UTC(),
+		IdempotencyKey: models.IdempotencyKey(models.JobTypeReconcileSchedules, "startup"),
+	}
+
+	return QueueJob(ctx, db, job)
+}
+
+// pendingCreateRoundJobs retrieves the pending CREATE_ROUND jobs for
+// regular rounds of a Slack channel, ordered by their execution time.
+func pendingCreateRoundJobs(ctx context.Context, db *gorm.DB, channelID string) ([]models.Job, error) {
+	var jobs []models.Job
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Model(&models.Job{}).
+		Where("job_type = ?", models.JobTypeCreateRound.String()).
+		Where("is_completed = false").
+		Where("data->>'channel_id' = ?", channelID).
+		Where("data->'adhoc' IS NULL").
+		Order("exec_at ASC").
+		Find(&jobs)
+
+	return jobs, result.Error
+}
+
+// cancelJobs marks the jobs with the given IDs as canceled.
+func cancelJobs(ctx context.Context, db *gorm.DB, ids []int32) error {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	return db.WithContext(dbCtx).
+		Model(&models.Job{}).
+		Where("id IN ?", ids).
+		Updates(&models.Job{IsCompleted: true, Status: models.JobStatusCanceled}).Error
+}
+
+// QueueReconcileSchedulesJob adds a new RECONCILE_SCHEDULES job to the queue.
+// Only one job is queued for each timestamp.
+func QueueReconcileSchedulesJob(ctx context.Context, db *gorm.DB, p *ReconcileSchedulesParams, timestamp time.Time) error {
+	job := models.GenericJob[*ReconcileSchedulesParams]{
+		JobType:        models.JobTypeReconcileSchedules,
+		Priority:       models.JobPriorityLow,
+		Params:         p,
+		ExecAt:         timestamp,
+		IdempotencyKey: models.IdempotencyKey(models.JobTypeReconcileSchedules, timestamp.UTC().Format(time.RFC3339)),
+	}
+
+	return QueueJob(ctx, db, job)
+}
@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y"
+)
+
+type ReconcileSchedulesSuite struct {
+	suite.Suite
+	ctx    context.Context
+	mock   sqlmock.Sqlmock
+	db     *gorm.DB
+	logger hclog.Logger
+	buffer *bytes.Buffer
+}
+
+func (s *ReconcileSchedulesSuite) SetupTest() {
+	s.logger, s.buffer = o11y.NewBufferedLogger()
+	s.ctx = hclog.WithContext(context.Background(), s.logger)
+	s.db, s.mock = database.NewMockedGormDB()
+}
+
+func (s *ReconcileSchedulesSuite) AfterTest(_, _ string) {
+	require.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *ReconcileSchedulesSuite) Test_ReconcileSchedules() {
+	r := require.New(s.T())
+
+	nextRound := time.Date(2030, time.January, 7, 12, 0, 0, 0, time.UTC)
+
+	// Queue the next RECONCILE_SCHEDULES job first
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(`INSERT INTO "jobs" (.+) VALUES (.+) ON CONFLICT DO NOTHING RETURNING`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectCommit()
+
+	s.mock.ExpectQuery(`SELECT \* FROM "channels"`).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"channel_id", "interval", "next_round"}).
+				AddRow("C0123456789", models.Weekly.String(), nextRound).
+				AddRow("C9876543210", models.Biweekly.String(), nextRound),
+		)
+
+	// First channel is missing its CREATE_ROUND job
+	s.mock.ExpectQuery(`SELECT \* FROM "jobs" WHERE job_type = (.+) AND is_completed = false AND data->>'channel_id' = (.+) AND data->'adhoc' IS NULL ORDER BY exec_at ASC`).
+		WithArgs(models.JobTypeCreateRound.String(), "C0123456789").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "exec_at"}))
+
+	database.MockQueueJob(
+		s.mock,
+		&CreateRoundParams{
+			ChannelID: "C0123456789",
+			NextRound: nextRound,
+			Interval:  models.Weekly.String(),
+		},
+		models.JobTypeCreateRound.String(),
+		models.JobPriorityStandard,
+	)
+
+	// Second channel has a duplicate CREATE_ROUND job for a stale next_round
+	s.mock.ExpectQuery(`SELECT \* FROM "jobs" WHERE job_type = (.+) AND is_completed = false AND data->>'channel_id' = (.+) AND data->'adhoc' IS NULL ORDER BY exec_at ASC`).
+		WithArgs(models.JobTypeCreateRound.String(), "C9876543210").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "exec_at"}).
+				AddRow(3, nextRound.AddDate(0, 0, -7)).
+				AddRow(4, nextRound),
+		)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`UPDATE "jobs" SET "status"=(.+),"is_completed"=(.+),"updated_at"=(.+) WHERE id IN (.+)`).
+		WithArgs(models.JobStatusCanceled, true, database.AnyTime(), 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectCommit()
+
+	err := ReconcileSchedules(s.ctx, s.db, nil, &ReconcileSchedulesParams{})
+	r.NoError(err)
+	r.Contains(s.buffer.String(), "queued missing CREATE_ROUND job")
+	r.Contains(s.buffer.String(), "canceled duplicate or mis-scheduled CREATE_ROUND jobs")
+	r.Contains(s.buffer.String(), "reconciled chat-roulette schedules: channels=2 queued=1 canceled=1")
+}
+
+func (s *ReconcileSchedulesSuite) Test_QueueReconcileSchedulesJob() {
+	r := require.New(s.T())
+
+	p := &ReconcileSchedulesParams{}
+	timestamp := time.Date(2030, time.January, 7, 12, 15, 0, 0, time.UTC)
+
+	database.MockQueueJobWithKey(
+		s.mock,
+		p,
+		models.JobTypeReconcileSchedules.String(),
+		models.JobPriorityLow,
+		"RECONCILE_SCHEDULES:2030-01-07T12:15:00Z",
+	)
+
+	err := QueueReconcileSchedulesJob(s.ctx, s.db, p, timestamp)
+	r.NoError(err)
+	r.Contains(s.buffer.String(), "added new job to the database")
+}
+
+func (s *ReconcileSchedulesSuite) Test_QueueStartupReconcileSchedulesJob() {
+	r := require.New(s.T())
+
+	database.MockQueueJobWithKey(
+		s.mock,
+		&ReconcileSchedulesParams{},
+		models.JobTypeReconcileSchedules.String(),
+		models.JobPriorityLow,
+		"RECONCILE_SCHEDULES:startup",
+	)
+
+	err := QueueStartupReconcileSchedulesJob(s.ctx, s.db)
+	r.NoError(err)
+	r.Contains(s.buffer.String(), "added new job to the database")
+}
+
+func Test_ReconcileSchedules_suite(t *testing.T) {
+	suite.Run(t, new(ReconcileSchedulesSuite))
+}
@@ -12,6 +12,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
@@ -128,6 +129,12 @@ func ReportMatches(ctx context.Context, db *gorm.DB, client *slack.Client, p *Re
 		PreferredHybrid:        stats.PreferredHybrid,
 	}
 
+	override, err := loadChannelTemplate(ctx, db, p.ChannelID, reportMatchesTemplateFilename)
+	if err != nil {
+		logger.Error("failed to retrieve the channel's message template", "error", err)
+		return err
+	}
+
 	var multiErr error
 
 	wg := new(sync.WaitGroup)
@@ -140,7 +147,7 @@ func ReportMatches(ctx context.Context, db *gorm.DB, client *slack.Client, p *Re
 		template := t
 		template.IsAdmin = true
 
-		content, err := renderTemplate(reportMatchesTemplateFilename, template)
+		content, err := renderChannelTemplate(ctx, i18n.Resolve(channel.Language), override, reportMatchesTemplateFilename, template)
 		if err != nil {
 			logger.Error("failed to render template", "error", err, "template", reportMatchesTemplateFilename)
 			multiErr = errors.Wrap(err, "failed to render template")
@@ -190,7 +197,7 @@ func ReportMatches(ctx context.Context, db *gorm.DB, client *slack.Client, p *Re
 		defer wg.Done()
 
 		// Render template
-		content, err := renderTemplate(reportMatchesTemplateFilename, t)
+		content, err := renderChannelTemplate(ctx, i18n.Resolve(channel.Language), override, reportMatchesTemplateFilename, t)
 		if err != nil {
 			logger.Error("failed to render template", "error", err, "template", reportMatchesTemplateFilename)
 			multiErr = errors.Wrap(err, "failed to render template")
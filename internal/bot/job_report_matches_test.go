@@ -23,6 +23,7 @@ import (
 
 	"github.com/chat-roulettte/chat-roulette/internal/database"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y"
 )
 
@@ -44,7 +45,7 @@ func Test_reportMatchesTemplate(t *testing.T) {
 		data.Unpaired = 1
 		data.Pairs = 25
 
-		content, err := renderTemplate(reportMatchesTemplateFilename, data)
+		content, err := renderTemplate(i18n.English, reportMatchesTemplateFilename, data)
 		assert.Nil(t, err)
 
 		g.Assert(t, "report_matches_admin.json", []byte(content))
@@ -63,7 +64,7 @@ func Test_reportMatchesTemplate(t *testing.T) {
 		data.PreferredInPerson = 30
 		data.PreferredHybrid = 11
 
-		content, err := renderTemplate(reportMatchesTemplateFilename, data)
+		content, err := renderTemplate(i18n.English, reportMatchesTemplateFilename, data)
 		assert.Nil(t, err)
 
 		g.Assert(t, "report_matches_admin_connection_mode.json", []byte(content))
@@ -79,7 +80,7 @@ func Test_reportMatchesTemplate(t *testing.T) {
 		data.Unpaired = 0
 		data.Pairs = 0
 
-		content, err := renderTemplate(reportMatchesTemplateFilename, data)
+		content, err := renderTemplate(i18n.English, reportMatchesTemplateFilename, data)
 		assert.Nil(t, err)
 
 		g.Assert(t, "report_matches_admin_zero.json", []byte(content))
@@ -90,7 +91,7 @@ func Test_reportMatchesTemplate(t *testing.T) {
 		data.Participants = 13
 		data.Pairs = 6
 
-		content, err := renderTemplate(reportMatchesTemplateFilename, data)
+		content, err := renderTemplate(i18n.English, reportMatchesTemplateFilename, data)
 		assert.Nil(t, err)
 
 		g.Assert(t, "report_matches_channel.json", []byte(content))
@@ -101,7 +102,7 @@ func Test_reportMatchesTemplate(t *testing.T) {
 		data.Participants = 1
 		data.Pairs = 0
 
-		content, err := renderTemplate(reportMatchesTemplateFilename, data)
+		content, err := renderTemplate(i18n.English, reportMatchesTemplateFilename, data)
 		assert.Nil(t, err)
 
 		g.Assert(t, "report_matches_channel_zero.json", []byte(content))
@@ -215,6 +216,15 @@ func (s *ReportMatchesSuite) Test_ReportMatches() {
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"men", "women", "has_gender_preference", "preferred_virtual", "preferred_in_person", "preferred_hybrid"}).AddRow([]driver.Value{5, 5, 0, 10, 0, 0}...))
 
+	// Mock message template lookup
+	s.mock.ExpectQuery(`SELECT \* FROM "message_templates" WHERE channel_id = (.+) AND name = (.+)`).
+		WithArgs(
+			channelID,
+			"report_matches",
+			1,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content"}))
+
 	p := &ReportMatchesParams{
 		ChannelID:    channelID,
 		RoundID:      int32(roundID),
@@ -76,7 +76,19 @@ func ReportStats(ctx context.Context, db *gorm.DB, client *slack.Client, p *Repo
 		Percent: percent,
 	}
 
-	content, err := renderTemplate(reportStatsTemplateFilename, t)
+	override, err := loadChannelTemplate(ctx, db, p.ChannelID, reportStatsTemplateFilename)
+	if err != nil {
+		logger.Error("failed to retrieve the channel's message template", "error", err)
+		return err
+	}
+
+	lang, err := ChannelLanguage(ctx, db, p.ChannelID)
+	if err != nil {
+		logger.Error("failed to retrieve the language of the Slack channel", "error", err)
+		return err
+	}
+
+	content, err := renderChannelTemplate(ctx, lang, override, reportStatsTemplateFilename, t)
 	if err != nil {
 		return errors.Wrap(err, "failed to render template")
 	}
@@ -20,6 +20,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y"
 )
 
@@ -90,6 +91,16 @@ func (s *ReportStatsSuite) Test_ReportStats() {
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"total", "met"}).FromCSVString("10,5"))
 
+	s.mock.ExpectQuery(`SELECT \* FROM "message_templates" WHERE channel_id = (.+) AND name = (.+)`).
+		WithArgs(
+			channelID,
+			"report_stats",
+			1,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content"}))
+
+	mockChannelLanguage(s.mock, channelID, "en")
+
 	err := ReportStats(s.ctx, s.db, client, p)
 	r.NoError(err)
 }
@@ -140,7 +151,7 @@ func Test_reportStatsTemplate(t *testing.T) {
 				Percent: tc.percent,
 			}
 
-			content, err := renderTemplate(reportStatsTemplateFilename, p)
+			content, err := renderTemplate(i18n.English, reportStatsTemplateFilename, p)
 			require.NoError(t, err)
 
 			if tc.name == "all met" {
@@ -21,6 +21,9 @@ type SyncChannelsParams struct {
 
 // SyncChannels ensures that there is no discrepancy between the Slack channels in
 // the database and the Slack channels that the bot is a member of.
+//
+// For an org-wide installation in an Enterprise Grid org, the Slack channels
+// of every workspace in the org that the app was granted to are synced.
 func SyncChannels(ctx context.Context, db *gorm.DB, client *slack.Client, p *SyncChannelsParams) error {
 
 	logger := hclog.FromContext(ctx)
@@ -42,7 +45,7 @@ func SyncChannels(ctx context.Context, db *gorm.DB, client *slack.Client, p *Syn
 	defer cancel()
 
 	var dbChannels []chatRouletteChannel
-	result := db.WithContext(ctx).Model(&models.Channel{}).Select("channel_id", "inviter").Find(&dbChannels)
+	result := db.WithContext(ctx).Model(&models.Channel{}).Scopes(scopeTeam(ctx)).Select("channel_id", "team_id", "inviter").Find(&dbChannels)
 	if result.Error != nil {
 		message := "failed to retrieve Slack channels from the database"
 		logger.Error(message, "error", result.Error)
@@ -59,6 +62,13 @@ func SyncChannels(ctx context.Context, db *gorm.DB, client *slack.Client, p *Syn
 			attributes.SlackChannelID, channel.ChannelID,
 		)
 
+		// Jobs for the channel of an org-wide installation are
+		// executed for the workspace of the channel in the org
+		ctx := ctx
+		if channel.TeamID != "" {
+			ctx = ContextWithTeamID(ctx, channel.TeamID)
+		}
+
 		switch {
 		case channel.Create:
 			// Skip scheduling GREET_ADMIN job if it has already been done
@@ -119,10 +129,19 @@ func SyncChannels(ctx context.Context, db *gorm.DB, client *slack.Client, p *Syn
 
 // QueueSyncChannelsJob adds a new SYNC_CHANNELS job to the queue.
 func QueueSyncChannelsJob(ctx context.Context, db *gorm.DB, p *SyncChannelsParams) error {
+	// Only one sync is needed at a time for each Slack workspace or Enterprise Grid org
+	key := models.IdempotencyKey(models.JobTypeSyncChannels)
+	if teamID := TeamIDFromContext(ctx); teamID != "" {
+		key = models.IdempotencyKey(models.JobTypeSyncChannels, teamID)
+	} else if enterpriseID := EnterpriseIDFromContext(ctx); enterpriseID != "" {
+		key = models.IdempotencyKey(models.JobTypeSyncChannels, enterpriseID)
+	}
+
 	job := models.GenericJob[*SyncChannelsParams]{
-		JobType:  models.JobTypeSyncChannels,
-		Priority: models.JobPriorityHighest,
-		Params:   p,
+		JobType:        models.JobTypeSyncChannels,
+		Priority:       models.JobPriorityHighest,
+		Params:         p,
+		IdempotencyKey: key,
 	}
 
 	return QueueJob(ctx, db, job)
@@ -134,11 +134,12 @@ func (s *SyncChannelsSuite) Test_SyncChannelsJob() {
 		BotUserID: "U1111111111",
 	}
 
-	database.MockQueueJob(
+	database.MockQueueJobWithKey(
 		s.mock,
 		p,
 		models.JobTypeSyncChannels.String(),
 		models.JobPriorityHighest,
+		"SYNC_CHANNELS",
 	)
 
 	err := QueueSyncChannelsJob(s.ctx, s.db, p)
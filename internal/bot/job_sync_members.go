@@ -11,6 +11,7 @@ import (
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+	"github.com/chat-roulettte/chat-roulette/internal/slackclient"
 )
 
 // SyncMembersParams are the parameters for the SYNC_MEMBERS job.
@@ -57,6 +58,44 @@ func SyncMembers(ctx context.Context, db *gorm.DB, client *slack.Client, p *Sync
 
 	logger.Debug("retrieved the list of members from the database", "members_count", len(dbMembers))
 
+	// Members added before external members were tracked are checked once,
+	// so that they are removed if the channel excludes external members
+	backfilled, err := backfillExternalMembers(ctx, db, client, p.ChannelID)
+	if err != nil {
+		message := "failed to backfill external members of the Slack channel"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	if backfilled > 0 {
+		logger.Info("backfilled external members of the Slack Connect channel", "members_count", backfilled)
+	}
+
+	// External members of a Slack Connect channel are removed
+	// if the channel excludes them from chat-roulette
+	var excludedMembers []string
+
+	dbCtx, cancel = context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result = db.WithContext(dbCtx).
+		Model(&models.Member{}).
+		Joins("JOIN channels ON channels.channel_id = members.channel_id").
+		Where("members.channel_id = ?", p.ChannelID).
+		Where("members.is_external AND NOT channels.include_external_members").
+		Pluck("members.user_id", &excludedMembers)
+
+	if result.Error != nil {
+		message := "failed to retrieve excluded external members from the database"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	if len(excludedMembers) > 0 {
+		slackMembers = excludeMembers(slackMembers, excludedMembers)
+		logger.Debug("excluded external members of the Slack Connect channel", "members_count", len(excludedMembers))
+	}
+
 	// Retrieve the userID of the chat-roulette bot
 	logger.Debug("retrieving the user ID of the Slack bot")
 
@@ -115,13 +154,112 @@ func SyncMembers(ctx context.Context, db *gorm.DB, client *slack.Client, p *Sync
 	return nil
 }
 
+// backfillExternalMembers checks if the members of a Slack channel that were added before
+// external members were tracked belong to another org, and updates their is_external flag.
+// The number of external members that were found is returned.
+func backfillExternalMembers(ctx context.Context, db *gorm.DB, client *slack.Client, channelID string) (int, error) {
+	var userIDs []string
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Model(&models.Member{}).
+		Where("channel_id = ?", channelID).
+		Where("NOT is_external_checked").
+		Pluck("user_id", &userIDs)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	teamID, err := getBotTeamID(ctx, client)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, userID := range userIDs {
+		isExternal, err := backfillExternalMember(ctx, db, client, channelID, userID, teamID)
+		if err != nil {
+			return count, err
+		}
+
+		if isExternal {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// backfillExternalMember checks if a member of a Slack channel belongs to another org
+// than the workspace of the channel and saves the result in the database.
+func backfillExternalMember(ctx context.Context, db *gorm.DB, client *slack.Client, channelID, userID, teamID string) (bool, error) {
+	slackCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	// The profile of a member from another org in a Slack Connect channel may be restricted
+	user, err := client.GetUserInfoContext(slackCtx, userID)
+	isExternal := slackclient.IsRestrictedUser(err)
+
+	if err != nil && !isExternal {
+		return false, err
+	}
+
+	if !isExternal {
+		isExternal = isExternalUser(user, teamID, EnterpriseIDFromContext(ctx))
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Model(&models.Member{}).
+		Where("channel_id = ? AND user_id = ?", channelID, userID).
+		Updates(map[string]interface{}{
+			"is_external":         isExternal,
+			"is_external_checked": true,
+		})
+
+	return isExternal, result.Error
+}
+
+// excludeMembers removes the given Slack users from the members of a Slack channel
+func excludeMembers(members []chatRouletteMember, userIDs []string) []chatRouletteMember {
+	excluded := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		excluded[userID] = true
+	}
+
+	var filtered []chatRouletteMember
+	for _, member := range members {
+		if !excluded[member.UserID] {
+			filtered = append(filtered, member)
+		}
+	}
+
+	return filtered
+}
+
 // QueueSyncMembersJob adds a new SYNC_MEMBERS job to the queue.
 func QueueSyncMembersJob(ctx context.Context, db *gorm.DB, p *SyncMembersParams) error {
+	_, err := queueSyncMembersJob(ctx, db, p)
+	return err
+}
+
+// queueSyncMembersJob adds a new SYNC_MEMBERS job to the queue and
+// returns it, so that it can be used as a parent of other jobs.
+func queueSyncMembersJob(ctx context.Context, db *gorm.DB, p *SyncMembersParams) (*models.Job, error) {
 	job := models.GenericJob[*SyncMembersParams]{
 		JobType:  models.JobTypeSyncMembers,
 		Priority: models.JobPriorityHigh,
 		Params:   p,
 	}
 
-	return QueueJob(ctx, db, job)
+	return queueJob(ctx, db, job)
 }
@@ -18,6 +18,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
@@ -152,7 +153,10 @@ func HandleUnblockMemberButton(ctx context.Context, baseURL string, db *gorm.DB,
 			BlockedMembers:  blockedMembersList,
 		}
 
-		content, err := renderTemplate(unblockMemberTemplateFilename, t)
+		// The language of the user is the value of the button in the App Home
+		lang := i18n.Resolve(interaction.ActionCallback.BlockActions[0].Value)
+
+		content, err := renderTemplate(lang, unblockMemberTemplateFilename, t)
 		if err != nil {
 			return errors.Wrap(err, "failed to render template")
 		}
@@ -5,11 +5,13 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 	"github.com/chat-roulettte/chat-roulette/internal/timex"
 )
@@ -22,6 +24,36 @@ type UpdateChannelParams struct {
 	Weekday        string    `json:"weekday"`
 	Hour           int       `json:"hour"`
 	NextRound      time.Time `json:"next_round"`
+
+	// Reminders are only updated when they are set
+	Reminders *ReminderSettingsParams `json:"reminders,omitempty"`
+
+	// IncludeExternalMembers is only updated when it is set
+	IncludeExternalMembers *bool `json:"include_external_members,omitempty"`
+
+	// Icebreakers are only updated when they are set
+	Icebreakers *IcebreakerSettingsParams `json:"icebreakers,omitempty"`
+
+	// Language is only updated when it is set
+	Language string `json:"language,omitempty"`
+}
+
+// IcebreakerSettingsParams are the settings for the icebreakers shared with pairs.
+type IcebreakerSettingsParams struct {
+	Categories   []string `json:"categories"`
+	RepeatRounds int      `json:"repeat_rounds"`
+}
+
+// ReminderSettingsParams are the settings for the reminders sent to pairs during a round.
+type ReminderSettingsParams struct {
+	KickoffEnabled         bool `json:"kickoff_enabled"`
+	KickoffAfterHours      int  `json:"kickoff_after_hours"`
+	MidRoundCheckInEnabled bool `json:"midround_checkin_enabled"`
+	MidRoundCheckInPercent int  `json:"midround_checkin_percent"`
+	EndRoundCheckInEnabled bool `json:"endround_checkin_enabled"`
+	EndRoundCheckInHours   int  `json:"endround_checkin_hours"`
+	MarkInactiveEnabled    bool `json:"mark_inactive_enabled"`
+	MarkInactiveHours      int  `json:"mark_inactive_hours"`
 }
 
 // UpdateChannel updates the settings for a chat-roulette enabled Slack channel.
@@ -52,6 +84,17 @@ func UpdateChannel(ctx context.Context, db *gorm.DB, client *slack.Client, p *Up
 		return err
 	}
 
+	var language string
+	if p.Language != "" {
+		lang, ok := i18n.Parse(p.Language)
+		if !ok {
+			err := errors.Errorf("%s is not a supported language", p.Language)
+			logger.Error("failed to parse language", "error", err)
+			return err
+		}
+		language = lang.String()
+	}
+
 	// Update the chat-roulette settings for the Slack channel
 	updatedChannel := &models.Channel{
 		ChannelID:      p.ChannelID,
@@ -60,6 +103,33 @@ func UpdateChannel(ctx context.Context, db *gorm.DB, client *slack.Client, p *Up
 		Weekday:        weekday,
 		Hour:           p.Hour,
 		NextRound:      p.NextRound,
+
+		IncludeExternalMembers: p.IncludeExternalMembers,
+		Language:               language,
+	}
+
+	if r := p.Reminders; r != nil {
+		updatedChannel.Reminders = models.ReminderSettings{
+			KickoffEnabled:         &r.KickoffEnabled,
+			KickoffAfterHours:      r.KickoffAfterHours,
+			MidRoundCheckInEnabled: &r.MidRoundCheckInEnabled,
+			MidRoundCheckInPercent: r.MidRoundCheckInPercent,
+			EndRoundCheckInEnabled: &r.EndRoundCheckInEnabled,
+			EndRoundCheckInHours:   r.EndRoundCheckInHours,
+			MarkInactiveEnabled:    &r.MarkInactiveEnabled,
+			MarkInactiveHours:      r.MarkInactiveHours,
+		}
+	}
+
+	if i := p.Icebreakers; i != nil {
+		// A non-nil array ensures that the categories can be cleared
+		categories := pq.StringArray{}
+		for _, category := range i.Categories {
+			categories = append(categories, NormalizeIcebreakerCategory(category))
+		}
+
+		updatedChannel.IcebreakerCategories = categories
+		updatedChannel.IcebreakerRepeatRounds = i.RepeatRounds
 	}
 
 	dbCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
@@ -108,6 +178,22 @@ func UpdateChannel(ctx context.Context, db *gorm.DB, client *slack.Client, p *Up
 		return errors.Wrap(err, message)
 	}
 
+	// Reschedule reminders for the current round using the updated settings
+	if p.Reminders != nil {
+		if err := rescheduleReminders(ctx, db, p.ChannelID); err != nil {
+			return err
+		}
+	}
+
+	// Sync members so that external members are added or removed
+	if p.IncludeExternalMembers != nil {
+		if err := QueueSyncMembersJob(ctx, db, &SyncMembersParams{ChannelID: p.ChannelID}); err != nil {
+			message := "failed to add SYNC_MEMBERS job to the queue"
+			logger.Error(message, "error", err)
+			return errors.Wrap(err, message)
+		}
+	}
+
 	return nil
 }
 
@@ -11,6 +11,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
@@ -28,9 +29,11 @@ type UpdateMemberParams struct {
 	CalendlyLink        sqlcrypter.EncryptedBytes `json:"calendly_link,omitempty"`
 	IsActive            *bool                     `json:"is_active,omitempty"`
 	HasGenderPreference *bool                     `json:"has_gender_preference,omitempty"`
+	Language            string                    `json:"language,omitempty"`
 }
 
-// UpdateMember updates the participation status for a member of a Slack channel.
+// UpdateMember updates the participation status for a member of a Slack channel,
+// and refreshes the member's App Home.
 func UpdateMember(ctx context.Context, db *gorm.DB, client *slack.Client, p *UpdateMemberParams) error {
 
 	logger := hclog.FromContext(ctx).With(
@@ -75,6 +78,16 @@ func UpdateMember(ctx context.Context, db *gorm.DB, client *slack.Client, p *Upd
 		member.Gender = v
 	}
 
+	if p.Language != "" {
+		v, ok := i18n.Parse(p.Language)
+		if !ok {
+			err := errors.Errorf("%s is not a supported language", p.Language)
+			logger.Error("failed to parse language", "error", err)
+			return err
+		}
+		member.Language = v.String()
+	}
+
 	dbCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancel()
 
@@ -92,6 +105,9 @@ func UpdateMember(ctx context.Context, db *gorm.DB, client *slack.Client, p *Upd
 
 	logger.Info("updated database row for the member")
 
+	// Show the member's new settings in their App Home
+	refreshAppHome(ctx, db, client, p.UserID)
+
 	return nil
 }
 
@@ -10,17 +10,47 @@ import (
 	"github.com/slack-go/slack"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
 
 // JobFunc is the function signature for all job functions.
 type JobFunc[T any] func(ctx context.Context, db *gorm.DB, client *slack.Client, p *T) error
 
+// slackEventIDContextKey is the context key for the ID of a Slack event
+type slackEventIDContextKey struct{}
+
+// ContextWithSlackEventID returns a copy of the context with the ID of the Slack event
+// that a request is for. Jobs queued with the context are only queued once for the event,
+// even if Slack retries its delivery after the jobs have completed.
+func ContextWithSlackEventID(ctx context.Context, eventID string) context.Context {
+	return context.WithValue(ctx, slackEventIDContextKey{}, eventID)
+}
+
+// SlackEventIDFromContext returns the ID of the Slack event in the context,
+// or an empty string if the context is not for a Slack event.
+func SlackEventIDFromContext(ctx context.Context) string {
+	eventID, _ := ctx.Value(slackEventIDContextKey{}).(string)
+	return eventID
+}
+
 // QueueJob is a generic function for adding a background job to the database job queue.
 func QueueJob[T any](ctx context.Context, db *gorm.DB, gJob models.GenericJob[T]) error {
+	_, err := queueJob(ctx, db, gJob)
+	return err
+}
+
+// queueJob adds a background job to the database job queue and returns it,
+// so that it can be used as a parent of other jobs. If the job is skipped
+// because an identical job is already pending, or was already queued for
+// the same Slack event, the existing job is returned instead.
+func queueJob[T any](ctx context.Context, db *gorm.DB, gJob models.GenericJob[T]) (*models.Job, error) {
 	// Start a new span
 	tracer := otel.Tracer("")
 	ctx, span := tracer.Start(ctx, "job.queue")
@@ -42,7 +72,7 @@ func QueueJob[T any](ctx context.Context, db *gorm.DB, gJob models.GenericJob[T]
 	data, err := json.Marshal(gJob.Params)
 	if err != nil {
 		logger.Error("failed to marshal JSON", "error", err)
-		return err
+		return nil, err
 	}
 
 	job := models.NewJob(gJob.JobType, data)
@@ -55,6 +85,40 @@ func QueueJob[T any](ctx context.Context, db *gorm.DB, gJob models.GenericJob[T]
 		job.ExecAt = gJob.ExecAt
 	}
 
+	if len(gJob.ParentIDs) > 0 {
+		job.ParentIDs = gJob.ParentIDs
+	}
+
+	// Jobs are executed with the Slack client of the workspace they were queued for
+	if teamID := TeamIDFromContext(ctx); teamID != "" {
+		job.TeamID = teamID
+	}
+
+	if enterpriseID := EnterpriseIDFromContext(ctx); enterpriseID != "" {
+		job.EnterpriseID = enterpriseID
+	}
+
+	// Store the trace context of the enqueueing span, so that the
+	// execution of the job can be followed in the same trace
+	if traceParent := injectTraceParent(ctx); traceParent != "" {
+		job.TraceParent = &traceParent
+	}
+
+	query := db
+	if gJob.IdempotencyKey != "" {
+		job.IdempotencyKey = &gJob.IdempotencyKey
+
+		// Skip the job if an identical job is already pending in the queue
+		query = db.Clauses(clause.OnConflict{DoNothing: true})
+	}
+
+	if eventID := SlackEventIDFromContext(ctx); eventID != "" {
+		job.SlackEventID = &eventID
+
+		// Skip the job if it was already queued for a previous delivery of the Slack event
+		query = db.Clauses(clause.OnConflict{DoNothing: true})
+	}
+
 	span.SetAttributes(
 		attribute.String(attributes.JobType, job.JobType.String()),
 		attribute.String(attributes.JobID, job.JobID.String()),
@@ -64,21 +128,67 @@ func QueueJob[T any](ctx context.Context, db *gorm.DB, gJob models.GenericJob[T]
 	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
 	defer cancel()
 
-	if err := db.WithContext(dbCtx).Create(job).Error; err != nil {
-		logger.Error("failed to add new job to the database", "error", err)
-		return err
+	result := query.WithContext(dbCtx).Create(job)
+	if result.Error != nil {
+		logger.Error("failed to add new job to the database", "error", result.Error)
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		logger.Info("skipped duplicate job", "idempotency_key", gJob.IdempotencyKey, "slack_event_id", SlackEventIDFromContext(ctx))
+		return existingJob(ctx, db, job)
 	}
 
 	logger.Info("added new job to the database", "job_id", job.JobID)
 
-	return nil
+	return job, nil
+}
+
+// existingJob retrieves the job that a duplicate job was skipped for.
+func existingJob(ctx context.Context, db *gorm.DB, job *models.Job) (*models.Job, error) {
+	logger := hclog.FromContext(ctx)
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	query := db.WithContext(dbCtx).Model(&models.Job{})
+
+	switch {
+	case job.SlackEventID != nil && job.IdempotencyKey != nil:
+		query = query.Where(
+			db.Where("job_type = ? AND slack_event_id = ?", job.JobType.String(), *job.SlackEventID).
+				Or("idempotency_key = ?", *job.IdempotencyKey),
+		)
+	case job.SlackEventID != nil:
+		query = query.Where("job_type = ? AND slack_event_id = ?", job.JobType.String(), *job.SlackEventID)
+	default:
+		query = query.Where("idempotency_key = ?", *job.IdempotencyKey)
+	}
+
+	var existing models.Job
+
+	if err := query.Order("id DESC").First(&existing).Error; err != nil {
+		logger.Error("failed to retrieve the existing job", "error", err)
+		return nil, err
+	}
+
+	return &existing, nil
 }
 
 // ExecJob is a generic function for executing job functions.
 func ExecJob[T any](ctx context.Context, db *gorm.DB, client *slack.Client, job *models.Job, f JobFunc[T]) error {
-	// Start a new span
+	// Start a new span as a child of the span that queued the job,
+	// linked to the span of the worker that is executing the job
+	var opts []trace.SpanStartOption
+	if job.TraceParent != nil {
+		if parent := extractTraceParent(*job.TraceParent); parent.IsValid() {
+			opts = append(opts, trace.WithLinks(trace.LinkFromContext(ctx)))
+			ctx = trace.ContextWithRemoteSpanContext(ctx, parent)
+		}
+	}
+
 	tracer := otel.Tracer("")
-	ctx, span := tracer.Start(ctx, "job.exec")
+	ctx, span := tracer.Start(ctx, "job.exec", opts...)
 	defer span.End()
 
 	span.SetAttributes(
@@ -88,7 +198,7 @@ func ExecJob[T any](ctx context.Context, db *gorm.DB, client *slack.Client, job
 	)
 
 	// Inject annotated logger into the context for the job function below
-	ctx = hclog.WithContext(ctx, hclog.FromContext(ctx).With(
+	ctx = hclog.WithContext(ctx, o11y.LoggerWithTrace(ctx, hclog.FromContext(ctx)).With(
 		attributes.JobType, job.JobType.String(),
 		attributes.JobID, job.JobID.String(),
 	))
@@ -132,3 +242,18 @@ func extractChannelIDFromParams[T any](p T) string {
 
 	return channelID
 }
+
+// injectTraceParent returns the W3C traceparent of the span in the context,
+// or an empty string if the context does not contain a valid span.
+func injectTraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// extractTraceParent returns the span context from a W3C traceparent.
+func extractTraceParent(traceParent string) trace.SpanContext {
+	carrier := propagation.MapCarrier{"traceparent": traceParent}
+	ctx := propagation.TraceContext{}.Extract(context.Background(), carrier)
+	return trace.SpanContextFromContext(ctx)
+}
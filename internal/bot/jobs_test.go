@@ -10,6 +10,9 @@ import (
 	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"gorm.io/gorm"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database"
@@ -60,6 +63,84 @@ func Test_QueueJob(t *testing.T) {
 	r.Equal(int64(1), count)
 }
 
+func Test_QueueJob_Duplicate(t *testing.T) {
+	r := require.New(t)
+
+	resource, databaseURL, err := database.NewTestPostgresDB(true)
+	r.NoError(err)
+	defer resource.Close()
+
+	db, err := database.NewGormDB(databaseURL)
+	r.NoError(err)
+
+	logger, buffer := o11y.NewBufferedLogger()
+	ctx := hclog.WithContext(context.Background(), logger)
+
+	p := &AddMemberParams{
+		ChannelID: "C0123456789",
+		UserID:    "U0123456789",
+	}
+
+	// A pending job with the same idempotency key is queued again
+	job := models.GenericJob[*AddMemberParams]{
+		JobType:        models.JobTypeAddMember,
+		Params:         p,
+		IdempotencyKey: models.IdempotencyKey(models.JobTypeAddMember, p.ChannelID, p.UserID),
+	}
+
+	first, err := queueJob(ctx, db, job)
+	r.NoError(err)
+
+	duplicate, err := queueJob(ctx, db, job)
+	r.NoError(err)
+	r.Equal(first.ID, duplicate.ID)
+	r.Contains(buffer.String(), "skipped duplicate job")
+
+	var count int64
+	result := db.Model(&models.Job{}).
+		Where("idempotency_key = ?", "ADD_MEMBER:C0123456789:U0123456789").
+		Count(&count)
+	r.NoError(result.Error)
+	r.Equal(int64(1), count)
+
+	// The job can be queued again once the pending job has completed
+	result = db.Model(&models.Job{}).
+		Where("idempotency_key = ?", "ADD_MEMBER:C0123456789:U0123456789").
+		Updates(map[string]interface{}{"status": models.JobStatusSucceeded, "is_completed": true})
+	r.NoError(result.Error)
+
+	r.NoError(QueueAddMemberJob(ctx, db, p))
+
+	result = db.Model(&models.Job{}).
+		Where("idempotency_key = ?", "ADD_MEMBER:C0123456789:U0123456789").
+		Count(&count)
+	r.NoError(result.Error)
+	r.Equal(int64(2), count)
+
+	// Slack retries the member_joined_channel event after its job has completed
+	eventCtx := ContextWithSlackEventID(ctx, "Ev0123456789")
+
+	result = db.Model(&models.Job{}).
+		Where("is_completed = false").
+		Updates(map[string]interface{}{"status": models.JobStatusSucceeded, "is_completed": true})
+	r.NoError(result.Error)
+
+	r.NoError(QueueAddMemberJob(eventCtx, db, p))
+
+	result = db.Model(&models.Job{}).
+		Where("slack_event_id = ?", "Ev0123456789").
+		Updates(map[string]interface{}{"status": models.JobStatusSucceeded, "is_completed": true})
+	r.NoError(result.Error)
+
+	r.NoError(QueueAddMemberJob(eventCtx, db, p))
+
+	result = db.Model(&models.Job{}).
+		Where("slack_event_id = ?", "Ev0123456789").
+		Count(&count)
+	r.NoError(result.Error)
+	r.Equal(int64(1), count)
+}
+
 func Test_ExecJob(t *testing.T) {
 	type Params struct {
 		Content string
@@ -80,6 +161,69 @@ func Test_ExecJob(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_ExecJob_TraceParent(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	// Simulate the span that queued the job
+	ctx, queueSpan := provider.Tracer("").Start(context.Background(), "job.queue")
+	traceParent := injectTraceParent(ctx)
+	queueSpan.End()
+
+	// Simulate the span of the worker executing the job
+	workerCtx, workerSpan := provider.Tracer("").Start(context.Background(), "worker.run")
+	defer workerSpan.End()
+
+	job := models.NewJob(models.JobTypeUpdateMember, []byte(`{}`))
+	job.TraceParent = &traceParent
+
+	jobFunc := func(ctx context.Context, db *gorm.DB, client *slack.Client, p *struct{}) error {
+		return nil
+	}
+
+	err := ExecJob(workerCtx, nil, nil, job, jobFunc)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	execSpan := spans[1]
+	assert.Equal(t, "job.exec", execSpan.Name())
+	assert.Equal(t, queueSpan.SpanContext().TraceID(), execSpan.SpanContext().TraceID())
+	assert.Equal(t, queueSpan.SpanContext().SpanID(), execSpan.Parent().SpanID())
+	require.Len(t, execSpan.Links(), 1)
+	assert.Equal(t, workerSpan.SpanContext().SpanID(), execSpan.Links()[0].SpanContext.SpanID())
+}
+
+func Test_injectTraceParent(t *testing.T) {
+	t.Run("valid span", func(t *testing.T) {
+		provider := sdktrace.NewTracerProvider()
+		ctx, span := provider.Tracer("").Start(context.Background(), "test")
+		defer span.End()
+
+		traceParent := injectTraceParent(ctx)
+		assert.NotEmpty(t, traceParent)
+
+		sc := extractTraceParent(traceParent)
+		assert.True(t, sc.IsValid())
+		assert.True(t, sc.IsRemote())
+		assert.Equal(t, span.SpanContext().TraceID(), sc.TraceID())
+		assert.Equal(t, span.SpanContext().SpanID(), sc.SpanID())
+	})
+
+	t.Run("no span", func(t *testing.T) {
+		assert.Empty(t, injectTraceParent(context.Background()))
+	})
+
+	t.Run("invalid traceparent", func(t *testing.T) {
+		assert.False(t, extractTraceParent("invalid").IsValid())
+	})
+}
+
 func Test_extractChannelIDFromParams(t *testing.T) {
 	t.Run("found", func(t *testing.T) {
 		channelID := "C0123456789"
@@ -0,0 +1,144 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
+)
+
+// ChannelLanguage retrieves the default language of the messages sent in a Slack channel.
+func ChannelLanguage(ctx context.Context, db *gorm.DB, channelID string) (i18n.Language, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var language string
+
+	result := db.WithContext(dbCtx).
+		Model(&models.Channel{}).
+		Select("language").
+		Where("channel_id = ?", channelID).
+		Scan(&language)
+
+	if result.Error != nil {
+		return i18n.DefaultLanguage, errors.Wrap(result.Error, "failed to retrieve the language of the Slack channel")
+	}
+
+	return i18n.Resolve(language), nil
+}
+
+// memberLanguage retrieves the language of the messages sent to a Slack user.
+//
+// This is the language of the user in the Slack channel, or in any other
+// chat-roulette channel, falling back to the default language of the Slack channel.
+func memberLanguage(ctx context.Context, db *gorm.DB, channelID, userID string) (i18n.Language, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var language sql.NullString
+
+	result := db.WithContext(dbCtx).Raw(`
+		SELECT COALESCE(
+			(
+				SELECT language FROM members
+				WHERE user_id = @user_id AND language IS NOT NULL
+				ORDER BY channel_id = @channel_id DESC
+				LIMIT 1
+			),
+			(SELECT language FROM channels WHERE channel_id = @channel_id)
+		)`,
+		sql.Named("user_id", userID),
+		sql.Named("channel_id", channelID),
+	).Scan(&language)
+
+	if result.Error != nil {
+		return i18n.DefaultLanguage, errors.Wrap(result.Error, "failed to retrieve the language of the Slack user")
+	}
+
+	return i18n.Resolve(language.String), nil
+}
+
+// slackUserLanguage returns the language that matches the locale of a Slack user,
+// or the default language if it cannot be retrieved or is not supported.
+func slackUserLanguage(ctx context.Context, client *slack.Client, userID string) i18n.Language {
+	slackCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	user, err := client.GetUserInfoContext(slackCtx, userID)
+	if err != nil {
+		return i18n.DefaultLanguage
+	}
+
+	if lang, ok := i18n.Parse(user.Locale); ok {
+		return lang
+	}
+
+	return i18n.DefaultLanguage
+}
+
+// pairLanguage retrieves the language of the messages sent to a chat-roulette pair.
+//
+// This is the language of the members if they share the same language,
+// or else the default language of the Slack channel.
+func pairLanguage(ctx context.Context, db *gorm.DB, channelID string, userIDs ...string) (i18n.Language, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var rows []struct {
+		MemberLanguage  sql.NullString
+		ChannelLanguage string
+	}
+
+	result := db.WithContext(dbCtx).Raw(`
+		SELECT
+			m.language AS member_language,
+			c.language AS channel_language
+		FROM channels c
+		LEFT JOIN members m ON m.channel_id = c.channel_id AND m.user_id IN @user_ids
+		WHERE c.channel_id = @channel_id`,
+		sql.Named("user_ids", userIDs),
+		sql.Named("channel_id", channelID),
+	).Scan(&rows)
+
+	if result.Error != nil {
+		return i18n.DefaultLanguage, errors.Wrap(result.Error, "failed to retrieve the languages of the chat-roulette pair")
+	}
+
+	if len(rows) == 0 {
+		return i18n.DefaultLanguage, nil
+	}
+
+	languages := make([]string, len(rows))
+	for i, row := range rows {
+		languages[i] = row.MemberLanguage.String
+	}
+
+	return sharedLanguage(rows[0].ChannelLanguage, languages...), nil
+}
+
+// sharedLanguage returns the language shared by all of the members of a Slack channel,
+// or else the default language of the channel. Members without a language use the
+// default language of the channel.
+func sharedLanguage(channelLanguage string, memberLanguages ...string) i18n.Language {
+	shared := ""
+
+	for _, l := range memberLanguages {
+		if l == "" {
+			l = channelLanguage
+		}
+
+		if shared != "" && l != shared {
+			return i18n.Resolve(channelLanguage)
+		}
+
+		shared = l
+	}
+
+	return i18n.Resolve(shared, channelLanguage)
+}
@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
+)
+
+// mockMemberLanguage mocks the query made by memberLanguage
+func mockMemberLanguage(mock sqlmock.Sqlmock, channelID, userID, language string) {
+	rows := sqlmock.NewRows([]string{"coalesce"})
+	if language != "" {
+		rows.AddRow(language)
+	} else {
+		rows.AddRow(nil)
+	}
+
+	mock.ExpectQuery(`SELECT COALESCE\(`).
+		WithArgs(userID, channelID, channelID).
+		WillReturnRows(rows)
+}
+
+// mockPairLanguage mocks the query made by pairLanguage
+func mockPairLanguage(mock sqlmock.Sqlmock, channelID, channelLanguage string, memberLanguages ...string) {
+	rows := sqlmock.NewRows([]string{"member_language", "channel_language"})
+	for _, l := range memberLanguages {
+		if l != "" {
+			rows.AddRow(l, channelLanguage)
+		} else {
+			rows.AddRow(nil, channelLanguage)
+		}
+	}
+
+	mock.ExpectQuery(`SELECT\s+m.language AS member_language`).
+		WillReturnRows(rows)
+}
+
+// mockChannelLanguage mocks the query made by ChannelLanguage
+func mockChannelLanguage(mock sqlmock.Sqlmock, channelID, language string) {
+	mock.ExpectQuery(`SELECT "language" FROM "channels" WHERE channel_id = \$1`).
+		WithArgs(channelID).
+		WillReturnRows(sqlmock.NewRows([]string{"language"}).AddRow(language))
+}
+
+func Test_ChannelLanguage(t *testing.T) {
+	r := require.New(t)
+
+	db, mock := database.NewMockedGormDB()
+
+	mockChannelLanguage(mock, "C0123456789", "de")
+
+	lang, err := ChannelLanguage(context.Background(), db, "C0123456789")
+	r.NoError(err)
+	r.Equal(i18n.German, lang)
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func Test_memberLanguage(t *testing.T) {
+	r := require.New(t)
+
+	db, mock := database.NewMockedGormDB()
+
+	mockMemberLanguage(mock, "C0123456789", "U0123456789", "es")
+
+	lang, err := memberLanguage(context.Background(), db, "C0123456789", "U0123456789")
+	r.NoError(err)
+	r.Equal(i18n.Spanish, lang)
+
+	mockMemberLanguage(mock, "C0123456789", "U0123456789", "")
+
+	lang, err = memberLanguage(context.Background(), db, "C0123456789", "U0123456789")
+	r.NoError(err)
+	r.Equal(i18n.English, lang)
+
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func Test_pairLanguage(t *testing.T) {
+	r := require.New(t)
+
+	db, mock := database.NewMockedGormDB()
+
+	mockPairLanguage(mock, "C0123456789", "en", "fr", "fr")
+
+	lang, err := pairLanguage(context.Background(), db, "C0123456789", "U0123456789", "U9876543210")
+	r.NoError(err)
+	r.Equal(i18n.French, lang)
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func Test_sharedLanguage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		channel  string
+		members  []string
+		expected i18n.Language
+	}{
+		{"same language", "en", []string{"fr", "fr"}, i18n.French},
+		{"different languages", "de", []string{"fr", "es"}, i18n.German},
+		{"channel language", "es", []string{"", "es"}, i18n.Spanish},
+		{"channel language differs", "es", []string{"", "fr"}, i18n.Spanish},
+		{"no members", "fr", nil, i18n.French},
+		{"no languages", "", []string{"", ""}, i18n.English},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, sharedLanguage(tc.channel, tc.members...))
+		})
+	}
+}
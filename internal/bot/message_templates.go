@@ -0,0 +1,430 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+)
+
+const (
+	// MaxMessageTemplateLength is the maximum length of a message template override
+	MaxMessageTemplateLength = 20000
+
+	// maxMessageBlocks is the maximum number of blocks that Slack accepts in a message
+	maxMessageBlocks = 50
+)
+
+var (
+	// ErrUnknownMessageTemplate is returned for a message template that cannot be customized
+	ErrUnknownMessageTemplate = errors.New("unknown message template")
+
+	// ErrInvalidMessageTemplate is returned when a message template does not render to valid Block Kit JSON
+	ErrInvalidMessageTemplate = errors.New("invalid message template")
+)
+
+// customizableTemplate is a Slack message template that can be overridden per channel
+type customizableTemplate struct {
+	Name        string
+	Filename    string
+	Title       string
+	Description string
+
+	// samples returns the data used to validate and preview the template.
+	// Templates with variants (eg, mid-round vs end of round) have a sample for each.
+	samples func(channelID string) []interface{}
+}
+
+// customizableTemplates are the Slack message templates that
+// channel admins can override, in the order they are sent in a round.
+var customizableTemplates = []customizableTemplate{
+	{
+		Name:        "report_matches",
+		Filename:    reportMatchesTemplateFilename,
+		Title:       "Round Kickoff Report",
+		Description: "Posted in the channel and sent to the admin when a new round starts",
+		samples: func(channelID string) []interface{} {
+			t := reportMatchesTemplate{
+				UserID:              "U0123456789",
+				ChannelID:           channelID,
+				NextRound:           time.Now().Add(14 * 24 * time.Hour),
+				Participants:        10,
+				Men:                 4,
+				Women:               6,
+				HasGenderPreference: 2,
+				Pairs:               5,
+			}
+
+			admin := t
+			admin.IsAdmin = true
+
+			return []interface{}{t, admin}
+		},
+	},
+	{
+		Name:        "notify_pair",
+		Filename:    notifyPairTemplateFilename,
+		Title:       "Introduction",
+		Description: "Sent to each pair to introduce them to each other",
+		samples: func(channelID string) []interface{} {
+			return []interface{}{
+				notifyPairTemplate{
+					ChannelID: channelID,
+					Interval:  models.Biweekly.String(),
+					Participant: memberView{
+						UserID:      "U0123456789",
+						Country:     "Kenya",
+						City:        "Nairobi",
+						ProfileType: "GitHub",
+						ProfileLink: "https://github.com/chat-roulettte",
+					},
+					ParticipantTimezone: "EAT (UTC+03:00)",
+					Partner: memberView{
+						UserID:      "U9876543210",
+						Country:     "United States",
+						City:        "Phoenix",
+						ProfileType: "LinkedIn",
+						ProfileLink: "https://www.linkedin.com/in/chat-roulettte",
+					},
+					PartnerTimezone: "MST (UTC-07:00)",
+					ConnectionMode:  models.ConnectionModeVirtual.String(),
+				},
+			}
+		},
+	},
+	{
+		Name:        "kickoff_pair",
+		Filename:    kickoffPairTemplateFilename,
+		Title:       "Icebreaker",
+		Description: "Sent to each pair with an icebreaker to get the conversation started",
+		samples: func(channelID string) []interface{} {
+			return []interface{}{
+				kickoffPairTemplate{
+					Volunteer:           "U0123456789",
+					Participant:         "U0123456789",
+					Partner:             "U9876543210",
+					NoMessagesExchanged: true,
+					Icebreaker:          "What's the best book you've read this year?",
+					IcebreakerID:        1,
+					MatchID:             1,
+				},
+			}
+		},
+	},
+	{
+		Name:        "check_pair",
+		Filename:    checkPairTemplateFilename,
+		Title:       "Check-in",
+		Description: "Sent to each pair in the middle and at the end of the round to ask if they met",
+		samples: func(channelID string) []interface{} {
+			t := checkPairTemplate{
+				Participant: "U0123456789",
+				Partner:     "U9876543210",
+				MatchID:     1,
+				IsMidRound:  true,
+			}
+
+			endOfRound := t
+			endOfRound.IsMidRound = false
+
+			return []interface{}{t, endOfRound}
+		},
+	},
+	{
+		Name:        "report_stats",
+		Filename:    reportStatsTemplateFilename,
+		Title:       "Round Ending Report",
+		Description: "Posted in the channel when a round ends",
+		samples: func(channelID string) []interface{} {
+			return []interface{}{
+				reportStatsTemplate{
+					Pairs:   5,
+					Met:     4,
+					Percent: 80,
+				},
+			}
+		},
+	},
+}
+
+// lookupCustomizableTemplate returns the customizable template with the given name.
+func lookupCustomizableTemplate(name string) (*customizableTemplate, error) {
+	for i := range customizableTemplates {
+		if customizableTemplates[i].Name == name {
+			return &customizableTemplates[i], nil
+		}
+	}
+
+	return nil, errors.Wrapf(ErrUnknownMessageTemplate, "%q", name)
+}
+
+// MessageTemplate is a Slack message template of a channel.
+type MessageTemplate struct {
+	Name        string
+	Title       string
+	Description string
+
+	// Content is the content of the channel's override, or the default template if there is none
+	Content string
+
+	// IsCustom is true if the channel overrides the template
+	IsCustom bool
+}
+
+// ListMessageTemplates lists the customizable Slack message templates for a channel.
+func ListMessageTemplates(ctx context.Context, db *gorm.DB, channelID string) ([]MessageTemplate, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var overrides []models.MessageTemplate
+
+	result := db.WithContext(dbCtx).
+		Where("channel_id = ?", channelID).
+		Find(&overrides)
+
+	if result.Error != nil {
+		return nil, errors.Wrap(result.Error, "failed to retrieve message templates")
+	}
+
+	contents := make(map[string]string, len(overrides))
+	for _, o := range overrides {
+		contents[o.Name] = o.Content
+	}
+
+	list := make([]MessageTemplate, 0, len(customizableTemplates))
+
+	for _, c := range customizableTemplates {
+		b, err := tmplFS.ReadFile("templates/" + c.Filename)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read message template")
+		}
+
+		t := MessageTemplate{
+			Name:        c.Name,
+			Title:       c.Title,
+			Description: c.Description,
+			Content:     string(b),
+		}
+
+		if content, ok := contents[c.Name]; ok {
+			t.Content = content
+			t.IsCustom = true
+		}
+
+		list = append(list, t)
+	}
+
+	return list, nil
+}
+
+// PreviewMessageTemplate validates the content of a Slack message template by rendering
+// it with sample data for each of its variants, and returns the first rendering in the language.
+func PreviewMessageTemplate(lang i18n.Language, channelID, name, content string) (string, error) {
+	c, err := lookupCustomizableTemplate(name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(content) > MaxMessageTemplateLength {
+		return "", errors.Wrapf(ErrInvalidMessageTemplate, "must be no more than %d characters", MaxMessageTemplateLength)
+	}
+
+	var preview string
+
+	for _, data := range c.samples(channelID) {
+		rendered, err := renderContent(lang, name, content, data)
+		if err != nil {
+			return "", err
+		}
+
+		if preview == "" {
+			preview = rendered
+		}
+	}
+
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(preview), "", "  "); err != nil {
+		return "", errors.Wrap(err, "failed to indent JSON")
+	}
+
+	return b.String(), nil
+}
+
+// SaveMessageTemplate validates and saves the override of a Slack message template for a channel.
+func SaveMessageTemplate(ctx context.Context, db *gorm.DB, channelID, name, content string) error {
+	logger := hclog.FromContext(ctx).With(
+		attributes.SlackChannelID, channelID,
+		"template", name,
+	)
+
+	// The messages of a template are valid in every language if they are valid in one
+	if _, err := PreviewMessageTemplate(i18n.DefaultLanguage, channelID, name, content); err != nil {
+		return err
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "channel_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"content", "updated_at"}),
+		}).
+		Create(&models.MessageTemplate{
+			ChannelID: channelID,
+			Name:      name,
+			Content:   content,
+		})
+
+	if result.Error != nil {
+		message := "failed to save message template"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	logger.Info("saved message template for the Slack channel")
+
+	return nil
+}
+
+// ResetMessageTemplate deletes the override of a Slack message template for a channel,
+// so that the default template is used again.
+func ResetMessageTemplate(ctx context.Context, db *gorm.DB, channelID, name string) error {
+	logger := hclog.FromContext(ctx).With(
+		attributes.SlackChannelID, channelID,
+		"template", name,
+	)
+
+	if _, err := lookupCustomizableTemplate(name); err != nil {
+		return err
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Where("channel_id = ?", channelID).
+		Where("name = ?", name).
+		Delete(&models.MessageTemplate{})
+
+	if result.Error != nil {
+		message := "failed to reset message template"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	logger.Info("reset message template for the Slack channel")
+
+	return nil
+}
+
+// loadChannelTemplate retrieves the channel's override of a Slack message template.
+// An empty string is returned if the channel uses the default template.
+func loadChannelTemplate(ctx context.Context, db *gorm.DB, channelID, filename string) (string, error) {
+	var name string
+	for _, c := range customizableTemplates {
+		if c.Filename == filename {
+			name = c.Name
+		}
+	}
+
+	if name == "" {
+		return "", nil
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var override models.MessageTemplate
+
+	result := db.WithContext(dbCtx).
+		Where("channel_id = ?", channelID).
+		Where("name = ?", name).
+		Limit(1).
+		Find(&override)
+
+	if result.Error != nil {
+		return "", errors.Wrap(result.Error, "failed to retrieve message template")
+	}
+
+	return override.Content, nil
+}
+
+// renderChannelTemplate renders the channel's override of a Slack message template in the language,
+// falling back to the default template if there is no override or if the override
+// does not render to valid Block Kit JSON.
+func renderChannelTemplate(ctx context.Context, lang i18n.Language, override, filename string, data interface{}) (string, error) {
+	if override != "" {
+		content, err := renderContent(lang, filename, override, data)
+		if err == nil {
+			return content, nil
+		}
+
+		hclog.FromContext(ctx).Warn("falling back to the default message template", "error", err, "template", filename)
+	}
+
+	return renderTemplate(lang, filename, data)
+}
+
+// renderContent renders the content of a Slack message template in the language
+// and validates that the result is a valid Block Kit message.
+func renderContent(lang i18n.Language, name, content string, data interface{}) (string, error) {
+	t, err := newUntrustedTemplate(name, lang).Parse(content)
+	if err != nil {
+		return "", errors.Wrapf(ErrInvalidMessageTemplate, "failed to parse: %s", err)
+	}
+
+	b, err := executeUntrusted(t, data)
+	if err != nil {
+		return "", errors.Wrapf(ErrInvalidMessageTemplate, "failed to render: %s", err)
+	}
+
+	if err := validateBlockKit(b); err != nil {
+		return "", errors.Wrapf(ErrInvalidMessageTemplate, "%s", err)
+	}
+
+	return string(b), nil
+}
+
+// validateBlockKit validates that a rendered template is a Block Kit message
+func validateBlockKit(content []byte) error {
+	var msg slackMessage
+	if err := json.Unmarshal(content, &msg); err != nil {
+		return fmt.Errorf("not valid JSON: %s", err)
+	}
+
+	blocks := msg.Blocks.BlockSet
+
+	if len(blocks) == 0 {
+		return fmt.Errorf("must have at least 1 block")
+	}
+
+	for _, attachment := range msg.Attachments {
+		if attachment.Blocks.BlockSet != nil {
+			blocks = append(blocks, attachment.Blocks.BlockSet...)
+		}
+	}
+
+	if len(blocks) > maxMessageBlocks {
+		return fmt.Errorf("must have no more than %d blocks", maxMessageBlocks)
+	}
+
+	for i, block := range blocks {
+		if _, ok := block.(*slack.UnknownBlock); ok {
+			return fmt.Errorf("block %d: unknown block type %q", i+1, block.BlockType())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y"
+)
+
+func Test_PreviewMessageTemplate_Defaults(t *testing.T) {
+	// The embedded templates must pass the validation of overrides
+	for _, c := range customizableTemplates {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := tmplFS.ReadFile("templates/" + c.Filename)
+			assert.Nil(t, err)
+
+			preview, err := PreviewMessageTemplate(i18n.English, "C0123456789", c.Name, string(b))
+			assert.Nil(t, err)
+			assert.NotEmpty(t, preview)
+		})
+	}
+}
+
+func Test_PreviewMessageTemplate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		template string
+		content  string
+		err      error
+		message  string
+	}{
+		{
+			name:     "valid",
+			template: "check_pair",
+			content:  `{"blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": "Did <@{{ .Participant }}> and <@{{ .Partner }}> meet?"}}]}`,
+		},
+		{
+			name:     "unknown template",
+			template: "greet_admin",
+			content:  `{"blocks": []}`,
+			err:      ErrUnknownMessageTemplate,
+		},
+		{
+			name:     "parse error",
+			template: "check_pair",
+			content:  `{"blocks": [{{ .Participant }]}`,
+			err:      ErrInvalidMessageTemplate,
+			message:  "failed to parse",
+		},
+		{
+			name:     "unknown field",
+			template: "check_pair",
+			content:  `{"blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": "{{ .Nope }}"}}]}`,
+			err:      ErrInvalidMessageTemplate,
+			message:  "failed to render",
+		},
+		{
+			name:     "environment",
+			template: "check_pair",
+			content:  `{"blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": "{{ env "HOME" }}"}}]}`,
+			err:      ErrInvalidMessageTemplate,
+			message:  `function "env" not defined`,
+		},
+		{
+			name:     "key generation",
+			template: "check_pair",
+			content:  `{"blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": "{{ genPrivateKey "rsa" }}"}}]}`,
+			err:      ErrInvalidMessageTemplate,
+			message:  `function "genPrivateKey" not defined`,
+		},
+		{
+			name:     "large list",
+			template: "check_pair",
+			content:  `{"blocks": [{{ range until 1000000000 }}{"type": "divider"},{{ end }}]}`,
+			err:      ErrInvalidMessageTemplate,
+			message:  `function "until" not defined`,
+		},
+		{
+			name:     "large output",
+			template: "check_pair",
+			content:  `{{ $l := list 0 1 2 3 4 5 6 7 8 9 }}{{ range $l }}{{ range $l }}{{ range $l }}{{ range $l }}{{ range $l }}{"type": "divider"},{{ end }}{{ end }}{{ end }}{{ end }}{{ end }}`,
+			err:      ErrInvalidMessageTemplate,
+			message:  errRenderTooLarge.Error(),
+		},
+		{
+			name:     "invalid JSON",
+			template: "report_stats",
+			content:  `{"blocks": [{"type": "section",}]}`,
+			err:      ErrInvalidMessageTemplate,
+			message:  "not valid JSON",
+		},
+		{
+			name:     "no blocks",
+			template: "report_stats",
+			content:  `{"blocks": []}`,
+			err:      ErrInvalidMessageTemplate,
+			message:  "must have at least 1 block",
+		},
+		{
+			name:     "unknown block type",
+			template: "report_stats",
+			content:  `{"blocks": [{"type": "banner"}]}`,
+			err:      ErrInvalidMessageTemplate,
+			message:  `unknown block type "banner"`,
+		},
+		{
+			name:     "invalid variant",
+			template: "check_pair",
+			content:  `{{ if .IsMidRound }}{"blocks": [{"type": "divider"}]}{{ end }}`,
+			err:      ErrInvalidMessageTemplate,
+			message:  "not valid JSON",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			preview, err := PreviewMessageTemplate(i18n.English, "C0123456789", tc.template, tc.content)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Contains(t, err.Error(), tc.message)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Contains(t, preview, "Did <@U0123456789> and <@U9876543210> meet?")
+		})
+	}
+}
+
+func Test_limitedWriter(t *testing.T) {
+	var b bytes.Buffer
+
+	w := &limitedWriter{w: &b, remaining: 5, deadline: time.Now().Add(time.Minute)}
+
+	_, err := w.Write([]byte("hello"))
+	assert.Nil(t, err)
+
+	_, err = w.Write([]byte("!"))
+	assert.ErrorIs(t, err, errRenderTooLarge)
+	assert.Equal(t, "hello", b.String())
+
+	w = &limitedWriter{w: &b, remaining: 5, deadline: time.Now().Add(-time.Second)}
+
+	_, err = w.Write([]byte("hello"))
+	assert.ErrorIs(t, err, errRenderTimeout)
+}
+
+func Test_renderChannelTemplate(t *testing.T) {
+	data := reportStatsTemplate{
+		Pairs:   5,
+		Met:     4,
+		Percent: 80,
+	}
+
+	expected, err := renderTemplate(i18n.English, reportStatsTemplateFilename, data)
+	require.NoError(t, err)
+
+	t.Run("default", func(t *testing.T) {
+		content, err := renderChannelTemplate(context.Background(), i18n.English, "", reportStatsTemplateFilename, data)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, content)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		override := `{"blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": "{{ .Met }} of {{ .Pairs }} met"}}]}`
+
+		content, err := renderChannelTemplate(context.Background(), i18n.English, override, reportStatsTemplateFilename, data)
+		assert.Nil(t, err)
+		assert.Contains(t, content, "4 of 5 met")
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		logger, out := o11y.NewBufferedLogger()
+		ctx := hclog.WithContext(context.Background(), logger)
+
+		content, err := renderChannelTemplate(ctx, i18n.English, `{"blocks": [`, reportStatsTemplateFilename, data)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, content)
+		assert.Contains(t, out.String(), "falling back to the default message template")
+	})
+}
+
+type MessageTemplatesSuite struct {
+	suite.Suite
+	ctx    context.Context
+	mock   sqlmock.Sqlmock
+	db     *gorm.DB
+	logger hclog.Logger
+	buffer *bytes.Buffer
+}
+
+func (s *MessageTemplatesSuite) SetupTest() {
+	s.logger, s.buffer = o11y.NewBufferedLogger()
+	s.ctx = hclog.WithContext(context.Background(), s.logger)
+	s.db, s.mock = database.NewMockedGormDB()
+}
+
+func (s *MessageTemplatesSuite) AfterTest(_, _ string) {
+	require.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *MessageTemplatesSuite) Test_SaveMessageTemplate() {
+	r := require.New(s.T())
+
+	channelID := "C0123456789"
+	content := `{"blocks": [{"type": "header", "text": {"type": "plain_text", "text": "Round over!"}}]}`
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(`INSERT INTO "message_templates" (.+) VALUES (.+) ON CONFLICT \("channel_id","name"\) DO UPDATE SET "content"="excluded"."content","updated_at"="excluded"."updated_at" RETURNING "id"`).
+		WithArgs(channelID, "report_stats", content, database.AnyTime(), database.AnyTime()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectCommit()
+
+	err := SaveMessageTemplate(s.ctx, s.db, channelID, "report_stats", content)
+	r.NoError(err)
+	r.Contains(s.buffer.String(), "saved message template for the Slack channel")
+}
+
+func (s *MessageTemplatesSuite) Test_SaveMessageTemplate_Invalid() {
+	r := require.New(s.T())
+
+	err := SaveMessageTemplate(s.ctx, s.db, "C0123456789", "report_stats", `{"blocks": []}`)
+	r.ErrorIs(err, ErrInvalidMessageTemplate)
+}
+
+func (s *MessageTemplatesSuite) Test_ResetMessageTemplate() {
+	r := require.New(s.T())
+
+	channelID := "C0123456789"
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(`DELETE FROM "message_templates" WHERE channel_id = \$1 AND name = \$2`).
+		WithArgs(channelID, "notify_pair").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	err := ResetMessageTemplate(s.ctx, s.db, channelID, "notify_pair")
+	r.NoError(err)
+	r.Contains(s.buffer.String(), "reset message template for the Slack channel")
+}
+
+func (s *MessageTemplatesSuite) Test_ListMessageTemplates() {
+	r := require.New(s.T())
+
+	channelID := "C0123456789"
+	content := `{"blocks": [{"type": "divider"}]}`
+
+	s.mock.ExpectQuery(`SELECT \* FROM "message_templates" WHERE channel_id = \$1`).
+		WithArgs(channelID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "content"}).AddRow("check_pair", content))
+
+	templates, err := ListMessageTemplates(s.ctx, s.db, channelID)
+	r.NoError(err)
+	r.Len(templates, len(customizableTemplates))
+
+	for i, t := range templates {
+		if t.Name == "check_pair" {
+			r.True(t.IsCustom)
+			r.Equal(content, t.Content)
+			continue
+		}
+
+		b, err := tmplFS.ReadFile("templates/" + customizableTemplates[i].Filename)
+		r.NoError(err)
+
+		r.False(t.IsCustom)
+		r.Equal(string(b), t.Content)
+	}
+}
+
+func Test_MessageTemplates_suite(t *testing.T) {
+	suite.Run(t, new(MessageTemplatesSuite))
+}
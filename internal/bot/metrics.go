@@ -0,0 +1,22 @@
+package bot
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var meter = otel.Meter("")
+
+var (
+	// roundParticipants records the number of participants in each round of chat-roulette
+	roundParticipants, _ = meter.Int64Histogram("round.participants",
+		metric.WithDescription("Number of participants in a round of chat-roulette"),
+		metric.WithExplicitBucketBoundaries(2, 5, 10, 25, 50, 100, 250, 500, 1000),
+	)
+
+	// roundMatches records the number of matches in each round of chat-roulette
+	roundMatches, _ = meter.Int64Histogram("round.matches",
+		metric.WithDescription("Number of matches in a round of chat-roulette"),
+		metric.WithExplicitBucketBoundaries(1, 2, 5, 10, 25, 50, 100, 250, 500),
+	)
+)
@@ -9,12 +9,17 @@ import (
 	"github.com/go-playground/tz"
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
+
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 )
 
 type privateMetadata struct {
 	ChannelID   string       `json:"channel_id,omitempty"`
 	ResponseURL string       `json:"response_url,omitempty"`
 	Blocks      slack.Blocks `json:"blocks,omitempty"`
+
+	// Language is the language of the user completing the onboarding flow
+	Language i18n.Language `json:"language,omitempty"`
 }
 
 // Encode encodes privateMetadata from struct to json to base64
@@ -45,6 +50,15 @@ func ExtractChannelIDFromPrivateMetada(interaction *slack.InteractionCallback) (
 	return pm.ChannelID, nil
 }
 
+// extractLanguageFromPrivateMetadata returns the language stored in the
+// private_metadata field, or the default language if there is none.
+func extractLanguageFromPrivateMetadata(interaction *slack.InteractionCallback) i18n.Language {
+	var pm privateMetadata
+	_ = pm.Decode(interaction.View.PrivateMetadata)
+
+	return i18n.Resolve(pm.Language.String())
+}
+
 // onboardingTemplate is used with templates/onboarding_*.json.tmpl templates
 type onboardingTemplate struct {
 	UserID          string
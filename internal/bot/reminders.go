@@ -0,0 +1,254 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+	"github.com/chat-roulettte/chat-roulette/internal/timex"
+)
+
+// reminderJobTypes are the job types whose execution time is
+// determined by the reminder settings for a Slack channel.
+var reminderJobTypes = []string{
+	models.JobTypeKickoffPair.String(),
+	models.JobTypeCheckPair.String(),
+	models.JobTypeMarkInactive.String(),
+}
+
+// isEnabled returns the value of a reminder flag, which defaults to enabled.
+func isEnabled(b *bool) bool {
+	return b == nil || *b
+}
+
+// kickoffPairTime returns when to send an icebreaker to a pair that was notified at the given time.
+func kickoffPairTime(r models.ReminderSettings, notifiedAt time.Time) time.Time {
+	return notifiedAt.Add(time.Duration(r.KickoffAfterHours) * time.Hour)
+}
+
+// midRoundCheckInTime returns when to check in on a pair in the middle of the round.
+func midRoundCheckInTime(r models.ReminderSettings, notifiedAt, roundEnd time.Time) (time.Time, error) {
+	return timex.PercentPoint(notifiedAt, roundEnd, r.MidRoundCheckInPercent)
+}
+
+// endRoundCheckInTime returns when to check in on a pair at the end of the round.
+func endRoundCheckInTime(r models.ReminderSettings, roundEnd time.Time) time.Time {
+	return roundEnd.Add(-(time.Duration(r.EndRoundCheckInHours) * time.Hour))
+}
+
+// markInactiveTime returns when to evaluate if the participants of a match were inactive.
+func markInactiveTime(r models.ReminderSettings, roundEnd time.Time) time.Time {
+	return roundEnd.Add(-(time.Duration(r.MarkInactiveHours) * time.Hour))
+}
+
+// reminderJobData contains the fields shared by the data of reminder jobs
+type reminderJobData struct {
+	MatchID    int32 `json:"match_id"`
+	IsMidRound bool  `json:"is_mid_round"`
+}
+
+// reminderDisabledError is the error recorded for reminder jobs that were canceled because
+// the reminder was disabled, so that they can be rescheduled if it is enabled again
+const reminderDisabledError = "canceled because the reminder was disabled for the Slack channel"
+
+// reminderJob is a reminder job along with when its match was made and when its round ends
+type reminderJob struct {
+	ID          int32
+	JobType     string
+	Data        []byte
+	IsCompleted bool
+	MatchedAt   time.Time
+	IsAdHoc     bool
+	EndsAt      *time.Time
+}
+
+// reminderUpdate is the update of a reminder job to match the channel's reminder settings.
+// Nil fields are left unchanged.
+type reminderUpdate struct {
+	ID           int32
+	WasCompleted bool
+	ExecAt       *time.Time
+	Status       *string
+	IsCompleted  bool
+	LastError    *string
+}
+
+// rescheduleReminders updates the execution time of any pending KICKOFF_PAIR, CHECK_PAIR,
+// and MARK_INACTIVE jobs for a Slack channel to match the channel's reminder settings.
+// Pending jobs for reminders that have been disabled are canceled, and jobs for matches in
+// the current round that were canceled this way are rescheduled if they are enabled again.
+//
+// The jobs are updated with a single statement. Jobs that a worker is executing are skipped,
+// as are jobs that were completed since they were retrieved, so that the outcome of a job
+// is never overwritten.
+func rescheduleReminders(ctx context.Context, db *gorm.DB, channelID string) error {
+
+	logger := hclog.FromContext(ctx).With(attributes.SlackChannelID, channelID)
+
+	var channel models.Channel
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	if err := db.WithContext(dbCtx).Where("channel_id = ?", channelID).First(&channel).Error; err != nil {
+		message := "failed to retrieve metadata for the Slack channel"
+		logger.Error(message, "error", err)
+		return errors.Wrap(err, message)
+	}
+
+	var jobs []reminderJob
+
+	jobsCtx, cancelJobs := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancelJobs()
+
+	result := db.WithContext(jobsCtx).
+		Model(&models.Job{}).
+		Select("jobs.id, jobs.job_type, jobs.data, jobs.is_completed, matches.created_at AS matched_at, rounds.is_adhoc, rounds.ends_at").
+		Joins("JOIN matches ON matches.id = (jobs.data->>'match_id')::integer").
+		Joins("JOIN rounds ON rounds.id = matches.round_id").
+		Where("jobs.data->>'channel_id' = ?", channelID).
+		Where("jobs.job_type IN ?", reminderJobTypes).
+		Where(
+			db.Where("jobs.is_completed = false").
+				Or("jobs.status = ? AND jobs.last_error = ? AND rounds.has_ended = false", models.JobStatusCanceled, reminderDisabledError)).
+		Scan(&jobs)
+
+	if result.Error != nil {
+		message := "failed to retrieve reminder jobs"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	now := time.Now().UTC()
+
+	var rows []string
+	var args []interface{}
+
+	for i := range jobs {
+		var data reminderJobData
+		if err := json.Unmarshal(jobs[i].Data, &data); err != nil {
+			logger.Warn("failed to unmarshal JSON from job.Data", "error", err, "id", jobs[i].ID)
+			continue
+		}
+
+		update := rescheduleReminder(&channel, &jobs[i], data, now)
+		if update == nil {
+			continue
+		}
+
+		rows = append(rows, "(?::integer, ?::boolean, ?::timestamp, ?::job_status, ?::boolean, ?::text)")
+		args = append(args, update.ID, update.WasCompleted, update.ExecAt, update.Status, update.IsCompleted, update.LastError)
+	}
+
+	if len(rows) == 0 {
+		logger.Info("rescheduled pending reminder jobs", "jobs", 0)
+		return nil
+	}
+
+	updateCtx, cancelUpdate := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancelUpdate()
+
+	result = db.WithContext(updateCtx).Exec(`
+		UPDATE jobs
+		SET
+			exec_at = COALESCE(v.exec_at, jobs.exec_at),
+			status = COALESCE(v.status, jobs.status),
+			is_completed = v.is_completed,
+			last_error = COALESCE(v.last_error, jobs.last_error),
+			updated_at = NOW()
+		FROM (VALUES `+strings.Join(rows, ", ")+`) AS v(id, was_completed, exec_at, status, is_completed, last_error)
+		WHERE jobs.id = v.id
+			AND jobs.is_completed = v.was_completed
+			AND (jobs.locked_until IS NULL OR jobs.locked_until < NOW())`,
+		args...,
+	)
+
+	if result.Error != nil {
+		message := "failed to reschedule reminder jobs"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	logger.Info("rescheduled pending reminder jobs", "jobs", result.RowsAffected, "skipped", int64(len(rows))-result.RowsAffected)
+
+	return nil
+}
+
+// rescheduleReminder returns the update of a reminder job to match the channel's
+// reminder settings, or nil if the job does not need to be updated.
+func rescheduleReminder(channel *models.Channel, job *reminderJob, data reminderJobData, now time.Time) *reminderUpdate {
+	roundEnd := channel.NextRound
+	if job.IsAdHoc && job.EndsAt != nil {
+		roundEnd = *job.EndsAt
+	}
+
+	r := channel.Reminders
+
+	var enabled bool
+	var execAt time.Time
+
+	switch job.JobType {
+	case models.JobTypeKickoffPair.String():
+		enabled = isEnabled(r.KickoffEnabled)
+		execAt = kickoffPairTime(r, job.MatchedAt)
+	case models.JobTypeCheckPair.String():
+		if data.IsMidRound {
+			enabled = isEnabled(r.MidRoundCheckInEnabled)
+			t, err := midRoundCheckInTime(r, job.MatchedAt, roundEnd)
+			if err != nil {
+				enabled = false
+			}
+			execAt = t
+		} else {
+			enabled = isEnabled(r.EndRoundCheckInEnabled)
+			execAt = endRoundCheckInTime(r, roundEnd)
+		}
+	case models.JobTypeMarkInactive.String():
+		enabled = isEnabled(r.MarkInactiveEnabled)
+		execAt = markInactiveTime(r, roundEnd)
+	}
+
+	update := &reminderUpdate{
+		ID:           job.ID,
+		WasCompleted: job.IsCompleted,
+	}
+
+	switch {
+	case !enabled && job.IsCompleted:
+		// The job was already canceled when the reminder was disabled
+		return nil
+
+	case !enabled:
+		status := models.JobStatusCanceled.String()
+		lastError := reminderDisabledError
+
+		update.Status = &status
+		update.IsCompleted = true
+		update.LastError = &lastError
+
+	default:
+		if execAt.Before(now) {
+			execAt = now
+		}
+
+		update.ExecAt = &execAt
+
+		// Return the job to the queue if it was canceled when the reminder was disabled
+		if job.IsCompleted {
+			status := models.JobStatusPending.String()
+			lastError := ""
+
+			update.Status = &status
+			update.LastError = &lastError
+		}
+	}
+
+	return update
+}
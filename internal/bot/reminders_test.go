@@ -0,0 +1,118 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y"
+)
+
+func Test_reminderTimes(t *testing.T) {
+	r := models.ReminderSettings{
+		KickoffAfterHours:      12,
+		MidRoundCheckInPercent: 50,
+		EndRoundCheckInHours:   18,
+		MarkInactiveHours:      1,
+	}
+
+	notifiedAt := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	roundEnd := time.Date(2024, 10, 8, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 10, 2, 0, 0, 0, 0, time.UTC), kickoffPairTime(r, notifiedAt))
+
+	midRound, err := midRoundCheckInTime(r, notifiedAt, roundEnd)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 10, 5, 12, 0, 0, 0, time.UTC), midRound)
+
+	assert.Equal(t, time.Date(2024, 10, 7, 18, 0, 0, 0, time.UTC), endRoundCheckInTime(r, roundEnd))
+	assert.Equal(t, time.Date(2024, 10, 8, 11, 0, 0, 0, time.UTC), markInactiveTime(r, roundEnd))
+}
+
+func Test_isEnabled(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	assert.True(t, isEnabled(nil))
+	assert.True(t, isEnabled(&enabled))
+	assert.False(t, isEnabled(&disabled))
+}
+
+type RescheduleRemindersSuite struct {
+	suite.Suite
+	ctx    context.Context
+	mock   sqlmock.Sqlmock
+	db     *gorm.DB
+	logger hclog.Logger
+	buffer *bytes.Buffer
+}
+
+func (s *RescheduleRemindersSuite) SetupTest() {
+	s.logger, s.buffer = o11y.NewBufferedLogger()
+	s.ctx = hclog.WithContext(context.Background(), s.logger)
+	s.db, s.mock = database.NewMockedGormDB()
+}
+
+func (s *RescheduleRemindersSuite) AfterTest(_, _ string) {
+	require.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *RescheduleRemindersSuite) Test_rescheduleReminders() {
+	r := require.New(s.T())
+
+	channelID := "C0123456789"
+	nextRound := time.Now().UTC().Add(72 * time.Hour).Truncate(time.Second)
+
+	s.mock.ExpectQuery(`SELECT \* FROM "channels" WHERE channel_id = (.+)`).
+		WithArgs(channelID, 1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"channel_id", "next_round", "kickoff_enabled", "mark_inactive_enabled", "mark_inactive_hours"}).
+				AddRow(channelID, nextRound, false, true, 2),
+		)
+
+	s.mock.ExpectQuery(`SELECT jobs.id, jobs.job_type, jobs.data, jobs.is_completed, matches.created_at AS matched_at, rounds.is_adhoc, rounds.ends_at FROM "jobs" JOIN matches ON (.+) JOIN rounds ON rounds.id = matches.round_id WHERE jobs.data->>'channel_id' = (.+) AND jobs.job_type IN (.+) AND \(jobs.is_completed = false OR \(jobs.status = (.+) AND jobs.last_error = (.+) AND rounds.has_ended = false\)\)`).
+		WithArgs(
+			channelID,
+			models.JobTypeKickoffPair.String(),
+			models.JobTypeCheckPair.String(),
+			models.JobTypeMarkInactive.String(),
+			models.JobStatusCanceled,
+			reminderDisabledError,
+		).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "job_type", "data", "is_completed", "matched_at", "is_adhoc"}).
+				AddRow(1, models.JobTypeKickoffPair.String(), []byte(`{"channel_id":"C0123456789","match_id":1}`), false, time.Now().UTC(), false).
+				AddRow(2, models.JobTypeMarkInactive.String(), []byte(`{"channel_id":"C0123456789","match_id":1}`), false, time.Now().UTC(), false).
+				AddRow(3, models.JobTypeCheckPair.String(), []byte(`{"channel_id":"C0123456789","match_id":1}`), true, time.Now().UTC(), false),
+		)
+
+	// The jobs are updated in a single statement, skipping jobs that a worker is executing
+	s.mock.ExpectExec(`UPDATE jobs SET (.+) FROM \(VALUES (.+)\) AS v\(id, was_completed, exec_at, status, is_completed, last_error\) WHERE jobs.id = v.id AND jobs.is_completed = v.was_completed AND \(jobs.locked_until IS NULL OR jobs.locked_until < NOW\(\)\)`).
+		WithArgs(
+			// KICKOFF_PAIR is disabled, so the job is canceled
+			1, false, nil, models.JobStatusCanceled.String(), true, reminderDisabledError,
+			// MARK_INACTIVE is rescheduled
+			2, false, nextRound.Add(-2*time.Hour), nil, false, nil,
+			// CHECK_PAIR was canceled when it was disabled, and is returned to the queue
+			3, true, nextRound, models.JobStatusPending.String(), false, "",
+		).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := rescheduleReminders(s.ctx, s.db, channelID)
+	r.NoError(err)
+	r.Contains(s.buffer.String(), "jobs=2 skipped=1")
+}
+
+func Test_RescheduleReminders_suite(t *testing.T) {
+	suite.Run(t, new(RescheduleRemindersSuite))
+}
@@ -1,11 +1,10 @@
 package bot
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
-	"github.com/chat-roulettte/chat-roulette/internal/templatex"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/timex"
 )
 
@@ -28,13 +27,11 @@ func NextChatRouletteRound(t time.Time, interval models.IntervalEnum) time.Time
 	return timestamp
 }
 
-// formatSchedule ...
-func formatSchedule(interval models.IntervalEnum, t time.Time) string {
-	when := fmt.Sprintf("*%s* on *%ss*", templatex.Capitalize(interval.String()), t.Weekday())
-
+// formatSchedule returns when the rounds of chat-roulette occur, translated into the language.
+func formatSchedule(lang i18n.Language, interval models.IntervalEnum, t time.Time) string {
 	if interval == models.Monthly {
-		when = fmt.Sprintf("On the *%s* of every month", timex.FormatMonthlyOccurrence(t))
+		return i18n.T(lang, "schedule.monthly", timex.FormatMonthlyOccurrence(lang, t))
 	}
 
-	return when
+	return i18n.T(lang, "schedule.recurring", i18n.T(lang, "interval."+interval.String()), i18n.Weekdays(lang, t.Weekday()))
 }
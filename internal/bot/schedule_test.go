@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 )
 
 func TestNextChatRouletteRound(t *testing.T) {
@@ -34,33 +35,51 @@ func TestNextChatRouletteRound(t *testing.T) {
 func TestFormatSchedule(t *testing.T) {
 	testCases := []struct {
 		name      string
+		lang      i18n.Language
 		interval  models.IntervalEnum
 		timestamp time.Time
 		expected  string
 	}{
 		{
 			name:      "Weekly on Mondays",
+			lang:      i18n.English,
 			interval:  models.Weekly,
 			timestamp: time.Date(2024, 8, 5, 0, 0, 0, 0, time.UTC),
 			expected:  "*Weekly* on *Mondays*",
 		},
 		{
 			name:      "Biweekly on Tuesdays",
+			lang:      i18n.English,
 			interval:  models.Biweekly,
 			timestamp: time.Date(2024, 8, 6, 0, 0, 0, 0, time.UTC),
 			expected:  "*Biweekly* on *Tuesdays*",
 		},
 		{
 			name:      "Monthly",
+			lang:      i18n.English,
 			interval:  models.Monthly,
 			timestamp: time.Date(2024, 8, 2, 0, 0, 0, 0, time.UTC),
 			expected:  "On the *first Friday* of every month",
 		},
+		{
+			name:      "French weekly on Mondays",
+			lang:      i18n.French,
+			interval:  models.Weekly,
+			timestamp: time.Date(2024, 8, 5, 0, 0, 0, 0, time.UTC),
+			expected:  "*Toutes les semaines*, le *lundi*",
+		},
+		{
+			name:      "German monthly",
+			lang:      i18n.German,
+			interval:  models.Monthly,
+			timestamp: time.Date(2024, 8, 2, 0, 0, 0, 0, time.UTC),
+			expected:  "Am *ersten Freitag* jedes Monats",
+		},
 	}
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := formatSchedule(tt.interval, tt.timestamp)
+			actual := formatSchedule(tt.lang, tt.interval, tt.timestamp)
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
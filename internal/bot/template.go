@@ -3,10 +3,15 @@ package bot
 import (
 	"bytes"
 	"embed"
+	"io"
 	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
 
 	sprig "github.com/Masterminds/sprig/v3"
 
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/templatex"
 )
 
@@ -18,19 +23,140 @@ var (
 	// funcMap is a map of custom template functions
 	funcMap = template.FuncMap{
 		"capitalize":    templatex.Capitalize,
-		"prettyDate":    templatex.PrettierDate,
 		"prettyURL":     templatex.PrettyURL,
 		"prettyPercent": templatex.PrettyPercent,
 	}
 
-	templates = template.New("custom").Funcs(funcMap).Funcs(sprig.TxtFuncMap())
+	// untrustedExcludedFuncs are the sprig functions that are not available to templates
+	// supplied by channel admins, in addition to the functions that are not hermetic,
+	// such as env and expandenv. They generate keys and random values, hash passwords,
+	// or generate arbitrarily large lists and strings.
+	untrustedExcludedFuncs = []string{
+		"until",
+		"untilStep",
+		"seq",
+		"repeat",
+		"randInt",
+		"shuffle",
+		"bcrypt",
+		"htpasswd",
+		"genPrivateKey",
+		"derivePassword",
+		"buildCustomCert",
+		"genCA",
+		"genCAWithKey",
+		"genSelfSignedCert",
+		"genSelfSignedCertWithKey",
+		"genSignedCert",
+		"genSignedCertWithKey",
+		"encryptAES",
+		"decryptAES",
+	}
+
+	// errRenderTooLarge is returned when a template supplied by a channel admin renders too much output
+	errRenderTooLarge = errors.New("rendered template is too large")
+
+	// errRenderTimeout is returned when a template supplied by a channel admin takes too long to render
+	errRenderTimeout = errors.New("timed out rendering template")
 )
 
-// renderTemplate renders a template using the supplied filename and data
-func renderTemplate(filename string, data interface{}) (string, error) {
+const (
+	// maxUntrustedRenderLength is the maximum size in bytes of a rendered template supplied by a channel admin
+	maxUntrustedRenderLength = 5 * MaxMessageTemplateLength
+
+	// untrustedRenderTimeout is the maximum time for rendering a template supplied by a channel admin
+	untrustedRenderTimeout = 1 * time.Second
+)
+
+// newTemplate returns an empty template with the custom template functions
+// and the functions for translating messages into the language.
+func newTemplate(name string, lang i18n.Language) *template.Template {
+	return newTemplateWithFuncs(name, lang, sprig.TxtFuncMap())
+}
+
+// newUntrustedTemplate returns an empty template for content supplied by channel admins.
+//
+// Unlike newTemplate, the template cannot call the sprig functions that read the
+// environment of the server, such as the secrets in its configuration.
+func newUntrustedTemplate(name string, lang i18n.Language) *template.Template {
+	sprigFuncs := sprig.HermeticTxtFuncMap()
+	for _, fn := range untrustedExcludedFuncs {
+		delete(sprigFuncs, fn)
+	}
+
+	return newTemplateWithFuncs(name, lang, sprigFuncs)
+}
+
+// executeUntrusted renders a template supplied by a channel admin, stopping once the
+// output exceeds maxUntrustedRenderLength or rendering exceeds untrustedRenderTimeout.
+func executeUntrusted(t *template.Template, data interface{}) ([]byte, error) {
+	var b bytes.Buffer
+
+	w := &limitedWriter{
+		w:         &b,
+		remaining: maxUntrustedRenderLength,
+		deadline:  time.Now().Add(untrustedRenderTimeout),
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- t.Execute(w, data)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	case <-time.After(untrustedRenderTimeout):
+		// The rendering is abandoned, and stops at the next write after the deadline
+		return nil, errRenderTimeout
+	}
+}
+
+// limitedWriter is an io.Writer that fails once the
+// size limit is exceeded or the deadline has passed.
+type limitedWriter struct {
+	w         io.Writer
+	remaining int
+	deadline  time.Time
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if time.Now().After(l.deadline) {
+		return 0, errRenderTimeout
+	}
+
+	if len(p) > l.remaining {
+		return 0, errRenderTooLarge
+	}
+
+	l.remaining -= len(p)
+
+	return l.w.Write(p)
+}
+
+// newTemplateWithFuncs returns an empty template with the custom template functions,
+// the sprig functions, and the functions for translating messages into the language.
+func newTemplateWithFuncs(name string, lang i18n.Language, sprigFuncs template.FuncMap) *template.Template {
+	return template.New(name).
+		Funcs(funcMap).
+		Funcs(sprigFuncs).
+		Funcs(i18n.Funcs(lang)).
+		Funcs(template.FuncMap{
+			"prettyDate": func(t time.Time) string {
+				return i18n.FormatLongDate(lang, t)
+			},
+		})
+}
+
+// renderTemplate renders a template using the supplied filename and data,
+// translating its messages into the language.
+func renderTemplate(lang i18n.Language, filename string, data interface{}) (string, error) {
 	var b bytes.Buffer
 
-	t, err := templates.ParseFS(tmplFS, "templates/*")
+	t, err := newTemplate("custom", lang).ParseFS(tmplFS, "templates/*")
 
 	if err != nil {
 		return "", err
@@ -0,0 +1,255 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/bincyber/go-sqlcrypter"
+	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+	"github.com/chat-roulettte/chat-roulette/internal/slackclient"
+)
+
+// teamIDContextKey is the context key for the ID of the Slack workspace
+type teamIDContextKey struct{}
+
+// ContextWithTeamID returns a copy of the context with the ID of the Slack workspace
+// that a request or job is for. Jobs queued with the context are executed with the
+// Slack client of that workspace.
+func ContextWithTeamID(ctx context.Context, teamID string) context.Context {
+	return context.WithValue(ctx, teamIDContextKey{}, teamID)
+}
+
+// TeamIDFromContext returns the ID of the Slack workspace in the context,
+// or an empty string if the context is not for a specific workspace.
+func TeamIDFromContext(ctx context.Context) string {
+	teamID, _ := ctx.Value(teamIDContextKey{}).(string)
+	return teamID
+}
+
+// enterpriseIDContextKey is the context key for the ID of the Enterprise Grid org
+type enterpriseIDContextKey struct{}
+
+// ContextWithEnterpriseID returns a copy of the context with the ID of the Enterprise Grid
+// org that a request or job is for. A context with an Enterprise Grid org but without a
+// Slack workspace is for every workspace in the org that the app was installed org-wide in.
+func ContextWithEnterpriseID(ctx context.Context, enterpriseID string) context.Context {
+	return context.WithValue(ctx, enterpriseIDContextKey{}, enterpriseID)
+}
+
+// EnterpriseIDFromContext returns the ID of the Enterprise Grid org in the context,
+// or an empty string if the context is not for an Enterprise Grid org.
+func EnterpriseIDFromContext(ctx context.Context) string {
+	enterpriseID, _ := ctx.Value(enterpriseIDContextKey{}).(string)
+	return enterpriseID
+}
+
+// ContextWithWorkspace returns a copy of the context for the Slack workspace that the app was
+// installed in. The context of an org-wide installation is for every workspace in the org.
+func ContextWithWorkspace(ctx context.Context, workspace *models.Workspace) context.Context {
+	if workspace.IsEnterpriseInstall {
+		return ContextWithEnterpriseID(ctx, workspace.TeamID)
+	}
+
+	return ContextWithEnterpriseID(ContextWithTeamID(ctx, workspace.TeamID), workspace.EnterpriseID)
+}
+
+// scopeTeam scopes a query of a tenant table to the Slack workspace in the context,
+// or to the Enterprise Grid org in the context if it is not for a specific workspace.
+// Queries are not scoped if the context is for neither.
+func scopeTeam(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if teamID := TeamIDFromContext(ctx); teamID != "" {
+			return db.Where("team_id = ?", teamID)
+		}
+
+		if enterpriseID := EnterpriseIDFromContext(ctx); enterpriseID != "" {
+			return db.Where("enterprise_id = ?", enterpriseID)
+		}
+
+		return db
+	}
+}
+
+// SlackInstallations returns a function that retrieves the bot token of the app
+// in a Slack workspace that it was installed in with the OAuth install flow.
+//
+// An installation in the workspace itself takes precedence over an org-wide
+// installation in the Enterprise Grid org of the workspace.
+func SlackInstallations(db *gorm.DB) slackclient.InstallationFunc {
+	return func(ctx context.Context, enterpriseID, teamID string) (*slackclient.Installation, error) {
+		dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+		defer cancel()
+
+		var workspace models.Workspace
+
+		query := db.WithContext(dbCtx).Where("team_id = ? AND is_enterprise_install = false", teamID)
+		if enterpriseID != "" {
+			query = query.Or("team_id = ? AND is_enterprise_install = true", enterpriseID)
+		}
+
+		result := query.
+			Order("is_enterprise_install ASC").
+			Limit(1).
+			Find(&workspace)
+
+		if result.Error != nil {
+			return nil, errors.Wrap(result.Error, "failed to retrieve Slack workspace from the database")
+		}
+
+		if result.RowsAffected == 0 {
+			return nil, nil
+		}
+
+		return &slackclient.Installation{
+			BotToken:  workspace.BotToken.String(),
+			BotUserID: workspace.BotUserID,
+		}, nil
+	}
+}
+
+// SaveWorkspace stores the bot token that was issued when the app
+// was installed in a Slack workspace with the OAuth install flow.
+//
+// An org-wide installation in an Enterprise Grid org is stored
+// with the ID of the org in place of the ID of a workspace.
+func SaveWorkspace(ctx context.Context, db *gorm.DB, resp *slack.OAuthV2Response) (*models.Workspace, error) {
+	workspace := &models.Workspace{
+		TeamID:       resp.Team.ID,
+		TeamName:     resp.Team.Name,
+		EnterpriseID: resp.Enterprise.ID,
+		AppID:        resp.AppID,
+		BotUserID:    resp.BotUserID,
+		BotToken:     sqlcrypter.NewEncryptedBytes(resp.AccessToken),
+		Installer:    resp.AuthedUser.ID,
+	}
+
+	if resp.IsEnterpriseInstall {
+		workspace.TeamID = resp.Enterprise.ID
+		workspace.TeamName = resp.Enterprise.Name
+		workspace.IsEnterpriseInstall = true
+	}
+
+	logger := hclog.FromContext(ctx).With(
+		attributes.SlackTeamID, workspace.TeamID,
+		attributes.SlackEnterpriseID, workspace.EnterpriseID,
+	)
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	// Reinstalling the app issues a new bot token
+	result := db.WithContext(dbCtx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "team_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"team_name", "enterprise_id", "is_enterprise_install", "app_id", "bot_user_id", "bot_token", "installer", "updated_at"}),
+		}).
+		Create(workspace)
+
+	if result.Error != nil {
+		message := "failed to save Slack workspace to the database"
+		logger.Error(message, "error", result.Error)
+		return nil, errors.Wrap(result.Error, message)
+	}
+
+	logger.Info("saved Slack workspace to the database")
+
+	return workspace, nil
+}
+
+// DeleteWorkspace deletes the bot token of a Slack workspace that the app was uninstalled from.
+//
+// If the app was not installed in the workspace itself, the bot token of the org-wide
+// installation in the Enterprise Grid org of the workspace is deleted instead.
+func DeleteWorkspace(ctx context.Context, db *gorm.DB, enterpriseID, teamID string) error {
+	logger := hclog.FromContext(ctx).With(
+		attributes.SlackTeamID, teamID,
+		attributes.SlackEnterpriseID, enterpriseID,
+	)
+
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Where("team_id = ? AND is_enterprise_install = false", teamID).
+		Delete(&models.Workspace{})
+
+	if result.Error == nil && result.RowsAffected == 0 && enterpriseID != "" {
+		result = db.WithContext(dbCtx).
+			Where("team_id = ? AND is_enterprise_install = true", enterpriseID).
+			Delete(&models.Workspace{})
+	}
+
+	if result.Error != nil {
+		message := "failed to delete Slack workspace from the database"
+		logger.Error(message, "error", result.Error)
+		return errors.Wrap(result.Error, message)
+	}
+
+	logger.Info("deleted Slack workspace from the database")
+
+	return nil
+}
+
+// ListWorkspaces retrieves the Slack workspaces that the app was installed in with the OAuth install flow.
+func ListWorkspaces(ctx context.Context, db *gorm.DB) ([]models.Workspace, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	var workspaces []models.Workspace
+
+	if err := db.WithContext(dbCtx).Select("team_id", "enterprise_id", "is_enterprise_install", "bot_user_id").Find(&workspaces).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve Slack workspaces from the database")
+	}
+
+	return workspaces, nil
+}
+
+// BackfillTeamID sets the Slack workspace of the rows in the tenant tables that were
+// created before the app supported multiple Slack workspaces. These rows belong
+// to the workspace of the bot token in the config.
+func BackfillTeamID(ctx context.Context, db *gorm.DB, teamID string) error {
+	logger := hclog.FromContext(ctx).With(attributes.SlackTeamID, teamID)
+
+	tables := []interface{}{
+		&models.Channel{},
+		&models.Member{},
+		&models.BlockedMember{},
+		&models.Job{},
+	}
+
+	for _, table := range tables {
+		rows, err := backfillTableTeamID(ctx, db, table, teamID)
+		if err != nil {
+			message := "failed to backfill the Slack workspace of existing rows"
+			logger.Error(message, "error", err)
+			return errors.Wrap(err, message)
+		}
+
+		if rows > 0 {
+			logger.Info("backfilled the Slack workspace of existing rows", "rows", rows)
+		}
+	}
+
+	return nil
+}
+
+// backfillTableTeamID sets the Slack workspace of the rows in a tenant table
+// that do not have one, and returns the number of rows that were updated.
+func backfillTableTeamID(ctx context.Context, db *gorm.DB, table interface{}, teamID string) (int64, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := db.WithContext(dbCtx).
+		Model(table).
+		Where("team_id IS NULL").
+		UpdateColumn("team_id", teamID)
+
+	return result.RowsAffected, result.Error
+}
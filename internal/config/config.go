@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/hex"
 	"fmt"
+	"net/url"
 	"os"
 	"reflect"
 	"strconv"
@@ -19,12 +20,25 @@ import (
 
 // Config stores the configuration for the application
 type Config struct {
-	Bot      SlackBotConfig
-	Database DatabaseConfig
-	Server   ServerConfig
-	Worker   WorkerConfig
-	Tracing  TracingConfig
-	Dev      bool
+	Bot       SlackBotConfig
+	Database  DatabaseConfig
+	Server    ServerConfig
+	Worker    WorkerConfig
+	Tracing   TracingConfig
+	Metrics   MetricsConfig
+	Telemetry TelemetryConfig
+	Mode      Mode
+	Dev       bool
+}
+
+// Override modifies the configuration after it has been loaded, but before it is validated
+type Override func(*Config)
+
+// WithMode overrides the mode that the process runs as
+func WithMode(m Mode) Override {
+	return func(c *Config) {
+		c.Mode = m
+	}
 }
 
 // DatabaseConfig stores the configuration for using the database
@@ -158,24 +172,152 @@ func (s *ServerConfig) GetSecretKey() ([]byte, error) {
 	return key, nil
 }
 
+// GetBaseURL returns the base URL of the web app, which is derived from the redirect URL
+func (s *ServerConfig) GetBaseURL() string {
+	u, err := url.Parse(s.RedirectURL)
+	if err != nil {
+		return ""
+	}
+
+	u.Path = ""
+	return u.String()
+}
+
 // WorkerConfig stores the configuration for the task queue workers
 type WorkerConfig struct {
 	// Concurrency is the number of concurrent workers to run
 	//
 	// Optional, defaults to the number of CPU cores
 	Concurrency int
+
+	// PollInterval is how often the queue is polled for jobs that were
+	// scheduled to run later. New jobs that are ready to run immediately
+	// wake up the workers using Postgres LISTEN/NOTIFY instead.
+	//
+	// Optional, defaults to 5s
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// DrainTimeout is how long to wait for in-flight jobs to complete
+	// during shutdown before they are canceled
+	//
+	// Optional, defaults to 30s
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"`
+
+	// HealthPort is the TCP port that the health and readiness endpoints
+	// bind on, when the worker is running without the HTTP server.
+	//
+	// Optional, defaults to 8081
+	HealthPort int `mapstructure:"health_port"`
 }
 
 // SlackBotConfig stores the configuration for the Slack bot
 type SlackBotConfig struct {
 	// AuthToken is the Slack OAuth2 bot token
 	//
-	// Required
+	// Optional, required if Distributed=false
 	AuthToken string `mapstructure:"auth_token"`
+
+	// Distributed enables installing the app in multiple Slack workspaces with the
+	// OAuth "Add to Slack" flow. The bot token issued for each workspace is stored
+	// encrypted in the database. The Server.ClientID and Server.ClientSecret are
+	// used for the OAuth flow.
+	//
+	// Optional
+	Distributed bool `mapstructure:"distributed"`
+
+	// SocketMode receives events and interactions from Slack over a websocket
+	// instead of HTTP requests, so that the server does not need a public URL.
+	//
+	// Optional
+	SocketMode bool `mapstructure:"socket_mode"`
+
+	// AppToken is the Slack app-level token with the connections:write scope,
+	// which is used to open the Socket Mode connection.
+	//
+	// Optional, required if SocketMode=true
+	AppToken string `mapstructure:"app_token"`
+}
+
+// TelemetryConfig stores the configuration for exporting traces, metrics
+// and logs to any OpenTelemetry collector or vendor with OTLP.
+type TelemetryConfig struct {
+	// Enabled turns on exporting telemetry with OTLP.
+	//
+	// Optional
+	Enabled bool `mapstructure:"enabled"`
+
+	// Endpoint is the URL of the OTLP receiver, such as
+	// "http://localhost:4317" or "https://api.honeycomb.io:443".
+	// TLS is disabled if the scheme is http.
+	//
+	// Optional, required if Enabled=true
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Protocol is the OTLP protocol: grpc or http.
+	//
+	// Optional, defaults to grpc
+	Protocol OTLPProtocol `mapstructure:"protocol"`
+
+	// Headers are sent with every export request, such as for authentication.
+	//
+	// Optional, defaults to the OTEL_EXPORTER_OTLP_HEADERS environment variable
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Traces stores the configuration for exporting traces
+	//
+	// Optional
+	Traces TelemetryTracesConfig `mapstructure:"traces"`
+
+	// Metrics stores the configuration for exporting metrics
+	//
+	// Optional
+	Metrics TelemetryMetricsConfig `mapstructure:"metrics"`
+
+	// Logs stores the configuration for exporting logs
+	//
+	// Optional
+	Logs TelemetryLogsConfig `mapstructure:"logs"`
+}
+
+// TelemetryTracesConfig stores the configuration for exporting traces with OTLP
+type TelemetryTracesConfig struct {
+	// Enabled turns on exporting traces.
+	//
+	// Optional, defaults to true
+	Enabled bool `mapstructure:"enabled"`
+
+	// SamplingRatio is the ratio (0.0 - 1.0) of new traces that are sampled.
+	// Spans with a parent follow the sampling decision of the parent.
+	//
+	// Optional, defaults to 1.0
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+}
+
+// TelemetryMetricsConfig stores the configuration for exporting metrics with OTLP
+type TelemetryMetricsConfig struct {
+	// Enabled turns on exporting metrics.
+	//
+	// Optional, defaults to true
+	Enabled bool `mapstructure:"enabled"`
+
+	// Interval is how often metrics are exported.
+	//
+	// Optional, defaults to 60s
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// TelemetryLogsConfig stores the configuration for exporting logs with OTLP
+type TelemetryLogsConfig struct {
+	// Enabled turns on exporting logs.
+	//
+	// Optional, defaults to true
+	Enabled bool `mapstructure:"enabled"`
 }
 
 // TracingConfig stores the configuration for OpenTelemetry tracing.
 // Only one exporter can be configured at a time.
+//
+// It is deprecated in favour of the traces of TelemetryConfig, which are exported with OTLP.
 type TracingConfig struct {
 	// Enabled turns on OpenTelemetry tracing.
 	//
@@ -199,6 +341,15 @@ type TracingConfig struct {
 	Honeycomb HoneycombTracing `mapstructure:"honeycomb"`
 }
 
+// MetricsConfig stores the configuration for Prometheus metrics
+type MetricsConfig struct {
+	// Enabled turns on the /metrics endpoint for Prometheus to scrape.
+	// A worker-only process serves it on the health port.
+	//
+	// Optional
+	Enabled bool `mapstructure:"enabled"`
+}
+
 // JaegerTracing contains configuration for the Jaeger exporter
 type JaegerTracing struct {
 	// Endpoint is the URL of the Jaeger collector.
@@ -228,7 +379,10 @@ func newDefaultConfig() *Config {
 			Port:    DefaultServerPort,
 		},
 		Worker: WorkerConfig{
-			Concurrency: DefaultWorkerConcurrency,
+			Concurrency:  DefaultWorkerConcurrency,
+			PollInterval: DefaultWorkerPollInterval,
+			DrainTimeout: DefaultWorkerDrainTimeout,
+			HealthPort:   DefaultWorkerHealthPort,
 		},
 		Database: DatabaseConfig{
 			Connections: DBConnectionsConfig{
@@ -238,12 +392,28 @@ func newDefaultConfig() *Config {
 				MaxIdletime: DefaultDBMaxIdletime,
 			},
 		},
-		Dev: false,
+		Telemetry: TelemetryConfig{
+			Protocol: DefaultTelemetryProtocol,
+			Traces: TelemetryTracesConfig{
+				Enabled:       true,
+				SamplingRatio: DefaultTelemetrySamplingRatio,
+			},
+			Metrics: TelemetryMetricsConfig{
+				Enabled:  true,
+				Interval: DefaultTelemetryMetricsInterval,
+			},
+			Logs: TelemetryLogsConfig{
+				Enabled: true,
+			},
+		},
+		Mode: DefaultMode,
+		Dev:  false,
 	}
 }
 
-// LoadConfig loads configuration from a file and environment variables
-func LoadConfig(path string) (*Config, error) {
+// LoadConfig loads configuration from a file and environment variables.
+// Overrides, such as from command-line flags, are applied before validation.
+func LoadConfig(path string, overrides ...Override) (*Config, error) {
 	v := viper.New()
 
 	// Load default config
@@ -273,6 +443,10 @@ func LoadConfig(path string) (*Config, error) {
 		config.Server.Port = v
 	}
 
+	for _, override := range overrides {
+		override(config)
+	}
+
 	// Validate the config
 	if err := config.Validate(); err != nil {
 		return nil, err
@@ -283,9 +457,16 @@ func LoadConfig(path string) (*Config, error) {
 
 // Validate verifies the configuration
 func (c Config) Validate() error {
+	if err := validation.ValidateStruct(&c,
+		validation.Field(&c.Mode, validation.Required, validation.In(ModeAll, ModeServer, ModeWorker)),
+	); err != nil {
+		return errors.Wrap(err, "failed to validate mode")
+	}
+
 	// Validate bot config
 	if err := validation.ValidateStruct(&c.Bot,
-		validation.Field(&c.Bot.AuthToken, validation.By(isx.SlackBotAuthToken)),
+		validation.Field(&c.Bot.AuthToken, validation.When(!c.Bot.Distributed || c.Bot.AuthToken != "", validation.By(isx.SlackBotAuthToken))),
+		validation.Field(&c.Bot.AppToken, validation.When(c.Bot.SocketMode, validation.By(isx.SlackAppToken))),
 	); err != nil {
 		return errors.Wrap(err, "failed to validate bot config")
 	}
@@ -338,6 +519,28 @@ func (c Config) Validate() error {
 		}
 	}
 
+	// Validate telemetry config
+	if c.Telemetry.Enabled {
+		if err := validation.ValidateStruct(&c.Telemetry,
+			validation.Field(&c.Telemetry.Endpoint, validation.Required, is.URL),
+			validation.Field(&c.Telemetry.Protocol, validation.Required, validation.In(OTLPProtocolGRPC, OTLPProtocolHTTP)),
+		); err != nil {
+			return errors.Wrap(err, "failed to validate telemetry config")
+		}
+
+		if c.Telemetry.Traces.SamplingRatio < 0 || c.Telemetry.Traces.SamplingRatio > 1 {
+			return errors.Wrap(fmt.Errorf("sampling_ratio must be between 0.0 and 1.0"), "failed to validate telemetry config")
+		}
+
+		if c.Telemetry.Metrics.Enabled && c.Telemetry.Metrics.Interval < time.Second {
+			return errors.Wrap(fmt.Errorf("metrics interval cannot be less than 1s"), "failed to validate telemetry config")
+		}
+	}
+
+	if c.Tracing.Enabled && c.Telemetry.TracesEnabled() {
+		return fmt.Errorf("tracing cannot be enabled when telemetry is exporting traces")
+	}
+
 	// Validate server config
 	if c.Server.Port < 0 || c.Server.Port > 65536 {
 		return errors.Wrap(fmt.Errorf("invalid server port"), "failed to validate server config")
@@ -345,21 +548,37 @@ func (c Config) Validate() error {
 
 	if err := validation.ValidateStruct(&c.Server,
 		validation.Field(&c.Server.Address, validation.Required, is.Host),
-		validation.Field(&c.Server.ClientID, validation.Required),
-		validation.Field(&c.Server.ClientSecret, validation.Required),
-		validation.Field(&c.Server.RedirectURL, validation.By(isx.RedirectURL)),
-		validation.Field(&c.Server.SecretKey, validation.Required, is.Hexadecimal, validation.Length(64, 64)),
-		validation.Field(&c.Server.SigningSecret, validation.Required),
 	); err != nil {
 		return errors.Wrap(err, "failed to validate server config")
 	}
 
+	// The HTTP server is not started by a worker-only process
+	if c.Mode.RunsServer() {
+		if err := validation.ValidateStruct(&c.Server,
+			validation.Field(&c.Server.ClientID, validation.Required),
+			validation.Field(&c.Server.ClientSecret, validation.Required),
+			validation.Field(&c.Server.RedirectURL, validation.By(isx.RedirectURL)),
+			validation.Field(&c.Server.SecretKey, validation.Required, is.Hexadecimal, validation.Length(64, 64)),
+			// Requests received over Socket Mode are not signed
+			validation.Field(&c.Server.SigningSecret, validation.When(!c.Bot.SocketMode, validation.Required)),
+		); err != nil {
+			return errors.Wrap(err, "failed to validate server config")
+		}
+	}
+
 	// Validate worker config
 	if c.Worker.Concurrency < 1 {
 		return fmt.Errorf("worker concurrency cannot be less than 1")
 	}
 
-	if err := validation.ValidateStruct(&c.Worker); err != nil {
+	if c.Worker.HealthPort < 0 || c.Worker.HealthPort > 65536 {
+		return errors.Wrap(fmt.Errorf("invalid health port"), "failed to validate worker config")
+	}
+
+	if err := validation.ValidateStruct(&c.Worker,
+		validation.Field(&c.Worker.PollInterval, validation.Required, validation.Min(100*time.Millisecond)),
+		validation.Field(&c.Worker.DrainTimeout, validation.Required),
+	); err != nil {
 		return errors.Wrap(err, "failed to validate worker config")
 	}
 
@@ -371,6 +590,11 @@ func (c *Config) GetAddr() string {
 	return fmt.Sprintf("%s:%d", c.Server.Address, c.Server.Port)
 }
 
+// GetWorkerHealthAddr returns the addr of the health endpoints for a worker-only process
+func (c *Config) GetWorkerHealthAddr() string {
+	return fmt.Sprintf("%s:%d", c.Server.Address, c.Worker.HealthPort)
+}
+
 // bindEnvs calls viper.BindEnv() for all fields of a struct
 //
 // This is a workaround for these viper issues:
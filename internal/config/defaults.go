@@ -9,10 +9,20 @@ const (
 	DefaultServerAddr = "0.0.0.0"
 	DefaultServerPort = 8080
 
+	DefaultMode = ModeAll
+
 	DefaultDBMaxOpen     = 20
 	DefaultDBMaxIdle     = 10
 	DefaultDBMaxLifetime = 60 * time.Minute
 	DefaultDBMaxIdletime = 15 * time.Minute
+
+	DefaultWorkerPollInterval = 5 * time.Second
+	DefaultWorkerDrainTimeout = 30 * time.Second
+	DefaultWorkerHealthPort   = 8081
+
+	DefaultTelemetryProtocol        = OTLPProtocolGRPC
+	DefaultTelemetrySamplingRatio   = 1.0
+	DefaultTelemetryMetricsInterval = 60 * time.Second
 )
 
 var (
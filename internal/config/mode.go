@@ -0,0 +1,25 @@
+package config
+
+// Mode is the role that a chat-roulette process runs as
+type Mode string
+
+const (
+	// ModeAll runs both the HTTP server and the worker in the same process
+	ModeAll Mode = "all"
+
+	// ModeServer runs only the HTTP server, which receives requests from Slack and the UI
+	ModeServer Mode = "server"
+
+	// ModeWorker runs only the worker, which processes the jobs in the queue
+	ModeWorker Mode = "worker"
+)
+
+// RunsServer returns true if the HTTP server runs in this mode
+func (m Mode) RunsServer() bool {
+	return m == ModeAll || m == ModeServer
+}
+
+// RunsWorker returns true if the worker runs in this mode
+func (m Mode) RunsWorker() bool {
+	return m == ModeAll || m == ModeWorker
+}
@@ -0,0 +1,24 @@
+package config
+
+// OTLPProtocol is the protocol used to export telemetry with OTLP
+type OTLPProtocol string
+
+const (
+	OTLPProtocolGRPC OTLPProtocol = "grpc"
+	OTLPProtocolHTTP OTLPProtocol = "http"
+)
+
+// TracesEnabled returns true if traces are exported with OTLP
+func (t *TelemetryConfig) TracesEnabled() bool {
+	return t.Enabled && t.Traces.Enabled
+}
+
+// MetricsEnabled returns true if metrics are exported with OTLP
+func (t *TelemetryConfig) MetricsEnabled() bool {
+	return t.Enabled && t.Metrics.Enabled
+}
+
+// LogsEnabled returns true if logs are exported with OTLP
+func (t *TelemetryConfig) LogsEnabled() bool {
+	return t.Enabled && t.Logs.Enabled
+}
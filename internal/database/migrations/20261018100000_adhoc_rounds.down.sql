@@ -0,0 +1,2 @@
+ALTER TABLE rounds DROP COLUMN IF EXISTS ends_at;
+ALTER TABLE rounds DROP COLUMN IF EXISTS is_adhoc;
@@ -0,0 +1,8 @@
+ALTER TABLE channels DROP COLUMN IF EXISTS mark_inactive_hours;
+ALTER TABLE channels DROP COLUMN IF EXISTS mark_inactive_enabled;
+ALTER TABLE channels DROP COLUMN IF EXISTS endround_checkin_hours;
+ALTER TABLE channels DROP COLUMN IF EXISTS endround_checkin_enabled;
+ALTER TABLE channels DROP COLUMN IF EXISTS midround_checkin_percent;
+ALTER TABLE channels DROP COLUMN IF EXISTS midround_checkin_enabled;
+ALTER TABLE channels DROP COLUMN IF EXISTS kickoff_after_hours;
+ALTER TABLE channels DROP COLUMN IF EXISTS kickoff_enabled;
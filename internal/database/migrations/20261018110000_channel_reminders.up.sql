@@ -0,0 +1,10 @@
+-- Reminders sent to pairs during a round of Chat Roulette are configurable per channel.
+-- The defaults match the timings that were previously hard-coded.
+ALTER TABLE channels ADD COLUMN kickoff_enabled boolean DEFAULT true NOT NULL;
+ALTER TABLE channels ADD COLUMN kickoff_after_hours smallint DEFAULT 24 NOT NULL; -- hours after the pair is notified
+ALTER TABLE channels ADD COLUMN midround_checkin_enabled boolean DEFAULT true NOT NULL;
+ALTER TABLE channels ADD COLUMN midround_checkin_percent smallint DEFAULT 50 NOT NULL; -- percentage of the round that has elapsed
+ALTER TABLE channels ADD COLUMN endround_checkin_enabled boolean DEFAULT true NOT NULL;
+ALTER TABLE channels ADD COLUMN endround_checkin_hours smallint DEFAULT 18 NOT NULL; -- hours before the round ends
+ALTER TABLE channels ADD COLUMN mark_inactive_enabled boolean DEFAULT true NOT NULL;
+ALTER TABLE channels ADD COLUMN mark_inactive_hours smallint DEFAULT 1 NOT NULL; -- hours before the round ends
@@ -0,0 +1 @@
+ALTER TYPE JOB_TYPE RENAME VALUE 'RECONCILE_SCHEDULES' TO 'RECONCILE_SCHEDULES_DEPRECATED';
@@ -0,0 +1 @@
+ALTER TYPE JOB_TYPE ADD VALUE 'RECONCILE_SCHEDULES';
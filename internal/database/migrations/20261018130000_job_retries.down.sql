@@ -0,0 +1,20 @@
+CREATE OR REPLACE FUNCTION GetNextJob()
+    RETURNS SETOF jobs
+    AS $$
+        SELECT *
+        FROM jobs
+        WHERE
+            exec_at <= NOW()
+            AND
+            is_completed = false
+            AND
+            status = 'PENDING'
+        ORDER BY priority DESC, created_at
+        LIMIT 1
+        FOR UPDATE SKIP LOCKED;
+    $$
+    language sql;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS next_retry_at;
+ALTER TABLE jobs DROP COLUMN IF EXISTS last_error;
+ALTER TABLE jobs DROP COLUMN IF EXISTS attempts;
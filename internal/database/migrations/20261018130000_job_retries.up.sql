@@ -0,0 +1,25 @@
+-- Track failed attempts for jobs so that they can be retried with exponential backoff
+ALTER TABLE jobs ADD COLUMN attempts smallint DEFAULT 0 NOT NULL;
+ALTER TABLE jobs ADD COLUMN last_error text DEFAULT '' NOT NULL;
+ALTER TABLE jobs ADD COLUMN next_retry_at timestamp without time zone;
+
+-- GetNextJob() retrieves the next available job in the queue,
+-- skipping jobs that are waiting for their next retry
+CREATE OR REPLACE FUNCTION GetNextJob()
+    RETURNS SETOF jobs
+    AS $$
+        SELECT *
+        FROM jobs
+        WHERE
+            exec_at <= NOW()
+            AND
+            (next_retry_at IS NULL OR next_retry_at <= NOW())
+            AND
+            is_completed = false
+            AND
+            status = 'PENDING'
+        ORDER BY priority DESC, created_at
+        LIMIT 1
+        FOR UPDATE SKIP LOCKED;
+    $$
+    language sql;
@@ -0,0 +1,13 @@
+-- Record the history of failed attempts for jobs
+CREATE TABLE IF NOT EXISTS job_attempts (
+    id integer GENERATED ALWAYS AS IDENTITY NOT NULL,
+    job_id integer NOT NULL,
+    attempt smallint NOT NULL,
+    error text NOT NULL,
+    created_at timestamp without time zone DEFAULT NOW()::timestamp NOT NULL,
+
+    CONSTRAINT job_attempts_pk_id PRIMARY KEY (id),
+    CONSTRAINT job_attempts_fk_job_id FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_job_attempts_job_id ON job_attempts(job_id);
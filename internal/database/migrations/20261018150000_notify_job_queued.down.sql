@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS jobs_notify_job_queued ON jobs;
+DROP FUNCTION IF EXISTS NotifyJobQueued();
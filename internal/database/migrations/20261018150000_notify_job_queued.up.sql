@@ -0,0 +1,25 @@
+-- NotifyJobQueued() notifies workers listening on the 'job_queued' channel
+-- when a job is ready to be executed, so that they do not need to poll for it.
+-- The payload is the id of the job, since Postgres collapses duplicate
+-- notifications sent within the same transaction.
+CREATE OR REPLACE FUNCTION NotifyJobQueued()
+    RETURNS trigger
+    AS $$
+    BEGIN
+        IF NEW.status = 'PENDING'
+            AND NEW.is_completed = false
+            AND NEW.exec_at <= NOW()
+            AND (NEW.next_retry_at IS NULL OR NEW.next_retry_at <= NOW())
+        THEN
+            PERFORM pg_notify('job_queued', NEW.id::text);
+        END IF;
+
+        RETURN NEW;
+    END;
+    $$
+    language plpgsql;
+
+CREATE TRIGGER jobs_notify_job_queued
+    AFTER INSERT OR UPDATE OF status, exec_at ON jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION NotifyJobQueued();
@@ -0,0 +1,25 @@
+DROP FUNCTION IF EXISTS ClaimNextJob(VARCHAR, INTEGER);
+
+-- GetNextJob() retrieves the next available job in the queue,
+-- skipping jobs that are waiting for their next retry
+CREATE OR REPLACE FUNCTION GetNextJob()
+    RETURNS SETOF jobs
+    AS $$
+        SELECT *
+        FROM jobs
+        WHERE
+            exec_at <= NOW()
+            AND
+            (next_retry_at IS NULL OR next_retry_at <= NOW())
+            AND
+            is_completed = false
+            AND
+            status = 'PENDING'
+        ORDER BY priority DESC, created_at
+        LIMIT 1
+        FOR UPDATE SKIP LOCKED;
+    $$
+    language sql;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS locked_until;
+ALTER TABLE jobs DROP COLUMN IF EXISTS locked_by;
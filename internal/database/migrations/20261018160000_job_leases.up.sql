@@ -0,0 +1,41 @@
+-- Jobs are claimed by a worker with a lease, instead of holding a row lock for the
+-- duration of the job, so that jobs can be executed outside of a transaction
+ALTER TABLE jobs ADD COLUMN locked_by varchar;
+ALTER TABLE jobs ADD COLUMN locked_until timestamp without time zone;
+
+-- ClaimNextJob() claims the next available job in the queue for a worker by
+-- leasing it for the given number of seconds. A job whose lease has expired,
+-- because the worker executing it has died, is available to be claimed again
+-- and the expired lease is counted as a failed attempt.
+CREATE OR REPLACE FUNCTION ClaimNextJob(p_worker_id VARCHAR, p_lease_seconds INTEGER)
+    RETURNS SETOF jobs
+    AS $$
+        UPDATE jobs
+        SET
+            locked_by = p_worker_id,
+            locked_until = NOW() + make_interval(secs => p_lease_seconds),
+            attempts = CASE WHEN locked_until IS NULL THEN attempts ELSE attempts + 1 END,
+            last_error = CASE WHEN locked_until IS NULL THEN last_error ELSE 'lease expired while the job was executing' END,
+            updated_at = NOW()
+        WHERE id = (
+            SELECT id
+            FROM jobs
+            WHERE
+                exec_at <= NOW()
+                AND
+                (next_retry_at IS NULL OR next_retry_at <= NOW())
+                AND
+                (locked_until IS NULL OR locked_until < NOW())
+                AND
+                is_completed = false
+                AND
+                status = 'PENDING'
+            ORDER BY priority DESC, created_at
+            LIMIT 1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING *;
+    $$
+    language sql;
+
+DROP FUNCTION IF EXISTS GetNextJob();
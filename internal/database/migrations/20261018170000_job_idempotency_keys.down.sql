@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_jobs_idempotency_key;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS idempotency_key;
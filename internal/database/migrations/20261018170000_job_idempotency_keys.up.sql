@@ -0,0 +1,4 @@
+ALTER TABLE jobs ADD COLUMN idempotency_key VARCHAR;
+
+-- Only one pending job can exist for each idempotency key
+CREATE UNIQUE INDEX idx_jobs_idempotency_key ON jobs(idempotency_key) WHERE is_completed = false;
@@ -0,0 +1,59 @@
+-- ClaimNextJob() claims the next available job in the queue for a worker by
+-- leasing it for the given number of seconds. A job whose lease has expired,
+-- because the worker executing it has died, is available to be claimed again
+-- and the expired lease is counted as a failed attempt.
+CREATE OR REPLACE FUNCTION ClaimNextJob(p_worker_id VARCHAR, p_lease_seconds INTEGER)
+    RETURNS SETOF jobs
+    AS $$
+        UPDATE jobs
+        SET
+            locked_by = p_worker_id,
+            locked_until = NOW() + make_interval(secs => p_lease_seconds),
+            attempts = CASE WHEN locked_until IS NULL THEN attempts ELSE attempts + 1 END,
+            last_error = CASE WHEN locked_until IS NULL THEN last_error ELSE 'lease expired while the job was executing' END,
+            updated_at = NOW()
+        WHERE id = (
+            SELECT id
+            FROM jobs
+            WHERE
+                exec_at <= NOW()
+                AND
+                (next_retry_at IS NULL OR next_retry_at <= NOW())
+                AND
+                (locked_until IS NULL OR locked_until < NOW())
+                AND
+                is_completed = false
+                AND
+                status = 'PENDING'
+            ORDER BY priority DESC, created_at
+            LIMIT 1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING *;
+    $$
+    language sql;
+
+-- NotifyJobQueued() notifies workers listening on the 'job_queued' channel
+-- when a job is ready to be executed, so that they do not need to poll for it.
+-- The payload is the id of the job, since Postgres collapses duplicate
+-- notifications sent within the same transaction.
+CREATE OR REPLACE FUNCTION NotifyJobQueued()
+    RETURNS trigger
+    AS $$
+    BEGIN
+        IF NEW.status = 'PENDING'
+            AND NEW.is_completed = false
+            AND NEW.exec_at <= NOW()
+            AND (NEW.next_retry_at IS NULL OR NEW.next_retry_at <= NOW())
+        THEN
+            PERFORM pg_notify('job_queued', NEW.id::text);
+        END IF;
+
+        RETURN NEW;
+    END;
+    $$
+    language plpgsql;
+
+DROP INDEX IF EXISTS idx_jobs_parent_ids;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS parent_ids;
@@ -0,0 +1,2 @@
+-- W3C traceparent of the span that queued the job
+ALTER TABLE jobs ADD COLUMN traceparent VARCHAR;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS channels_idx_team_id;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS team_id;
+ALTER TABLE blocked_members DROP COLUMN IF EXISTS team_id;
+ALTER TABLE members DROP COLUMN IF EXISTS team_id;
+ALTER TABLE channels DROP COLUMN IF EXISTS team_id;
+
+DROP TABLE IF EXISTS workspaces;
@@ -0,0 +1,23 @@
+-- Slack workspaces that the app was installed in with the "Add to Slack" OAuth flow
+CREATE TABLE IF NOT EXISTS workspaces (
+    team_id varchar NOT NULL,
+    team_name varchar NOT NULL,
+    app_id varchar NOT NULL,
+    bot_user_id varchar NOT NULL,
+    bot_token bytea NOT NULL,   -- encrypted with the data encryption key
+    installer varchar NOT NULL, -- The Slack ID of the user who installed the app
+
+    created_at timestamp without time zone DEFAULT NOW()::timestamp NOT NULL,
+    updated_at timestamp without time zone DEFAULT NOW()::timestamp NOT NULL,
+
+    CONSTRAINT workspaces_pk_team_id PRIMARY KEY (team_id)
+);
+
+-- The Slack workspace of each tenant row. This is null for rows that were
+-- created before the app supported multiple workspaces, until backfilled.
+ALTER TABLE channels ADD COLUMN team_id VARCHAR;
+ALTER TABLE members ADD COLUMN team_id VARCHAR;
+ALTER TABLE blocked_members ADD COLUMN team_id VARCHAR;
+ALTER TABLE jobs ADD COLUMN team_id VARCHAR;
+
+CREATE INDEX IF NOT EXISTS channels_idx_team_id ON channels (team_id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS channels_idx_enterprise_id;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS enterprise_id;
+ALTER TABLE members DROP COLUMN IF EXISTS enterprise_id;
+ALTER TABLE channels DROP COLUMN IF EXISTS enterprise_id;
+
+ALTER TABLE workspaces DROP COLUMN IF EXISTS is_enterprise_install;
+ALTER TABLE workspaces DROP COLUMN IF EXISTS enterprise_id;
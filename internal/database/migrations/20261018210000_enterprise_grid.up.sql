@@ -0,0 +1,12 @@
+-- Installations of the app in an Enterprise Grid org. An org-wide installation
+-- is keyed by the ID of the org (enterprise_id) instead of a workspace.
+ALTER TABLE workspaces ADD COLUMN enterprise_id VARCHAR;
+ALTER TABLE workspaces ADD COLUMN is_enterprise_install BOOLEAN DEFAULT false NOT NULL;
+
+-- The Enterprise Grid org of each tenant row, which is null
+-- for workspaces that are not part of an Enterprise Grid org.
+ALTER TABLE channels ADD COLUMN enterprise_id VARCHAR;
+ALTER TABLE members ADD COLUMN enterprise_id VARCHAR;
+ALTER TABLE jobs ADD COLUMN enterprise_id VARCHAR;
+
+CREATE INDEX IF NOT EXISTS channels_idx_enterprise_id ON channels (enterprise_id);
@@ -0,0 +1,2 @@
+ALTER TABLE members DROP COLUMN IF EXISTS is_external;
+ALTER TABLE channels DROP COLUMN IF EXISTS include_external_members;
@@ -0,0 +1,6 @@
+-- Slack Connect channels are shared with other orgs. Members from those orgs
+-- are included by default, matching how they were handled before.
+ALTER TABLE channels ADD COLUMN include_external_members boolean DEFAULT true NOT NULL;
+
+-- Members who belong to a different org than the workspace of the channel
+ALTER TABLE members ADD COLUMN is_external boolean DEFAULT false NOT NULL;
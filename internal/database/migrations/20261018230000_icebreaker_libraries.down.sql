@@ -0,0 +1,15 @@
+DROP FUNCTION IF EXISTS GetIcebreakerV1;
+
+ALTER TABLE matches DROP CONSTRAINT IF EXISTS matches_fk_icebreaker_id;
+ALTER TABLE matches DROP COLUMN IF EXISTS icebreaker_id;
+
+ALTER TABLE channels DROP COLUMN IF EXISTS icebreaker_repeat_rounds;
+ALTER TABLE channels DROP COLUMN IF EXISTS icebreaker_categories;
+
+DELETE FROM icebreakers WHERE channel_id IS NOT NULL;
+DROP INDEX IF EXISTS icebreakers_unique_channel_question;
+ALTER TABLE icebreakers ADD CONSTRAINT icebreakers_unique_question UNIQUE (question);
+
+ALTER TABLE icebreakers DROP CONSTRAINT IF EXISTS icebreakers_fk_channel_id;
+ALTER TABLE icebreakers DROP COLUMN IF EXISTS channel_id;
+ALTER TABLE icebreakers DROP COLUMN IF EXISTS category;
@@ -0,0 +1,53 @@
+DROP FUNCTION IF EXISTS GetIcebreakerV2;
+
+ALTER TABLE icebreakers DROP COLUMN IF EXISTS is_retired;
+
+DROP TABLE IF EXISTS icebreaker_ratings;
+
+-- GetIcebreakerV1() retrieves an icebreaker to share with a match in a Slack channel.
+--
+-- Both the icebreakers shared by every channel and the channel's custom icebreakers are considered.
+-- The function prioritizes in this order:
+-- 1. Icebreakers in the channel's categories, or any category if the channel has not chosen any
+-- 2. Icebreakers that were not shared with any of the users in the channel's last N rounds,
+--    where N is the channel's icebreaker_repeat_rounds
+-- 3. Icebreakers that have been used the least, to balance usage across all icebreakers
+--
+-- Example Usage:
+-- SELECT * FROM GetIcebreakerV1('C122315531', ARRAY['U0123456789', 'U9876543210']);
+CREATE OR REPLACE FUNCTION GetIcebreakerV1(p_channel_id VARCHAR, p_user_ids VARCHAR[])
+RETURNS SETOF icebreakers
+AS $$
+    WITH settings AS (
+        SELECT icebreaker_categories, icebreaker_repeat_rounds
+        FROM channels
+        WHERE channel_id = p_channel_id
+    ),
+    recent AS (
+        SELECT DISTINCT mt.icebreaker_id
+        FROM matches mt
+        INNER JOIN pairings p ON mt.id = p.match_id
+        INNER JOIN members m ON p.member_id = m.id
+        WHERE mt.icebreaker_id IS NOT NULL
+            AND m.channel_id = p_channel_id
+            AND m.user_id = ANY(p_user_ids)
+            AND mt.round_id IN (
+                SELECT r.id
+                FROM rounds r
+                WHERE r.channel_id = p_channel_id
+                ORDER BY r.id DESC
+                LIMIT (SELECT icebreaker_repeat_rounds FROM settings)
+            )
+    )
+    SELECT i.*
+    FROM icebreakers i, settings s
+    WHERE i.channel_id IS NULL OR i.channel_id = p_channel_id
+    ORDER BY
+        CASE WHEN cardinality(s.icebreaker_categories) = 0
+                  OR i.category = ANY(s.icebreaker_categories) THEN 0 ELSE 1 END,
+        CASE WHEN i.id IN (SELECT icebreaker_id FROM recent) THEN 1 ELSE 0 END,
+        i.usage_count,
+        RANDOM()
+    LIMIT 1;
+$$
+language sql;
@@ -0,0 +1,88 @@
+-- Participants rate the icebreaker shared with their match with a thumbs up or down
+CREATE TABLE IF NOT EXISTS icebreaker_ratings (
+    id integer GENERATED ALWAYS AS IDENTITY NOT NULL,
+    icebreaker_id integer NOT NULL,
+    match_id integer NOT NULL,
+    channel_id varchar NOT NULL,
+    user_id varchar NOT NULL,
+    is_positive boolean NOT NULL,
+
+    created_at timestamp without time zone DEFAULT NOW()::timestamp NOT NULL,
+    updated_at timestamp without time zone DEFAULT NOW()::timestamp NOT NULL,
+
+    CONSTRAINT icebreaker_ratings_pk_id PRIMARY KEY (id),
+    CONSTRAINT icebreaker_ratings_fk_icebreaker_id FOREIGN KEY (icebreaker_id) REFERENCES icebreakers(id) ON DELETE CASCADE,
+    CONSTRAINT icebreaker_ratings_fk_match_id FOREIGN KEY (match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    CONSTRAINT icebreaker_ratings_fk_channel_id FOREIGN KEY (channel_id) REFERENCES channels(channel_id) ON DELETE CASCADE,
+    CONSTRAINT icebreaker_ratings_unique_user_per_match UNIQUE (icebreaker_id, match_id, user_id)
+);
+
+CREATE INDEX icebreaker_ratings_idx_channel_id ON icebreaker_ratings (channel_id);
+
+-- Icebreakers that are consistently rated poorly are retired
+ALTER TABLE icebreakers ADD COLUMN is_retired boolean DEFAULT false NOT NULL;
+
+-- GetIcebreakerV2() retrieves an icebreaker to share with a match in a Slack channel.
+--
+-- Both the icebreakers shared by every channel and the channel's custom icebreakers are considered.
+-- The function prioritizes in this order:
+-- 1. Icebreakers that have not been retired for being rated poorly
+-- 2. Icebreakers in the channel's categories, or any category if the channel has not chosen any
+-- 3. Icebreakers that were not shared with any of the users in the channel's last N rounds,
+--    where N is the channel's icebreaker_repeat_rounds
+-- 4. Icebreakers that have been used the least, weighted by their approval rating so that
+--    well-rated icebreakers are shared up to 3 times as often as poorly-rated ones.
+--    Unrated icebreakers have an approval rating of 50%.
+--
+-- Example Usage:
+-- SELECT * FROM GetIcebreakerV2('C122315531', ARRAY['U0123456789', 'U9876543210']);
+CREATE OR REPLACE FUNCTION GetIcebreakerV2(p_channel_id VARCHAR, p_user_ids VARCHAR[])
+RETURNS SETOF icebreakers
+AS $$
+    WITH settings AS (
+        SELECT icebreaker_categories, icebreaker_repeat_rounds
+        FROM channels
+        WHERE channel_id = p_channel_id
+    ),
+    recent AS (
+        SELECT DISTINCT mt.icebreaker_id
+        FROM matches mt
+        INNER JOIN pairings p ON mt.id = p.match_id
+        INNER JOIN members m ON p.member_id = m.id
+        WHERE mt.icebreaker_id IS NOT NULL
+            AND m.channel_id = p_channel_id
+            AND m.user_id = ANY(p_user_ids)
+            AND mt.round_id IN (
+                SELECT r.id
+                FROM rounds r
+                WHERE r.channel_id = p_channel_id
+                ORDER BY r.id DESC
+                LIMIT (SELECT icebreaker_repeat_rounds FROM settings)
+            )
+    ),
+    ratings AS (
+        SELECT
+            icebreaker_id,
+            COUNT(*) FILTER (WHERE is_positive) AS upvotes,
+            COUNT(*) FILTER (WHERE NOT is_positive) AS downvotes
+        FROM icebreaker_ratings
+        GROUP BY icebreaker_id
+    )
+    SELECT i.*
+    FROM icebreakers i
+    CROSS JOIN settings s
+    LEFT JOIN ratings rt ON rt.icebreaker_id = i.id
+    WHERE i.channel_id IS NULL OR i.channel_id = p_channel_id
+    ORDER BY
+        i.is_retired,
+        CASE WHEN cardinality(s.icebreaker_categories) = 0
+                  OR i.category = ANY(s.icebreaker_categories) THEN 0 ELSE 1 END,
+        CASE WHEN i.id IN (SELECT icebreaker_id FROM recent) THEN 1 ELSE 0 END,
+        -- Laplace smoothed approval rating, ie. (upvotes + 1) / (ratings + 2)
+        i.usage_count * (1.5 - (COALESCE(rt.upvotes, 0) + 1.0) / (COALESCE(rt.upvotes, 0) + COALESCE(rt.downvotes, 0) + 2.0)),
+        RANDOM()
+    LIMIT 1;
+$$
+language sql;
+
+DROP FUNCTION GetIcebreakerV1;
@@ -0,0 +1,14 @@
+-- Channel admins can override the Slack message templates for their channel
+CREATE TABLE IF NOT EXISTS message_templates (
+    id integer GENERATED ALWAYS AS IDENTITY NOT NULL,
+    channel_id varchar NOT NULL,
+    name varchar NOT NULL,
+    content text NOT NULL,
+
+    created_at timestamp without time zone DEFAULT NOW()::timestamp NOT NULL,
+    updated_at timestamp without time zone DEFAULT NOW()::timestamp NOT NULL,
+
+    CONSTRAINT message_templates_pk_id PRIMARY KEY (id),
+    CONSTRAINT message_templates_fk_channel_id FOREIGN KEY (channel_id) REFERENCES channels(channel_id) ON DELETE CASCADE,
+    CONSTRAINT message_templates_unique_name_per_channel UNIQUE (channel_id, name)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE members DROP COLUMN IF EXISTS language;
+ALTER TABLE channels DROP COLUMN IF EXISTS language;
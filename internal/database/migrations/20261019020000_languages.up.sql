@@ -0,0 +1,6 @@
+-- The default language of the messages sent by the bot in a channel
+ALTER TABLE channels ADD COLUMN language varchar(8) DEFAULT 'en' NOT NULL;
+
+-- The language chosen by a member, or their Slack locale.
+-- The default language of the channel is used if this is NULL.
+ALTER TABLE members ADD COLUMN language varchar(8);
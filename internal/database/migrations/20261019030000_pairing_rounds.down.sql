@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pairings_round_member;
+ALTER TABLE pairings DROP COLUMN IF EXISTS round_id;
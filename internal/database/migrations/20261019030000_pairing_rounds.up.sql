@@ -0,0 +1,21 @@
+-- The round of the match, so that a member can only be paired once in each round
+ALTER TABLE pairings ADD COLUMN round_id INTEGER;
+
+ALTER TABLE pairings ADD CONSTRAINT pairings_fk_round_id FOREIGN KEY (round_id) REFERENCES rounds(id) ON DELETE CASCADE;
+
+-- Backfill the round of existing pairings. Members who were paired
+-- more than once in the same round are left without a round.
+UPDATE pairings
+SET round_id = matches.round_id
+FROM matches
+WHERE pairings.match_id = matches.id
+    AND NOT EXISTS (
+        SELECT 1
+        FROM pairings p
+            INNER JOIN matches m ON p.match_id = m.id
+        WHERE p.member_id = pairings.member_id
+            AND m.round_id = matches.round_id
+            AND p.match_id != pairings.match_id
+    );
+
+CREATE UNIQUE INDEX idx_pairings_round_member ON pairings(round_id, member_id);
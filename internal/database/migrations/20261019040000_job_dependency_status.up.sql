@@ -0,0 +1,93 @@
+-- ClaimNextJob() claims the next available job in the queue for a worker by
+-- leasing it for the given number of seconds. A job whose lease has expired,
+-- because the worker executing it has died, is available to be claimed again
+-- and the expired lease is counted as a failed attempt. Jobs with parent jobs
+-- that have not succeeded yet are skipped.
+CREATE OR REPLACE FUNCTION ClaimNextJob(p_worker_id VARCHAR, p_lease_seconds INTEGER)
+    RETURNS SETOF jobs
+    AS $$
+        UPDATE jobs
+        SET
+            locked_by = p_worker_id,
+            locked_until = NOW() + make_interval(secs => p_lease_seconds),
+            attempts = CASE WHEN locked_until IS NULL THEN attempts ELSE attempts + 1 END,
+            last_error = CASE WHEN locked_until IS NULL THEN last_error ELSE 'lease expired while the job was executing' END,
+            updated_at = NOW()
+        WHERE id = (
+            SELECT id
+            FROM jobs
+            WHERE
+                exec_at <= NOW()
+                AND
+                (next_retry_at IS NULL OR next_retry_at <= NOW())
+                AND
+                (locked_until IS NULL OR locked_until < NOW())
+                AND
+                is_completed = false
+                AND
+                status = 'PENDING'
+                AND
+                NOT EXISTS (
+                    SELECT 1
+                    FROM jobs AS parent
+                    WHERE parent.id = ANY(jobs.parent_ids) AND parent.status != 'SUCCEEDED'
+                )
+            ORDER BY priority DESC, created_at
+            LIMIT 1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING *;
+    $$
+    language sql;
+
+-- NotifyJobQueued() notifies workers listening on the 'job_queued' channel
+-- when a job is ready to be executed, so that they do not need to poll for it.
+-- The payload is the id of the job, since Postgres collapses duplicate
+-- notifications sent within the same transaction. When a job succeeds,
+-- workers are also notified of its child jobs that are now ready to be executed.
+--
+-- When a job fails or is canceled, its pending child jobs are canceled, since they
+-- must not be executed without it. Canceling a child job cancels its own children.
+CREATE OR REPLACE FUNCTION NotifyJobQueued()
+    RETURNS trigger
+    AS $$
+    DECLARE
+        child_id INTEGER;
+    BEGIN
+        IF NEW.status = 'PENDING'
+            AND NEW.is_completed = false
+            AND NEW.exec_at <= NOW()
+            AND (NEW.next_retry_at IS NULL OR NEW.next_retry_at <= NOW())
+            AND NOT EXISTS (
+                SELECT 1
+                FROM jobs AS parent
+                WHERE parent.id = ANY(NEW.parent_ids) AND parent.status != 'SUCCEEDED'
+            )
+        THEN
+            PERFORM pg_notify('job_queued', NEW.id::text);
+        END IF;
+
+        IF NEW.is_completed = true AND NEW.status = 'SUCCEEDED' THEN
+            FOR child_id IN
+                SELECT id
+                FROM jobs
+                WHERE NEW.id = ANY(parent_ids) AND is_completed = false
+            LOOP
+                PERFORM pg_notify('job_queued', child_id::text);
+            END LOOP;
+        END IF;
+
+        IF NEW.is_completed = true AND NEW.status IN ('FAILED', 'CANCELED') THEN
+            UPDATE jobs
+            SET
+                status = 'CANCELED',
+                is_completed = true,
+                last_error = format('parent %s job did not succeed', NEW.job_type),
+                updated_at = NOW()
+            WHERE NEW.id = ANY(parent_ids) AND is_completed = false;
+        END IF;
+
+        RETURN NEW;
+    END;
+    $$
+    language plpgsql;
@@ -0,0 +1,5 @@
+-- Members added before external members were tracked default to is_external = false,
+-- so they are checked once with the Slack API to backfill the flag
+ALTER TABLE members ADD COLUMN is_external_checked boolean DEFAULT false NOT NULL;
+
+UPDATE members SET is_external_checked = true WHERE is_external;
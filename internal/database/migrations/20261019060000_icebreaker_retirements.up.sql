@@ -0,0 +1,99 @@
+-- Icebreakers are retired per Slack channel, so that an icebreaker shared by every
+-- channel is only retired in the channels where it is consistently rated poorly
+CREATE TABLE IF NOT EXISTS retired_icebreakers (
+    channel_id varchar NOT NULL,
+    icebreaker_id integer NOT NULL,
+
+    created_at timestamp without time zone DEFAULT NOW()::timestamp NOT NULL,
+
+    CONSTRAINT retired_icebreakers_pk PRIMARY KEY (channel_id, icebreaker_id),
+    CONSTRAINT retired_icebreakers_fk_channel_id FOREIGN KEY (channel_id) REFERENCES channels(channel_id) ON DELETE CASCADE,
+    CONSTRAINT retired_icebreakers_fk_icebreaker_id FOREIGN KEY (icebreaker_id) REFERENCES icebreakers(id) ON DELETE CASCADE
+);
+
+-- Retired icebreakers stay retired in the channels whose ratings retired them
+INSERT INTO retired_icebreakers (channel_id, icebreaker_id)
+SELECT r.channel_id, r.icebreaker_id
+FROM icebreaker_ratings r
+INNER JOIN icebreakers i ON i.id = r.icebreaker_id
+WHERE i.is_retired
+GROUP BY r.channel_id, r.icebreaker_id
+HAVING COUNT(*) >= 10 AND AVG(r.is_positive::int) < 0.25;
+
+-- GetIcebreakerV3() retrieves an icebreaker to share with a match in a Slack channel.
+--
+-- Both the icebreakers shared by every channel and the channel's custom icebreakers are considered.
+-- The function prioritizes in this order:
+-- 1. Icebreakers that have not been retired in the channel for being rated poorly
+-- 2. Icebreakers in the channel's categories, or any category if the channel has not chosen any
+-- 3. Icebreakers that were not shared with any of the users in the channel's last N rounds,
+--    where N is the channel's icebreaker_repeat_rounds
+-- 4. Icebreakers that have been used the least, weighted by their approval rating so that
+--    well-rated icebreakers are shared up to 3 times as often as poorly-rated ones.
+--    Unrated icebreakers have an approval rating of 50%.
+--
+-- Example Usage:
+-- SELECT * FROM GetIcebreakerV3('C122315531', ARRAY['U0123456789', 'U9876543210']);
+CREATE OR REPLACE FUNCTION GetIcebreakerV3(p_channel_id VARCHAR, p_user_ids VARCHAR[])
+RETURNS SETOF icebreakers
+AS $$
+    WITH settings AS (
+        SELECT icebreaker_categories, icebreaker_repeat_rounds
+        FROM channels
+        WHERE channel_id = p_channel_id
+    ),
+    candidates AS (
+        SELECT id
+        FROM icebreakers
+        WHERE channel_id IS NULL OR channel_id = p_channel_id
+    ),
+    recent AS (
+        SELECT DISTINCT mt.icebreaker_id
+        FROM matches mt
+        INNER JOIN pairings p ON mt.id = p.match_id
+        INNER JOIN members m ON p.member_id = m.id
+        WHERE mt.icebreaker_id IS NOT NULL
+            AND m.channel_id = p_channel_id
+            AND m.user_id = ANY(p_user_ids)
+            AND mt.round_id IN (
+                SELECT r.id
+                FROM rounds r
+                WHERE r.channel_id = p_channel_id
+                ORDER BY r.id DESC
+                LIMIT (SELECT icebreaker_repeat_rounds FROM settings)
+            )
+    ),
+    retired AS (
+        SELECT icebreaker_id
+        FROM retired_icebreakers
+        WHERE channel_id = p_channel_id
+    ),
+    ratings AS (
+        SELECT
+            icebreaker_id,
+            COUNT(*) FILTER (WHERE is_positive) AS upvotes,
+            COUNT(*) FILTER (WHERE NOT is_positive) AS downvotes
+        FROM icebreaker_ratings
+        WHERE icebreaker_id IN (SELECT id FROM candidates)
+        GROUP BY icebreaker_id
+    )
+    SELECT i.*
+    FROM icebreakers i
+    CROSS JOIN settings s
+    LEFT JOIN ratings rt ON rt.icebreaker_id = i.id
+    WHERE i.id IN (SELECT id FROM candidates)
+    ORDER BY
+        CASE WHEN i.id IN (SELECT icebreaker_id FROM retired) THEN 1 ELSE 0 END,
+        CASE WHEN cardinality(s.icebreaker_categories) = 0
+                  OR i.category = ANY(s.icebreaker_categories) THEN 0 ELSE 1 END,
+        CASE WHEN i.id IN (SELECT icebreaker_id FROM recent) THEN 1 ELSE 0 END,
+        -- Laplace smoothed approval rating, ie. (upvotes + 1) / (ratings + 2)
+        i.usage_count * (1.5 - (COALESCE(rt.upvotes, 0) + 1.0) / (COALESCE(rt.upvotes, 0) + COALESCE(rt.downvotes, 0) + 2.0)),
+        RANDOM()
+    LIMIT 1;
+$$
+language sql;
+
+DROP FUNCTION GetIcebreakerV2;
+
+ALTER TABLE icebreakers DROP COLUMN IF EXISTS is_retired;
@@ -0,0 +1,3 @@
+ALTER TABLE job_attempts DROP COLUMN IF EXISTS replay;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS replays;
@@ -0,0 +1,5 @@
+-- Jobs replayed from the dead-letter queue start again from their first attempt,
+-- so failed attempts are recorded along with the replay that they belong to
+ALTER TABLE jobs ADD COLUMN replays smallint DEFAULT 0 NOT NULL;
+
+ALTER TABLE job_attempts ADD COLUMN replay smallint DEFAULT 0 NOT NULL;
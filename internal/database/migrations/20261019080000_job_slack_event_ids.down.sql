@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_jobs_slack_event_id;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS slack_event_id;
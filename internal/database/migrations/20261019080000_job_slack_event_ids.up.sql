@@ -0,0 +1,6 @@
+-- Slack retries the delivery of an event that was not acknowledged in time, even if
+-- the jobs queued for it have already completed, so those jobs are deduplicated on
+-- the ID of the Slack event regardless of whether they have completed
+ALTER TABLE jobs ADD COLUMN slack_event_id VARCHAR;
+
+CREATE UNIQUE INDEX idx_jobs_slack_event_id ON jobs(job_type, slack_event_id);
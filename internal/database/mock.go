@@ -8,6 +8,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/bincyber/go-sqlcrypter"
+	"github.com/lib/pq"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
@@ -66,6 +67,143 @@ func MockQueueJob(mock sqlmock.Sqlmock, params interface{}, job string, priority
 	mock.ExpectCommit()
 }
 
+// MockQueueJobWithKey is a helper function to mock queueing a job with an idempotency key.
+func MockQueueJobWithKey(mock sqlmock.Sqlmock, params interface{}, job string, priority int, key string) {
+	data, _ := json.Marshal(params)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "jobs" (.+) VALUES (.+) ON CONFLICT DO NOTHING RETURNING`).
+		WithArgs(
+			sqlmock.AnyArg(),
+			job,
+			priority,
+			models.JobStatusPending,
+			false,
+			string(data),
+			AnyTime(),
+			AnyTime(),
+			AnyTime(),
+			key,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+}
+
+// MockQueueJobForTeam is a helper function to mock queueing a job for a Slack workspace.
+func MockQueueJobForTeam(mock sqlmock.Sqlmock, params interface{}, job string, priority int, teamID string) {
+	data, _ := json.Marshal(params)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "jobs" (.+) VALUES (.+) RETURNING`).
+		WithArgs(
+			sqlmock.AnyArg(),
+			job,
+			priority,
+			models.JobStatusPending,
+			false,
+			string(data),
+			AnyTime(),
+			AnyTime(),
+			AnyTime(),
+			teamID,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+}
+
+// MockQueueJobWithKeyForTeam is a helper function to mock queueing a job
+// with an idempotency key for a Slack workspace.
+func MockQueueJobWithKeyForTeam(mock sqlmock.Sqlmock, params interface{}, job string, priority int, key, teamID string) {
+	data, _ := json.Marshal(params)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "jobs" (.+) VALUES (.+) ON CONFLICT DO NOTHING RETURNING`).
+		WithArgs(
+			sqlmock.AnyArg(),
+			job,
+			priority,
+			models.JobStatusPending,
+			false,
+			string(data),
+			AnyTime(),
+			AnyTime(),
+			AnyTime(),
+			key,
+			teamID,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+}
+
+// MockQueueJobWithKeyForEnterprise is a helper function to mock queueing a job
+// with an idempotency key for every Slack workspace in an Enterprise Grid org.
+func MockQueueJobWithKeyForEnterprise(mock sqlmock.Sqlmock, params interface{}, job string, priority int, key, enterpriseID string) {
+	data, _ := json.Marshal(params)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "jobs" (.+) VALUES (.+) ON CONFLICT DO NOTHING RETURNING`).
+		WithArgs(
+			sqlmock.AnyArg(),
+			job,
+			priority,
+			models.JobStatusPending,
+			false,
+			string(data),
+			AnyTime(),
+			AnyTime(),
+			AnyTime(),
+			key,
+			enterpriseID,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+}
+
+// MockQueueJobWithParents is a helper function to mock queueing a job that depends on parent jobs.
+func MockQueueJobWithParents(mock sqlmock.Sqlmock, params interface{}, job string, priority int, parentIDs ...int32) {
+	data, _ := json.Marshal(params)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "jobs" (.+) VALUES (.+) RETURNING`).
+		WithArgs(
+			sqlmock.AnyArg(),
+			job,
+			priority,
+			models.JobStatusPending,
+			false,
+			string(data),
+			AnyTime(),
+			AnyTime(),
+			AnyTime(),
+			pq.Int32Array(parentIDs),
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+}
+
+// MockQueueJobInTransaction is a helper function to mock queueing a job in a transaction
+// that has already begun. The args are the values of the optional columns of the job,
+// such as its idempotency key or parent job IDs, in the order that they are inserted.
+func MockQueueJobInTransaction(mock sqlmock.Sqlmock, params interface{}, job string, priority int, args ...driver.Value) {
+	data, _ := json.Marshal(params)
+
+	mock.ExpectQuery(`INSERT INTO "jobs" (.+) VALUES (.+) RETURNING`).
+		WithArgs(
+			append([]driver.Value{
+				sqlmock.AnyArg(),
+				job,
+				priority,
+				models.JobStatusPending,
+				false,
+				string(data),
+				AnyTime(),
+				AnyTime(),
+				AnyTime(),
+			}, args...)...,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
 // NoOpCrypter is a no-op crypter useful for testing.
 type NoOpCrypter struct{}
 
@@ -108,6 +108,9 @@ const (
 
 	// JobTypeUnblockMember is the job for unblocking a Slack member from being matched with a user
 	JobTypeUnblockMember
+
+	// JobTypeReconcileSchedules is the job for ensuring every channel has a CREATE_ROUND job scheduled for its next round
+	JobTypeReconcileSchedules
 )
 
 // IntervalEnum is an enum for chat roulette intervals
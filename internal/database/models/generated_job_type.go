@@ -11,11 +11,11 @@ import (
 	"strings"
 )
 
-const _jobTypeEnumName = "UNKNOWNADD_CHANNELGREET_ADMINUPDATE_CHANNELDELETE_CHANNELSYNC_CHANNELSADD_MEMBERUPDATE_MEMBERGREET_MEMBERDELETE_MEMBERSYNC_MEMBERSCREATE_ROUNDEND_ROUNDCREATE_MATCHESREPORT_MATCHESCREATE_MATCHUPDATE_MATCHCREATE_PAIRNOTIFY_PAIRKICKOFF_PAIRNOTIFY_MEMBERCHECK_PAIRREPORT_STATSMARK_INACTIVEBLOCK_MEMBERUNBLOCK_MEMBER"
+const _jobTypeEnumName = "UNKNOWNADD_CHANNELGREET_ADMINUPDATE_CHANNELDELETE_CHANNELSYNC_CHANNELSADD_MEMBERUPDATE_MEMBERGREET_MEMBERDELETE_MEMBERSYNC_MEMBERSCREATE_ROUNDEND_ROUNDCREATE_MATCHESREPORT_MATCHESCREATE_MATCHUPDATE_MATCHCREATE_PAIRNOTIFY_PAIRKICKOFF_PAIRNOTIFY_MEMBERCHECK_PAIRREPORT_STATSMARK_INACTIVEBLOCK_MEMBERUNBLOCK_MEMBERRECONCILE_SCHEDULES"
 
-var _jobTypeEnumIndex = [...]uint16{0, 7, 18, 29, 43, 57, 70, 80, 93, 105, 118, 130, 142, 151, 165, 179, 191, 203, 214, 225, 237, 250, 260, 272, 285, 297, 311}
+var _jobTypeEnumIndex = [...]uint16{0, 7, 18, 29, 43, 57, 70, 80, 93, 105, 118, 130, 142, 151, 165, 179, 191, 203, 214, 225, 237, 250, 260, 272, 285, 297, 311, 330}
 
-const _jobTypeEnumLowerName = "unknownadd_channelgreet_adminupdate_channeldelete_channelsync_channelsadd_memberupdate_membergreet_memberdelete_membersync_memberscreate_roundend_roundcreate_matchesreport_matchescreate_matchupdate_matchcreate_pairnotify_pairkickoff_pairnotify_membercheck_pairreport_statsmark_inactiveblock_memberunblock_member"
+const _jobTypeEnumLowerName = "unknownadd_channelgreet_adminupdate_channeldelete_channelsync_channelsadd_memberupdate_membergreet_memberdelete_membersync_memberscreate_roundend_roundcreate_matchesreport_matchescreate_matchupdate_matchcreate_pairnotify_pairkickoff_pairnotify_membercheck_pairreport_statsmark_inactiveblock_memberunblock_memberreconcile_schedules"
 
 func (i jobTypeEnum) String() string {
 	if i < 0 || i >= jobTypeEnum(len(_jobTypeEnumIndex)-1) {
@@ -54,9 +54,10 @@ func _jobTypeEnumNoOp() {
 	_ = x[JobTypeMarkInactive-(23)]
 	_ = x[JobTypeBlockMember-(24)]
 	_ = x[JobTypeUnblockMember-(25)]
+	_ = x[JobTypeReconcileSchedules-(26)]
 }
 
-var _jobTypeEnumValues = []jobTypeEnum{JobTypeUnknown, JobTypeAddChannel, JobTypeGreetAdmin, JobTypeUpdateChannel, JobTypeDeleteChannel, JobTypeSyncChannels, JobTypeAddMember, JobTypeUpdateMember, JobTypeGreetMember, JobTypeDeleteMember, JobTypeSyncMembers, JobTypeCreateRound, JobTypeEndRound, JobTypeCreateMatches, JobTypeReportMatches, JobTypeCreateMatch, JobTypeUpdateMatch, JobTypeCreatePair, JobTypeNotifyPair, JobTypeKickoffPair, JobTypeNotifyMember, JobTypeCheckPair, JobTypeReportStats, JobTypeMarkInactive, JobTypeBlockMember, JobTypeUnblockMember}
+var _jobTypeEnumValues = []jobTypeEnum{JobTypeUnknown, JobTypeAddChannel, JobTypeGreetAdmin, JobTypeUpdateChannel, JobTypeDeleteChannel, JobTypeSyncChannels, JobTypeAddMember, JobTypeUpdateMember, JobTypeGreetMember, JobTypeDeleteMember, JobTypeSyncMembers, JobTypeCreateRound, JobTypeEndRound, JobTypeCreateMatches, JobTypeReportMatches, JobTypeCreateMatch, JobTypeUpdateMatch, JobTypeCreatePair, JobTypeNotifyPair, JobTypeKickoffPair, JobTypeNotifyMember, JobTypeCheckPair, JobTypeReportStats, JobTypeMarkInactive, JobTypeBlockMember, JobTypeUnblockMember, JobTypeReconcileSchedules}
 
 var _jobTypeEnumNameToValueMap = map[string]jobTypeEnum{
 	_jobTypeEnumName[0:7]:          JobTypeUnknown,
@@ -111,6 +112,8 @@ var _jobTypeEnumNameToValueMap = map[string]jobTypeEnum{
 	_jobTypeEnumLowerName[285:297]: JobTypeBlockMember,
 	_jobTypeEnumName[297:311]:      JobTypeUnblockMember,
 	_jobTypeEnumLowerName[297:311]: JobTypeUnblockMember,
+	_jobTypeEnumName[311:330]:      JobTypeReconcileSchedules,
+	_jobTypeEnumLowerName[311:330]: JobTypeReconcileSchedules,
 }
 
 var _jobTypeEnumNames = []string{
@@ -140,6 +143,7 @@ var _jobTypeEnumNames = []string{
 	_jobTypeEnumName[272:285],
 	_jobTypeEnumName[285:297],
 	_jobTypeEnumName[297:311],
+	_jobTypeEnumName[311:330],
 }
 
 // jobTypeEnumString retrieves an enum value from the enum constants string name.
@@ -36,6 +36,18 @@ type GenericJob[T any] struct {
 	// ExecAt is the execution time.
 	// This is optional and will default to time.Now().
 	ExecAt time.Time
+	// IdempotencyKey deduplicates the job against pending jobs with the same key.
+	// This is optional and should be derived with IdempotencyKey().
+	IdempotencyKey string
+	// ParentIDs are the IDs of the jobs that must succeed before this job is executed.
+	// This is optional.
+	ParentIDs []int32
+}
+
+// IdempotencyKey derives the idempotency key for a job from its job type
+// and the values that identify it, eg: ADD_MEMBER:C0123456789:U0123456789
+func IdempotencyKey(jobType jobTypeEnum, parts ...string) string {
+	return strings.Join(append([]string{jobType.String()}, parts...), ":")
 }
 
 // FormatSlackActionID creates a pipe ("|") separated Slack action ID
@@ -58,6 +70,11 @@ func ExtractJobFromActionID(actionID string) (jobTypeEnum, error) {
 	return 0, enumerrs.ErrValueInvalid
 }
 
+// ParseJobType parses the name of a job type, such as "CREATE_ROUND"
+func ParseJobType(s string) (jobTypeEnum, error) {
+	return jobTypeEnumString(strings.ToUpper(s))
+}
+
 // JobRequiresSlackChannel ...
 func JobRequiresSlackChannel(jobType jobTypeEnum) bool {
 	switch jobType {
@@ -67,7 +84,26 @@ func JobRequiresSlackChannel(jobType jobTypeEnum) bool {
 		return false
 	case JobTypeBlockMember, JobTypeUnblockMember:
 		return false
+	case JobTypeReconcileSchedules:
+		return false
 	default:
 		return true
 	}
 }
+
+// JobMaxAttempts returns the number of times a job is attempted before it is marked as failed.
+func JobMaxAttempts(jobType jobTypeEnum) int {
+	switch jobType {
+	case JobTypeCreateRound, JobTypeEndRound, JobTypeCreateMatches:
+		// The schedule for a channel depends on these jobs succeeding
+		return 10
+	case JobTypeSyncChannels, JobTypeSyncMembers, JobTypeReconcileSchedules:
+		// These jobs are repeated regularly, so there is no need to retry them often
+		return 3
+	case JobTypeKickoffPair, JobTypeCheckPair, JobTypeNotifyMember:
+		// These messages are time-sensitive and become irrelevant if they are delayed for too long
+		return 3
+	default:
+		return 5
+	}
+}
@@ -33,6 +33,20 @@ func Test_ExtractJobFromActionID(t *testing.T) {
 	})
 }
 
+func Test_ParseJobType(t *testing.T) {
+	jobType, err := ParseJobType("create_round")
+	assert.Nil(t, err)
+	assert.Equal(t, JobTypeCreateRound, jobType)
+
+	_, err = ParseJobType("qwerty")
+	assert.NotNil(t, err)
+}
+
+func Test_IdempotencyKey(t *testing.T) {
+	assert.Equal(t, "ADD_MEMBER:C0123456789:U0123456789", IdempotencyKey(JobTypeAddMember, "C0123456789", "U0123456789"))
+	assert.Equal(t, "SYNC_CHANNELS", IdempotencyKey(JobTypeSyncChannels))
+}
+
 func Test_JobRequiresSlackChannel(t *testing.T) {
 	t.Run("ADD_CHANNEL", func(t *testing.T) {
 		v := JobRequiresSlackChannel(JobTypeAddChannel)
@@ -43,6 +57,18 @@ func Test_JobRequiresSlackChannel(t *testing.T) {
 		v := JobRequiresSlackChannel(JobTypeAddMember)
 		assert.True(t, v)
 	})
+
+	t.Run("RECONCILE_SCHEDULES", func(t *testing.T) {
+		v := JobRequiresSlackChannel(JobTypeReconcileSchedules)
+		assert.False(t, v)
+	})
+}
+
+func Test_JobMaxAttempts(t *testing.T) {
+	assert.Equal(t, 10, JobMaxAttempts(JobTypeCreateRound))
+	assert.Equal(t, 3, JobMaxAttempts(JobTypeSyncMembers))
+	assert.Equal(t, 3, JobMaxAttempts(JobTypeCheckPair))
+	assert.Equal(t, 5, JobMaxAttempts(JobTypeAddMember))
 }
 
 func Test_IsError(t *testing.T) {
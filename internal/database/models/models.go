@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/bincyber/go-sqlcrypter"
+	"github.com/lib/pq"
 	"github.com/segmentio/ksuid"
 	"gorm.io/datatypes"
 )
@@ -13,6 +14,12 @@ type Channel struct {
 	// ChannelID is the ID of the Slack channel
 	ChannelID string `gorm:"primaryKey"`
 
+	// TeamID is the ID of the Slack workspace of the channel
+	TeamID string `gorm:"default:null"`
+
+	// EnterpriseID is the ID of the Enterprise Grid org of the Slack workspace
+	EnterpriseID string `gorm:"default:null"`
+
 	// Inviter is the ID of the user who has invited the bot to the Slack channel
 	Inviter string
 
@@ -31,6 +38,23 @@ type Channel struct {
 	// NextRound is the timestamp of the next chat roulette round
 	NextRound time.Time
 
+	// Reminders are the settings for the reminders sent to pairs during a round
+	Reminders ReminderSettings `gorm:"embedded"`
+
+	// IncludeExternalMembers is a boolean flag for if members from other orgs
+	// in a Slack Connect channel participate in chat roulette
+	IncludeExternalMembers *bool `gorm:"default:true"`
+
+	// IcebreakerCategories are the categories of icebreakers shared with pairs.
+	// Icebreakers from every category are shared if this is empty.
+	IcebreakerCategories pq.StringArray `gorm:"type:text[];default:'{}'"`
+
+	// IcebreakerRepeatRounds is the number of rounds before an icebreaker can be shared with a member again
+	IcebreakerRepeatRounds int `gorm:"default:5"`
+
+	// Language is the default language of the messages sent by the bot in the channel
+	Language string `gorm:"default:en"`
+
 	// CreatedAt is the timestamp of when the record was first created
 	CreatedAt time.Time
 
@@ -38,6 +62,34 @@ type Channel struct {
 	UpdatedAt time.Time
 }
 
+// ReminderSettings are the per-channel settings for when the
+// KICKOFF_PAIR, CHECK_PAIR, and MARK_INACTIVE jobs are run for a match.
+type ReminderSettings struct {
+	// KickoffEnabled is a boolean flag for if an icebreaker is sent to pairs
+	KickoffEnabled *bool `gorm:"default:true"`
+
+	// KickoffAfterHours is the number of hours after a pair is notified to send the icebreaker
+	KickoffAfterHours int `gorm:"default:24"`
+
+	// MidRoundCheckInEnabled is a boolean flag for if pairs are checked in on in the middle of the round
+	MidRoundCheckInEnabled *bool `gorm:"column:midround_checkin_enabled;default:true"`
+
+	// MidRoundCheckInPercent is the percentage of the round that has elapsed when the mid-round check-in is sent
+	MidRoundCheckInPercent int `gorm:"column:midround_checkin_percent;default:50"`
+
+	// EndRoundCheckInEnabled is a boolean flag for if pairs are checked in on at the end of the round
+	EndRoundCheckInEnabled *bool `gorm:"column:endround_checkin_enabled;default:true"`
+
+	// EndRoundCheckInHours is the number of hours before the end of the round to send the end-of-round check-in
+	EndRoundCheckInHours int `gorm:"column:endround_checkin_hours;default:18"`
+
+	// MarkInactiveEnabled is a boolean flag for if participants who never message their partner are marked inactive
+	MarkInactiveEnabled *bool `gorm:"default:true"`
+
+	// MarkInactiveHours is the number of hours before the end of the round to evaluate inactivity
+	MarkInactiveHours int `gorm:"default:1"`
+}
+
 // Member represents a row in the members table
 type Member struct {
 	// ID is the primary key for the table
@@ -49,6 +101,12 @@ type Member struct {
 	// ChannelID is the ID of the Slack channel that the user is a member of
 	ChannelID string `gorm:"foreignKey:ChannelID;references:Channel"`
 
+	// TeamID is the ID of the Slack workspace of the user
+	TeamID string `gorm:"default:null"`
+
+	// EnterpriseID is the ID of the Enterprise Grid org of the Slack workspace
+	EnterpriseID string `gorm:"default:null"`
+
 	// ConnectionMode is the preferred mode of connection (virtual, in-person, or hybrid for no preference)
 	ConnectionMode ConnectionMode `gorm:"type:connection_mode;default:'ConnectionMode(3)'"`
 
@@ -84,6 +142,19 @@ type Member struct {
 	// A pointer is used here to ensure non-zero value (ie. false) is saved.
 	HasGenderPreference *bool
 
+	// IsExternal is a boolean flag for if the user belongs to a different org
+	// than the workspace of the Slack channel, ie. in a Slack Connect channel
+	IsExternal bool
+
+	// IsExternalChecked is a boolean flag for if IsExternal was determined with the Slack API.
+	// It is false for members added before external members were tracked.
+	IsExternalChecked bool
+
+	// Language is the language of the messages sent by the bot to the user, either
+	// chosen by the user or taken from their Slack locale. The default language
+	// of the Slack channel is used if this is empty.
+	Language string `gorm:"default:null"`
+
 	// CreatedAt is the timestamp of when the record was first created
 	CreatedAt time.Time
 
@@ -105,6 +176,13 @@ type Round struct {
 	// InactiveParticipants tracks how many participants were marked as inactive during this round
 	InactiveParticipants int16 `gorm:"column:inactive_users"`
 
+	// IsAdHoc is a boolean flag for if this is a one-off round started outside of the channel's regular schedule
+	IsAdHoc bool `gorm:"column:is_adhoc"`
+
+	// EndsAt is the timestamp of when an ad-hoc round ends. This is null for regular rounds,
+	// which end when the next round starts.
+	EndsAt *time.Time
+
 	// CreatedAt is the timestamp of when the record was first created
 	CreatedAt time.Time
 
@@ -123,6 +201,9 @@ type Match struct {
 	// MpimID is the ID of the Slack group DM
 	MpimID string `gorm:"column:mpim_id"`
 
+	// IcebreakerID is the ID of the icebreaker that was shared with the match
+	IcebreakerID *int32 `gorm:"default:null"`
+
 	// HasMet is a boolean flag for if the match has met for chat roulette
 	HasMet bool
 
@@ -147,6 +228,9 @@ type Pairing struct {
 	// MemberID is the ID of the Slack user in this chat roulette match
 	MemberID int32 `gorm:"primaryKey;foreignKey:MemberID;references:Members"`
 
+	// RoundID is the ID of the round of the match. A member can only be paired once in each round.
+	RoundID *int32 `gorm:"default:null"`
+
 	// CreatedAt is the timestamp of when the record was first created
 	CreatedAt time.Time
 }
@@ -156,9 +240,16 @@ type Icebreaker struct {
 	// ID is an auto-incrementing identifier for the table
 	ID int32 `gorm:"autoIncrement"`
 
+	// ChannelID is the ID of the Slack channel for a custom icebreaker.
+	// This is empty for icebreakers that are shared by every channel.
+	ChannelID string `gorm:"default:null"`
+
 	// Question is the icebreaker question
 	Question string
 
+	// Category is the category (ie, theme) of the icebreaker
+	Category string `gorm:"default:general"`
+
 	// UsageCount is the count of how many times this icebreaker has been selected
 	UsageCount int32 `gorm:"column:usage_count"`
 
@@ -169,6 +260,54 @@ type Icebreaker struct {
 	UpdatedAt time.Time
 }
 
+// IcebreakerRating represents a row in the icebreaker_ratings table
+type IcebreakerRating struct {
+	// ID is the primary key for the table
+	ID int32 `gorm:"primaryKey"`
+
+	// IcebreakerID is the ID of the icebreaker that was rated
+	IcebreakerID int32 `gorm:"foreignKey:IcebreakerID;references:Icebreaker"`
+
+	// MatchID is the ID of the match that the icebreaker was shared with
+	MatchID int32 `gorm:"foreignKey:MatchID;references:Match"`
+
+	// ChannelID is the ID of the Slack channel of the match
+	ChannelID string `gorm:"foreignKey:ChannelID;references:Channel"`
+
+	// UserID is the ID of the Slack user who rated the icebreaker
+	UserID string
+
+	// IsPositive is a boolean flag for if the rating is a thumbs up
+	IsPositive bool
+
+	// CreatedAt is the timestamp of when the record was first created
+	CreatedAt time.Time
+
+	// UpdatedAt is the timestamp of when the record was last updated
+	UpdatedAt time.Time
+}
+
+// MessageTemplate represents a row in the message_templates table
+type MessageTemplate struct {
+	// ID is the primary key for the table
+	ID int32 `gorm:"primaryKey"`
+
+	// ChannelID is the ID of the Slack channel that the template is overridden for
+	ChannelID string `gorm:"foreignKey:ChannelID;references:Channel"`
+
+	// Name is the name of the Slack message template that is overridden
+	Name string
+
+	// Content is the Go template for the Slack message in Block Kit JSON
+	Content string
+
+	// CreatedAt is the timestamp of when the record was first created
+	CreatedAt time.Time
+
+	// UpdatedAt is the timestamp of when the record was last updated
+	UpdatedAt time.Time
+}
+
 // Job represents a row in the jobs table
 type Job struct {
 	// ID is the primary key of the record
@@ -195,6 +334,44 @@ type Job struct {
 	// ExecAt is the timestamp of when the job should be executed
 	ExecAt time.Time
 
+	// IdempotencyKey deduplicates the job against other pending jobs with the same key
+	IdempotencyKey *string `gorm:"default:null"`
+
+	// SlackEventID is the ID of the Slack event that the job was queued for,
+	// which deduplicates the job against retried deliveries of the event
+	SlackEventID *string `gorm:"default:null"`
+
+	// ParentIDs are the IDs of the jobs that must succeed before this job can be executed.
+	// The job is canceled if any of them fails or is canceled, and they are cleared when it is replayed.
+	ParentIDs pq.Int32Array `gorm:"type:integer[];default:'{}'"`
+
+	// TraceParent is the W3C traceparent of the span that queued the job
+	TraceParent *string `gorm:"column:traceparent;default:null"`
+
+	// TeamID is the ID of the Slack workspace that the job is executed for
+	TeamID string `gorm:"default:null"`
+
+	// EnterpriseID is the ID of the Enterprise Grid org that the job is executed for
+	EnterpriseID string `gorm:"default:null"`
+
+	// Attempts is the number of times the job has failed to execute since it was last replayed
+	Attempts int `gorm:"<-:update"`
+
+	// Replays is the number of times the job was replayed from the dead-letter queue
+	Replays int `gorm:"<-:update"`
+
+	// LastError is the error from the most recent failed attempt
+	LastError string `gorm:"<-:update"`
+
+	// NextRetryAt is the timestamp of when a failed job can be retried
+	NextRetryAt *time.Time `gorm:"<-:update"`
+
+	// LockedBy is the ID of the worker that has claimed the job
+	LockedBy *string `gorm:"<-:update"`
+
+	// LockedUntil is the timestamp of when the worker's lease on the job expires
+	LockedUntil *time.Time `gorm:"<-:update"`
+
 	// CreatedAt is the timestamp of when the record was first created
 	CreatedAt time.Time
 
@@ -215,6 +392,29 @@ func NewJob(jobType jobTypeEnum, data datatypes.JSON) *Job {
 	}
 }
 
+// JobAttempt represents a row in the job_attempts table
+type JobAttempt struct {
+	// ID is the primary key for the table
+	ID int32 `gorm:"primaryKey"`
+
+	// JobID is the ID of the row in the jobs table for the job that failed
+	JobID int32 `gorm:"foreignKey:JobID;references:Job"`
+
+	// Attempt is the number of the failed attempt
+	Attempt int
+
+	// Replay is the number of times the job had been replayed from the
+	// dead-letter queue when the attempt failed. Attempt numbers restart at 1
+	// with each replay.
+	Replay int
+
+	// Error is the error that caused the attempt to fail
+	Error string
+
+	// CreatedAt is the timestamp of when the record was first created
+	CreatedAt time.Time
+}
+
 // BlockedMember represents a row in the blocked_members table
 type BlockedMember struct {
 	// ID is the primary key for the table
@@ -223,6 +423,9 @@ type BlockedMember struct {
 	// ChannelID is the ID of the Slack channel that the user is a member of
 	ChannelID string `gorm:"foreignKey:ChannelID;references:Channel"`
 
+	// TeamID is the ID of the Slack workspace of the user
+	TeamID string `gorm:"default:null"`
+
 	// UserID is the ID of the Slack user who is doing the blocking
 	UserID string `gorm:"foreignKey:UserID;references:User"`
 
@@ -232,3 +435,37 @@ type BlockedMember struct {
 	// CreatedAt is the timestamp of when the record was first created
 	CreatedAt time.Time
 }
+
+// Workspace represents a row in the workspaces table
+type Workspace struct {
+	// TeamID is the ID of the Slack workspace, or the ID of
+	// the Enterprise Grid org for an org-wide installation
+	TeamID string `gorm:"primaryKey"`
+
+	// TeamName is the name of the Slack workspace or Enterprise Grid org
+	TeamName string
+
+	// EnterpriseID is the ID of the Enterprise Grid org of the Slack workspace
+	EnterpriseID string `gorm:"default:null"`
+
+	// IsEnterpriseInstall is true if the app was installed org-wide in an Enterprise Grid org
+	IsEnterpriseInstall bool
+
+	// AppID is the ID of the Slack app that was installed
+	AppID string
+
+	// BotUserID is the ID of the chat-roulette bot user in the Slack workspace
+	BotUserID string
+
+	// BotToken is the OAuth2 bot token for the Slack workspace
+	BotToken sqlcrypter.EncryptedBytes
+
+	// Installer is the ID of the Slack user who installed the app in the Slack workspace
+	Installer string
+
+	// CreatedAt is the timestamp of when the record was first created
+	CreatedAt time.Time
+
+	// UpdatedAt is the timestamp of when the record was last updated
+	UpdatedAt time.Time
+}
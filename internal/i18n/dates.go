@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// Weekday returns the translated name of the day of the week.
+func Weekday(lang Language, d time.Weekday) string {
+	return T(lang, "weekday."+strings.ToLower(d.String()))
+}
+
+// Weekdays returns the translated name of the day of the week for a recurring event (eg, Mondays).
+func Weekdays(lang Language, d time.Weekday) string {
+	return T(lang, "weekdays."+strings.ToLower(d.String()))
+}
+
+// Month returns the translated name of the month.
+func Month(lang Language, m time.Month) string {
+	return T(lang, "month."+strings.ToLower(m.String()))
+}
+
+// ordinal returns the day of the month as it is written in a date.
+func ordinal(lang Language, day int) string {
+	switch lang {
+	case English:
+		return humanize.Ordinal(day)
+	case French:
+		if day == 1 {
+			return "1er"
+		}
+	case German:
+		return strconv.Itoa(day) + "."
+	}
+
+	return strconv.Itoa(day)
+}
+
+// FormatDate returns a translated date, such as January 4th, 2022
+func FormatDate(lang Language, t time.Time) string {
+	return T(lang, "date.short", Month(lang, t.Month()), ordinal(lang, t.Day()), t.Year())
+}
+
+// FormatLongDate returns a translated date with the day of the week, such as Monday, January 4th, 2021
+func FormatLongDate(lang Language, t time.Time) string {
+	return T(lang, "date.long", Weekday(lang, t.Weekday()), Month(lang, t.Month()), ordinal(lang, t.Day()), t.Year())
+}
+
+// FormatHour returns a translated hour of the day, such as 3 PM
+func FormatHour(lang Language, hour int) string {
+	return time.Date(0, 1, 1, hour, 0, 0, 0, time.UTC).Format(T(lang, "time.hour"))
+}
+
+// FormatClock returns a translated time of day on the hour, such as 3:00 PM
+func FormatClock(lang Language, hour int) string {
+	return time.Date(0, 1, 1, hour, 0, 0, 0, time.UTC).Format(T(lang, "time.clock"))
+}
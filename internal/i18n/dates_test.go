@@ -0,0 +1,33 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FormatDate(t *testing.T) {
+	testCases := []struct {
+		lang  Language
+		date  time.Time
+		short string
+		long  string
+		hour  string
+		clock string
+	}{
+		{English, time.Date(2022, time.January, 4, 15, 0, 0, 0, time.UTC), "January 4th, 2022", "Tuesday, January 4th, 2022", "3 PM", "3:00 PM"},
+		{French, time.Date(2022, time.May, 1, 9, 0, 0, 0, time.UTC), "1er mai 2022", "dimanche 1er mai 2022", "09:00", "09:00"},
+		{German, time.Date(2022, time.March, 18, 12, 0, 0, 0, time.UTC), "18. März 2022", "Freitag, 18. März 2022", "12:00 Uhr", "12:00 Uhr"},
+		{Spanish, time.Date(2022, time.August, 22, 20, 0, 0, 0, time.UTC), "22 de agosto de 2022", "lunes, 22 de agosto de 2022", "20:00", "20:00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.lang.String(), func(t *testing.T) {
+			assert.Equal(t, tc.short, FormatDate(tc.lang, tc.date))
+			assert.Equal(t, tc.long, FormatLongDate(tc.lang, tc.date))
+			assert.Equal(t, tc.hour, FormatHour(tc.lang, tc.date.Hour()))
+			assert.Equal(t, tc.clock, FormatClock(tc.lang, tc.date.Hour()))
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Package i18n translates the messages of the bot and the web UI.
+//
+// Messages are looked up by key in the catalogs embedded from locales/*.json,
+// one catalog per language. Messages are formatted with fmt verbs, so
+// translations can reorder arguments using explicit indexes (eg, %[2]s).
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Language is a language that the bot and the web UI are translated into.
+type Language string
+
+const (
+	English Language = "en"
+	French  Language = "fr"
+	German  Language = "de"
+	Spanish Language = "es"
+
+	// DefaultLanguage is used when no language has been chosen
+	DefaultLanguage = English
+)
+
+var (
+	//go:embed locales/*.json
+	localesFS embed.FS
+
+	// Languages are the supported languages, in the order they are listed in the UI
+	Languages = []Language{English, French, German, Spanish}
+
+	// catalogs maps each language to its messages
+	catalogs = loadCatalogs()
+
+	matcher = language.NewMatcher([]language.Tag{
+		language.English,
+		language.French,
+		language.German,
+		language.Spanish,
+	})
+)
+
+// loadCatalogs loads the message catalog of every supported language.
+func loadCatalogs() map[Language]map[string]string {
+	m := make(map[Language]map[string]string, len(Languages))
+
+	for _, lang := range Languages {
+		b, err := localesFS.ReadFile(path.Join("locales", string(lang)+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: missing catalog for %q: %s", lang, err))
+		}
+
+		var catalog map[string]string
+		if err := json.Unmarshal(b, &catalog); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog for %q: %s", lang, err))
+		}
+
+		m[lang] = catalog
+	}
+
+	return m
+}
+
+// String returns the language code.
+func (l Language) String() string {
+	return string(l)
+}
+
+// Name returns the name of the language in the language itself.
+func (l Language) Name() string {
+	return T(l, "language.name")
+}
+
+// IsSupported returns true if the bot and the web UI are translated into the language.
+func (l Language) IsSupported() bool {
+	_, ok := catalogs[l]
+	return ok
+}
+
+// Parse returns the supported language that best matches a locale, such as
+// the locale of a Slack user (eg, fr-FR). False is returned if none match.
+func Parse(locale string) (Language, bool) {
+	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+	if err != nil {
+		return "", false
+	}
+
+	_, i, confidence := matcher.Match(tag)
+	if confidence == language.No {
+		return "", false
+	}
+
+	return Languages[i], true
+}
+
+// MatchAcceptLanguage returns the supported language that best matches the
+// Accept-Language header of an HTTP request, or the default language.
+func MatchAcceptLanguage(header string) Language {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
+	}
+
+	_, i, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+
+	return Languages[i]
+}
+
+// Resolve returns the first supported language, or the default language if there is none.
+func Resolve(languages ...string) Language {
+	for _, l := range languages {
+		if lang := Language(l); lang.IsSupported() {
+			return lang
+		}
+	}
+
+	return DefaultLanguage
+}
+
+// T translates the message for the key into the language, falling back to English
+// if the message has not been translated. The key is returned if there is no message.
+func T(lang Language, key string, args ...interface{}) string {
+	message, ok := catalogs[lang][key]
+	if !ok {
+		message, ok = catalogs[DefaultLanguage][key]
+	}
+
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
+
+// TN translates the plural form of the message for the key that matches the count.
+//
+// The messages are looked up with the suffixes ".one" and ".other", and the count
+// is the first argument of the message.
+func TN(lang Language, key string, count interface{}, args ...interface{}) string {
+	suffix := ".other"
+	if isOne(lang, toFloat(count)) {
+		suffix = ".one"
+	}
+
+	return T(lang, key+suffix, append([]interface{}{count}, args...)...)
+}
+
+// isOne returns true if the count takes the singular form in the language.
+func isOne(lang Language, n float64) bool {
+	if lang == French {
+		return n >= 0 && n < 2
+	}
+
+	return n == 1
+}
+
+// toFloat converts a count to a float64 for choosing its plural form.
+func toFloat(count interface{}) float64 {
+	switch v := count.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	default:
+		return 0
+	}
+}
+
+// Translations returns the message for the key in every supported language.
+func Translations(key string) []string {
+	messages := make([]string, 0, len(Languages))
+	for _, lang := range Languages {
+		messages = append(messages, T(lang, key))
+	}
+
+	return messages
+}
+
+// Funcs returns the template functions for translating messages into the language.
+func Funcs(lang Language) map[string]interface{} {
+	return map[string]interface{}{
+		"T": func(key string, args ...interface{}) string {
+			return T(lang, key, args...)
+		},
+		"TN": func(key string, count interface{}, args ...interface{}) string {
+			return TN(lang, key, count, args...)
+		},
+		"lang": func() Language {
+			return lang
+		},
+		"languages": func() []Language {
+			return Languages
+		},
+	}
+}
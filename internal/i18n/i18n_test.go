@@ -0,0 +1,126 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Catalogs(t *testing.T) {
+	english := catalogs[English]
+
+	for _, lang := range Languages {
+		t.Run(lang.String(), func(t *testing.T) {
+			catalog := catalogs[lang]
+
+			assert.Len(t, catalog, len(english), "catalog must have the same keys as English")
+
+			for key, message := range catalog {
+				_, ok := english[key]
+				assert.True(t, ok, "unknown key %q", key)
+
+				// Messages are inserted as is into JSON strings in the bot templates
+				assert.False(t, strings.ContainsAny(message, "\"\\\n"), "message %q must not contain quotes, backslashes or newlines", key)
+			}
+		})
+	}
+}
+
+func Test_T(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lang     Language
+		key      string
+		args     []interface{}
+		expected string
+	}{
+		{"english", English, "common.cancel", nil, "Cancel"},
+		{"french", French, "common.cancel", nil, "Annuler"},
+		{"args", German, "greet_admin.hi", []interface{}{"U0123456789"}, "Hallo <@U0123456789> :wave:"},
+		{"reordered args", Spanish, "date.short", []interface{}{"enero", "4", 2022}, "4 de enero de 2022"},
+		{"no args", English, "report_stats.perfect", nil, "Congratulations to everyone for achieving *100%* :tada:"},
+		{"unsupported language", Language("it"), "common.cancel", nil, "Cancel"},
+		{"unknown key", French, "nope", nil, "nope"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, T(tc.lang, tc.key, tc.args...))
+		})
+	}
+}
+
+func Test_TN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lang     Language
+		count    interface{}
+		expected string
+	}{
+		{"english zero", English, 0, "*0* intros were made :tada:"},
+		{"english one", English, 1, "*1* intro was made :tada:"},
+		{"english many", English, 2, "*2* intros were made :tada:"},
+		{"french zero", French, 0, "*0* présentation a été faite :tada:"},
+		{"french one", French, 1.0, "*1* présentation a été faite :tada:"},
+		{"french many", French, int64(3), "*3* présentations ont été faites :tada:"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, TN(tc.lang, "report_matches.pairs", tc.count, ":tada:"))
+		})
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	testCases := []struct {
+		locale   string
+		expected Language
+		ok       bool
+	}{
+		{"en-US", English, true},
+		{"fr-FR", French, true},
+		{"fr-CA", French, true},
+		{"de_DE", German, true},
+		{"es-419", Spanish, true},
+		{"ja-JP", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.locale, func(t *testing.T) {
+			actual, ok := Parse(tc.locale)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_MatchAcceptLanguage(t *testing.T) {
+	testCases := []struct {
+		header   string
+		expected Language
+	}{
+		{"fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5", French},
+		{"ja, de;q=0.5", German},
+		{"ja", English},
+		{"", English},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.header, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MatchAcceptLanguage(tc.header))
+		})
+	}
+}
+
+func Test_Translations(t *testing.T) {
+	expected := []string{"Cancel", "Annuler", "Abbrechen", "Cancelar"}
+	assert.Equal(t, expected, Translations("common.cancel"))
+}
+
+func Test_Resolve(t *testing.T) {
+	assert.Equal(t, German, Resolve("", "it", "de", "fr"))
+	assert.Equal(t, DefaultLanguage, Resolve("", "it"))
+}
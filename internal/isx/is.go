@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/timex"
 	"github.com/chat-roulettte/chat-roulette/internal/tzx"
 )
@@ -68,6 +69,18 @@ func ConnectionMode(value interface{}) error {
 	return nil
 }
 
+// Language validates that the given value is the code of a language
+// that chat-roulette is translated into. An empty value is allowed.
+func Language(value interface{}) error {
+	s, _ := value.(string)
+
+	if s != "" && !i18n.Language(s).IsSupported() {
+		return fmt.Errorf("unsupported language")
+	}
+
+	return nil
+}
+
 // NextRoundDate validates that the given value
 // is a valid date for the next chat-roulette round.
 func NextRoundDate(value interface{}) error {
@@ -84,6 +97,24 @@ func NextRoundDate(value interface{}) error {
 	return nil
 }
 
+// AdHocRoundEndDate validates that the given value
+// is a valid end date for an ad-hoc chat-roulette round.
+func AdHocRoundEndDate(value interface{}) error {
+	t, _ := value.(time.Time)
+
+	// Ad-hoc rounds must run long enough for pairs to be checked in on
+	diff := time.Until(t)
+	if diff < 48*time.Hour {
+		return fmt.Errorf("invalid round end date: must be at least 2 days from now")
+	}
+
+	if diff > 28*24*time.Hour {
+		return fmt.Errorf("invalid round end date: cannot be more than 4 weeks from now")
+	}
+
+	return nil
+}
+
 // Country validates that the given value
 // is a valid name of a country.
 func Country(value interface{}) error {
@@ -182,6 +213,23 @@ func SlackBotAuthToken(value interface{}) error {
 	return nil
 }
 
+// SlackAppToken validates that the given value
+// is a valid Slack app-level token.
+func SlackAppToken(value interface{}) error {
+	s, _ := value.(string)
+
+	regex := regexp.MustCompile(`^xapp-\d+-[A-Z0-9]+-\d+-[a-zA-Z0-9]+$`)
+
+	if err := validation.Validate(s,
+		validation.Required,
+		validation.Match(regex).Error("app-level token is malformed"),
+	); err != nil {
+		return errors.Wrap(err, "invalid app-level token")
+	}
+
+	return nil
+}
+
 // PostgresConnectionURL validates that the given value
 // is a valid PostgreSQL connection URL.
 func PostgresConnectionURL(value interface{}) error {
@@ -1,6 +1,8 @@
 package attributes
 
 const (
+	SlackEnterpriseID   = "slack_enterprise_id"
+	SlackTeamID         = "slack_team_id"
 	SlackChannelID      = "slack_channel_id"
 	SlackUserID         = "slack_user_id"
 	SlackAPIEvent       = "slack_api_event"
@@ -9,13 +11,18 @@ const (
 	SlackViewCallbackID = "slack_view_callback_id"
 	SlackBlockID        = "slack_block_id"
 	SlackActionID       = "slack_action_id"
+	SlackAPIMethod      = "slack_api_method"
+	SlackCommand        = "slack_command"
 
 	JobType     = "job"
 	JobID       = "job_id"
 	JobPriority = "job_priority"
 	JobStatus   = "job_status"
+	JobAttempts = "job_attempts"
 
-	WorkerID = "worker_id"
+	WorkerID        = "worker_id"
+	WorkerBusySlots = "worker_busy_slots"
+	WorkerIdleSlots = "worker_idle_slots"
 
 	MatchID = "match_id"
 	RoundID = "round_id"
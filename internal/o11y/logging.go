@@ -10,9 +10,10 @@ import (
 	"github.com/chat-roulettte/chat-roulette/internal/version"
 )
 
-// CreateLogger returns an annotated logger
+// CreateLogger returns an annotated logger. Sinks can be registered
+// on the logger to send log entries to other destinations.
 func CreateLogger(level string, jsonFormat bool) (context.Context, hclog.Logger) {
-	logger := hclog.New(&hclog.LoggerOptions{
+	var logger hclog.Logger = hclog.NewInterceptLogger(&hclog.LoggerOptions{
 		Level:           hclog.LevelFromString(level),
 		Output:          os.Stdout,
 		IncludeLocation: true,
@@ -0,0 +1,186 @@
+package o11y
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/chat-roulettte/chat-roulette/internal/config"
+)
+
+const (
+	// LogKeyTraceID is the key of the trace ID in log entries
+	LogKeyTraceID = "trace_id"
+
+	// LogKeySpanID is the key of the span ID in log entries
+	LogKeySpanID = "span_id"
+)
+
+// LoggerWithTrace annotates the logger with the trace and span IDs of the
+// span in the context, so that log entries can be correlated with traces.
+func LoggerWithTrace(ctx context.Context, logger hclog.Logger) hclog.Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return logger
+	}
+
+	return logger.With(
+		LogKeyTraceID, sc.TraceID().String(),
+		LogKeySpanID, sc.SpanID().String(),
+	)
+}
+
+// NewLoggerProvider creates an OpenTelemetry LoggerProvider that exports logs with OTLP.
+func NewLoggerProvider(cfg *config.TelemetryConfig) (*sdklog.LoggerProvider, error) {
+	res, err := newResource()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create logging resource")
+	}
+
+	exp, err := newOTLPLogExporter(context.Background(), cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OTLP log exporter")
+	}
+
+	lp := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exp)),
+		sdklog.WithResource(res),
+	)
+
+	return lp, nil
+}
+
+// RegisterLogSink sends the log entries of the logger, and all of its
+// sub-loggers, to the LoggerProvider. The logger must be created by CreateLogger.
+func RegisterLogSink(logger hclog.Logger, lp otellog.LoggerProvider) error {
+	interceptLogger, ok := logger.(hclog.InterceptLogger)
+	if !ok {
+		return errors.New("logger does not support sinks")
+	}
+
+	interceptLogger.RegisterSink(&logSink{
+		level:  logger.GetLevel(),
+		logger: lp.Logger(ServiceName),
+	})
+
+	return nil
+}
+
+func ShutdownLogger(ctx context.Context, logger hclog.Logger, lp *sdklog.LoggerProvider) {
+	if err := lp.ForceFlush(ctx); err != nil {
+		logger.Warn("failed to flush logger")
+	}
+
+	if err := lp.Shutdown(ctx); err != nil {
+		logger.Warn("failed to shutdown logger")
+	}
+}
+
+// logSink is a hclog.SinkAdapter that emits log entries as OpenTelemetry log records
+type logSink struct {
+	level  hclog.Level
+	logger otellog.Logger
+}
+
+// Accept emits a log entry as an OpenTelemetry log record. The log record is
+// correlated with the trace and span IDs that the logger was annotated with.
+func (s *logSink) Accept(name string, level hclog.Level, msg string, args ...interface{}) {
+	if level < s.level {
+		return
+	}
+
+	var record otellog.Record
+	record.SetTimestamp(time.Now())
+	record.SetSeverity(logSeverity(level))
+	record.SetSeverityText(level.String())
+	record.SetBody(otellog.StringValue(msg))
+
+	if name != "" {
+		record.AddAttributes(otellog.String("logger.name", name))
+	}
+
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+
+	for i := 0; i < len(args); i += 2 {
+		key := fmt.Sprint(args[i])
+
+		if i+1 >= len(args) {
+			record.AddAttributes(otellog.String(hclog.MissingKey, key))
+			break
+		}
+
+		value := args[i+1]
+
+		switch key {
+		case LogKeyTraceID:
+			traceID, _ = trace.TraceIDFromHex(fmt.Sprint(value))
+			continue
+		case LogKeySpanID:
+			spanID, _ = trace.SpanIDFromHex(fmt.Sprint(value))
+			continue
+		}
+
+		record.AddAttributes(otellog.KeyValue{Key: key, Value: logValue(value)})
+	}
+
+	// The SDK reads the trace and span IDs of the log record from the context
+	ctx := context.Background()
+	if traceID.IsValid() && spanID.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		}))
+	}
+
+	s.logger.Emit(ctx, record)
+}
+
+// logSeverity converts a hclog.Level to the OpenTelemetry log severity
+func logSeverity(level hclog.Level) otellog.Severity {
+	switch level {
+	case hclog.Trace:
+		return otellog.SeverityTrace
+	case hclog.Debug:
+		return otellog.SeverityDebug
+	case hclog.Info:
+		return otellog.SeverityInfo
+	case hclog.Warn:
+		return otellog.SeverityWarn
+	case hclog.Error:
+		return otellog.SeverityError
+	default:
+		return otellog.SeverityUndefined
+	}
+}
+
+// logValue converts the value of a log entry argument to an OpenTelemetry log value
+func logValue(v interface{}) otellog.Value {
+	switch v := v.(type) {
+	case string:
+		return otellog.StringValue(v)
+	case bool:
+		return otellog.BoolValue(v)
+	case int:
+		return otellog.IntValue(v)
+	case int32:
+		return otellog.Int64Value(int64(v))
+	case int64:
+		return otellog.Int64Value(v)
+	case float64:
+		return otellog.Float64Value(v)
+	case error:
+		return otellog.StringValue(v.Error())
+	case fmt.Stringer:
+		return otellog.StringValue(v.String())
+	default:
+		return otellog.StringValue(fmt.Sprintf("%v", v))
+	}
+}
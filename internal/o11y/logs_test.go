@@ -0,0 +1,99 @@
+package o11y
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// memoryLogExporter is a sdklog.Exporter that stores log records in memory
+type memoryLogExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+
+	return nil
+}
+
+func (e *memoryLogExporter) Shutdown(ctx context.Context) error { return nil }
+
+func (e *memoryLogExporter) ForceFlush(ctx context.Context) error { return nil }
+
+func Test_LoggerWithTrace(t *testing.T) {
+	logger, buffer := NewBufferedLogger()
+
+	t.Run("no span", func(t *testing.T) {
+		LoggerWithTrace(context.Background(), logger).Info("no span")
+		assert.NotContains(t, buffer.String(), LogKeyTraceID)
+	})
+
+	t.Run("span", func(t *testing.T) {
+		provider := sdktrace.NewTracerProvider()
+		ctx, span := provider.Tracer("").Start(context.Background(), "test")
+		defer span.End()
+
+		LoggerWithTrace(ctx, logger).Info("span")
+		assert.Contains(t, buffer.String(), "trace_id="+span.SpanContext().TraceID().String())
+		assert.Contains(t, buffer.String(), "span_id="+span.SpanContext().SpanID().String())
+	})
+}
+
+func Test_RegisterLogSink(t *testing.T) {
+	r := require.New(t)
+
+	exporter := &memoryLogExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+
+	t.Run("unsupported logger", func(t *testing.T) {
+		logger, _ := NewBufferedLogger()
+		r.Error(RegisterLogSink(logger, lp))
+	})
+
+	logger := hclog.NewInterceptLogger(&hclog.LoggerOptions{
+		Level:  hclog.Info,
+		Output: io.Discard,
+	})
+	r.NoError(RegisterLogSink(logger, lp))
+
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("").Start(context.Background(), "test")
+	defer span.End()
+
+	logger.Debug("filtered by level")
+	LoggerWithTrace(ctx, logger).With("attempts", 3).Error("failed to execute job", "error", errors.New("boom"))
+
+	r.Len(exporter.records, 1)
+
+	record := exporter.records[0]
+	assert.Equal(t, "failed to execute job", record.Body().AsString())
+	assert.Equal(t, otellog.SeverityError, record.Severity())
+	assert.Equal(t, span.SpanContext().TraceID(), record.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), record.SpanID())
+
+	attrs := map[string]otellog.Value{}
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+
+	assert.Equal(t, int64(3), attrs["attempts"].AsInt64())
+	assert.Equal(t, "boom", attrs["error"].AsString())
+	assert.NotContains(t, attrs, LogKeyTraceID)
+}
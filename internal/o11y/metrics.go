@@ -0,0 +1,88 @@
+package o11y
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/chat-roulettte/chat-roulette/internal/config"
+)
+
+// MetricsNamespace is the prefix for the names of all metrics
+const MetricsNamespace = "chat_roulette"
+
+// NewMeterProvider creates an OpenTelemetry MeterProvider that exports metrics in
+// the Prometheus format, with OTLP, or both. If Prometheus metrics are enabled, the
+// returned handler serves the metrics for scraping, otherwise it is nil.
+func NewMeterProvider(c *config.Config) (*sdkmetric.MeterProvider, http.Handler, error) {
+	res, err := newResource()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create metrics resource")
+	}
+
+	opts := []sdkmetric.Option{
+		sdkmetric.WithResource(res),
+	}
+
+	var handler http.Handler
+
+	if c.Metrics.Enabled {
+		// Use a dedicated registry instead of the global one, so that
+		// only the metrics of this application and the Go runtime are served
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		)
+
+		exp, err := otelprometheus.New(
+			otelprometheus.WithRegisterer(registry),
+			otelprometheus.WithNamespace(MetricsNamespace),
+		)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to create Prometheus exporter")
+		}
+
+		opts = append(opts, sdkmetric.WithReader(exp))
+
+		handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+
+	if c.Telemetry.MetricsEnabled() {
+		exp, err := newOTLPMetricExporter(context.Background(), &c.Telemetry)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to create OTLP metric exporter")
+		}
+
+		reader := sdkmetric.NewPeriodicReader(exp,
+			sdkmetric.WithInterval(c.Telemetry.Metrics.Interval),
+		)
+
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
+
+	mp := sdkmetric.NewMeterProvider(opts...)
+
+	// Register MeterProvider globally
+	otel.SetMeterProvider(mp)
+
+	return mp, handler, nil
+}
+
+func ShutdownMeter(ctx context.Context, logger hclog.Logger, mp *sdkmetric.MeterProvider) {
+	if err := mp.ForceFlush(ctx); err != nil {
+		logger.Warn("failed to flush meter")
+	}
+
+	if err := mp.Shutdown(ctx); err != nil {
+		logger.Warn("failed to shutdown meter")
+	}
+}
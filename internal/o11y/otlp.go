@@ -0,0 +1,172 @@
+package o11y
+
+import (
+	"context"
+	"net/url"
+	"path"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/chat-roulettte/chat-roulette/internal/config"
+)
+
+// otlpEndpoint is the parsed URL of an OTLP receiver
+type otlpEndpoint struct {
+	// host is the host and port of the receiver
+	host string
+
+	// basePath is prefixed to the path of each signal when using OTLP over HTTP
+	basePath string
+
+	// insecure disables TLS
+	insecure bool
+}
+
+// parseOTLPEndpoint parses the URL of an OTLP receiver
+func parseOTLPEndpoint(endpoint string) (*otlpEndpoint, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse OTLP endpoint")
+	}
+
+	if u.Host == "" {
+		return nil, errors.New("OTLP endpoint must include a host")
+	}
+
+	return &otlpEndpoint{
+		host:     u.Host,
+		basePath: u.Path,
+		insecure: u.Scheme == "http",
+	}, nil
+}
+
+// signalPath returns the URL path of a signal (ie, traces, metrics, logs) for OTLP over HTTP
+func (e *otlpEndpoint) signalPath(signal string) string {
+	return path.Join("/", e.basePath, "v1", signal)
+}
+
+// newOTLPTraceExporter creates an exporter for sending traces with OTLP
+func newOTLPTraceExporter(ctx context.Context, cfg *config.TelemetryConfig) (sdktrace.SpanExporter, error) {
+	endpoint, err := parseOTLPEndpoint(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Protocol == config.OTLPProtocolHTTP {
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(endpoint.host),
+			otlptracehttp.WithURLPath(endpoint.signalPath("traces")),
+		}
+
+		if endpoint.insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+		}
+
+		return otlptracehttp.New(ctx, opts...)
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(endpoint.host),
+	}
+
+	if endpoint.insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+// newOTLPMetricExporter creates an exporter for sending metrics with OTLP
+func newOTLPMetricExporter(ctx context.Context, cfg *config.TelemetryConfig) (sdkmetric.Exporter, error) {
+	endpoint, err := parseOTLPEndpoint(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Protocol == config.OTLPProtocolHTTP {
+		opts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(endpoint.host),
+			otlpmetrichttp.WithURLPath(endpoint.signalPath("metrics")),
+		}
+
+		if endpoint.insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlpmetrichttp.WithHeaders(cfg.Headers))
+		}
+
+		return otlpmetrichttp.New(ctx, opts...)
+	}
+
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(endpoint.host),
+	}
+
+	if endpoint.insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(cfg.Headers))
+	}
+
+	return otlpmetricgrpc.New(ctx, opts...)
+}
+
+// newOTLPLogExporter creates an exporter for sending logs with OTLP
+func newOTLPLogExporter(ctx context.Context, cfg *config.TelemetryConfig) (sdklog.Exporter, error) {
+	endpoint, err := parseOTLPEndpoint(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Protocol == config.OTLPProtocolHTTP {
+		opts := []otlploghttp.Option{
+			otlploghttp.WithEndpoint(endpoint.host),
+			otlploghttp.WithURLPath(endpoint.signalPath("logs")),
+		}
+
+		if endpoint.insecure {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlploghttp.WithHeaders(cfg.Headers))
+		}
+
+		return otlploghttp.New(ctx, opts...)
+	}
+
+	opts := []otlploggrpc.Option{
+		otlploggrpc.WithEndpoint(endpoint.host),
+	}
+
+	if endpoint.insecure {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlploggrpc.WithHeaders(cfg.Headers))
+	}
+
+	return otlploggrpc.New(ctx, opts...)
+}
@@ -0,0 +1,33 @@
+package o11y
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseOTLPEndpoint(t *testing.T) {
+	t.Run("http", func(t *testing.T) {
+		endpoint, err := parseOTLPEndpoint("http://localhost:4318")
+		require.NoError(t, err)
+
+		assert.Equal(t, "localhost:4318", endpoint.host)
+		assert.True(t, endpoint.insecure)
+		assert.Equal(t, "/v1/traces", endpoint.signalPath("traces"))
+	})
+
+	t.Run("https with path", func(t *testing.T) {
+		endpoint, err := parseOTLPEndpoint("https://otlp.example.com/otlp/")
+		require.NoError(t, err)
+
+		assert.Equal(t, "otlp.example.com", endpoint.host)
+		assert.False(t, endpoint.insecure)
+		assert.Equal(t, "/otlp/v1/logs", endpoint.signalPath("logs"))
+	})
+
+	t.Run("missing host", func(t *testing.T) {
+		_, err := parseOTLPEndpoint("localhost")
+		assert.Error(t, err)
+	})
+}
@@ -34,19 +34,7 @@ const (
 func NewTracerProvider(cfg *config.TracingConfig) (*sdktrace.TracerProvider, error) {
 	var tp *sdktrace.TracerProvider
 
-	res, err := resource.New(
-		context.Background(),
-		resource.WithAttributes(
-			semconv.ServiceNameKey.String(ServiceName),
-			semconv.ProcessRuntimeNameKey.String(getRuntimeName()),
-			semconv.ProcessRuntimeVersionKey.String(runtime.Version()),
-			attribute.String("service.commit_sha", version.TruncatedCommitSha()),
-			attribute.String("service.build_date", version.BuildDate),
-		),
-		resource.WithHost(),
-		resource.WithOSType(),
-	)
-
+	res, err := newResource()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create tracing resource")
 	}
@@ -93,7 +81,40 @@ func NewTracerProvider(cfg *config.TracingConfig) (*sdktrace.TracerProvider, err
 		return nil, fmt.Errorf("unsupported tracing exporter")
 	}
 
-	// Register TracerProvider globally
+	registerTracerProvider(tp)
+
+	return tp, nil
+}
+
+// NewOTLPTracerProvider creates an OpenTelemetry TracerProvider that exports traces with OTLP.
+func NewOTLPTracerProvider(cfg *config.TelemetryConfig) (*sdktrace.TracerProvider, error) {
+	res, err := newResource()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create tracing resource")
+	}
+
+	exp, err := newOTLPTraceExporter(context.Background(), cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OTLP trace exporter")
+	}
+
+	// Sample a ratio of new traces, but always follow the
+	// sampling decision of the parent span if there is one
+	sampler := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Traces.SamplingRatio))
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sampler),
+	)
+
+	registerTracerProvider(tp)
+
+	return tp, nil
+}
+
+// registerTracerProvider registers the TracerProvider and propagators globally
+func registerTracerProvider(tp *sdktrace.TracerProvider) {
 	otel.SetTracerProvider(tp)
 
 	// Use the W3C trace context and baggage propagators for compatibility with most vendors
@@ -104,8 +125,6 @@ func NewTracerProvider(cfg *config.TracingConfig) (*sdktrace.TracerProvider, err
 			propagation.Baggage{},
 		),
 	)
-
-	return tp, nil
 }
 
 func ShutdownTracer(ctx context.Context, logger hclog.Logger, tp *sdktrace.TracerProvider) {
@@ -118,6 +137,22 @@ func ShutdownTracer(ctx context.Context, logger hclog.Logger, tp *sdktrace.Trace
 	}
 }
 
+// newResource returns the OpenTelemetry resource describing this service
+func newResource() (*resource.Resource, error) {
+	return resource.New(
+		context.Background(),
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(ServiceName),
+			semconv.ProcessRuntimeNameKey.String(getRuntimeName()),
+			semconv.ProcessRuntimeVersionKey.String(runtime.Version()),
+			attribute.String("service.commit_sha", version.TruncatedCommitSha()),
+			attribute.String("service.build_date", version.BuildDate),
+		),
+		resource.WithHost(),
+		resource.WithOSType(),
+	)
+}
+
 // getRuntimeName returns the name of the runtime
 // See: https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/resource/semantic_conventions/process.md#go-runtimes
 func getRuntimeName() string {
@@ -1,24 +1,54 @@
 package health
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
 	"github.com/chat-roulettte/chat-roulette/internal/server"
 	"github.com/chat-roulettte/chat-roulette/internal/server/api"
 )
 
+// Check verifies that a component of the application is ready
+type Check func(ctx context.Context) error
+
 type implServer struct {
 	*server.Server
 }
 
 const healthRoutesPrefix = "/-/"
 
-// RegisterRoutes registers health routes on the given server
-func RegisterRoutes(s *server.Server) {
+// RegisterRoutes registers health routes on the given server. The readiness endpoint
+// also runs the additional checks, such as for a worker running in the same process.
+func RegisterRoutes(s *server.Server, checks ...Check) {
 	i := implServer{s}
 
+	readinessHandler := i.readinessHandler
+	if len(checks) > 0 {
+		readinessHandler = checksHandler(append(i.readinessChecks(), checks...))
+	}
+
 	routes := []api.Route{
 		{Path: "healthy", Methods: []string{"GET"}, Func: i.healthHandler},
-		{Path: "ready", Methods: []string{"GET"}, Func: i.readinessHandler},
+		{Path: "ready", Methods: []string{"GET"}, Func: readinessHandler},
 	}
 
 	api.RegisterRoutes(s.GetMux(), healthRoutesPrefix, routes)
 }
+
+// NewHandler returns a handler serving only the health routes, for
+// processes that do not run the HTTP server, such as a worker-only process.
+func NewHandler(checks ...Check) http.Handler {
+	i := implServer{}
+
+	routes := []api.Route{
+		{Path: "healthy", Methods: []string{"GET"}, Func: i.healthHandler},
+		{Path: "ready", Methods: []string{"GET"}, Func: checksHandler(checks)},
+	}
+
+	r := mux.NewRouter()
+	api.RegisterRoutes(r, healthRoutesPrefix, routes)
+
+	return r
+}
@@ -9,59 +9,68 @@ import (
 	"github.com/chat-roulettte/chat-roulette/internal/database"
 )
 
-type check struct {
-	completed bool
-	err       error
-}
-
 // readinessHandler reports if the Server is ready to start receiving requests
 //
 // HTTP Method: GET
 //
 // HTTP Path: /ready
 func (s *implServer) readinessHandler(w http.ResponseWriter, r *http.Request) {
-	dbCheck := &check{}
-	slackCheck := &check{}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 1000*time.Millisecond)
-	defer cancel()
+	checksHandler(s.readinessChecks())(w, r)
+}
 
-	// Check the connection to the database
-	go func() {
-		dbCheck.err = database.Ping(ctx, s.GetDB())
-		dbCheck.completed = true
-	}()
+// readinessChecks returns the checks for the dependencies of the Server
+func (s *implServer) readinessChecks() []Check {
+	return []Check{
+		// Check the connection to the database
+		func(ctx context.Context) error {
+			return database.Ping(ctx, s.GetDB())
+		},
+		// Check the connection to Slack
+		func(ctx context.Context) error {
+			// There is no bot token in the config if the app is
+			// only installed in Slack workspaces with OAuth
+			if s.IsDistributed() && s.GetSlackBotUserID() == "" {
+				return nil
+			}
 
-	// Check the connection to Slack
-	go func() {
-		_, slackCheck.err = bot.GetBotUserID(ctx, s.GetSlackClient())
-		slackCheck.completed = true
-	}()
+			_, err := bot.GetBotUserID(ctx, s.GetSlackClient())
+			return err
+		},
+	}
+}
 
-	// Use a ticker to control the frequency of the loop
-	ticker := time.NewTicker(time.Millisecond)
+// checksHandler returns a handler that reports ready only if all of the checks
+// pass within 1 second. The checks are run concurrently.
+func checksHandler(checks []Check) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 1000*time.Millisecond)
+		defer cancel()
 
-	for {
-		select {
-		case <-ctx.Done():
-			w.WriteHeader(http.StatusServiceUnavailable)
-			w.Write([]byte("not ready"))
-			return
+		errCh := make(chan error, len(checks))
 
-		case <-ticker.C:
-			if !dbCheck.completed || !slackCheck.completed {
-				break
-			}
+		for _, check := range checks {
+			go func(check Check) {
+				errCh <- check(ctx)
+			}(check)
+		}
 
-			if dbCheck.err != nil || slackCheck.err != nil {
+		for range checks {
+			select {
+			case <-ctx.Done():
 				w.WriteHeader(http.StatusServiceUnavailable)
 				w.Write([]byte("not ready"))
 				return
-			}
 
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("ready"))
-			return
+			case err := <-errCh:
+				if err != nil {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					w.Write([]byte("not ready"))
+					return
+				}
+			}
 		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ready"))
 	}
 }
@@ -1,6 +1,7 @@
 package health
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -137,3 +138,37 @@ func (s *ReadinessTestSuite) Test_readinessHandler_slack_err() {
 func TestReadiness_suite(t *testing.T) {
 	suite.Run(t, new(ReadinessTestSuite))
 }
+
+func Test_NewHandler(t *testing.T) {
+	tt := []struct {
+		name   string
+		path   string
+		checks []Check
+		code   int
+		body   string
+	}{
+		{"healthy", "/-/healthy", nil, http.StatusOK, "ok"},
+		{"ready", "/-/ready", []Check{func(context.Context) error { return nil }}, http.StatusOK, "ready"},
+		{"not ready", "/-/ready", []Check{
+			func(context.Context) error { return nil },
+			func(context.Context) error { return fmt.Errorf("worker is shutting down") },
+		}, http.StatusServiceUnavailable, "not ready"},
+		{"timed out", "/-/ready", []Check{
+			func(ctx context.Context) error { <-ctx.Done(); return nil },
+		}, http.StatusServiceUnavailable, "not ready"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			request, _ := http.NewRequest(http.MethodGet, tc.path, nil)
+			response := httptest.NewRecorder()
+
+			NewHandler(tc.checks...).ServeHTTP(response, request)
+
+			r.Equal(tc.code, response.Code)
+			r.Equal(tc.body, response.Body.String())
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/chat-roulettte/chat-roulette/internal/server"
+)
+
+// Path is the path of the endpoint that Prometheus scrapes metrics from
+const Path = "/metrics"
+
+// RegisterRoutes registers the metrics route on the given server
+func RegisterRoutes(s *server.Server, handler http.Handler) {
+	Register(s.GetMux(), handler)
+}
+
+// Register registers the metrics route on the given router
+func Register(r *mux.Router, handler http.Handler) {
+	r.Handle(Path, handler).Methods("GET")
+}
@@ -0,0 +1,113 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/slack-go/slack"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+)
+
+// callbackHandler completes the OAuth "Add to Slack" flow by exchanging the
+// authorization code for a bot token and storing it for the Slack workspace.
+//
+// HTTP Method: GET
+//
+// HTTP Path: /callback
+func (s *implServer) callbackHandler(w http.ResponseWriter, r *http.Request) {
+	logger := hclog.FromContext(r.Context())
+	span := trace.SpanFromContext(r.Context())
+
+	q := r.URL.Query()
+
+	// The user canceled the install
+	if errCode := q.Get("error"); errCode != "" {
+		logger.Warn("Slack app was not installed", "error", errCode)
+		http.Redirect(w, r, "/", http.StatusFound)
+		return
+	}
+
+	state, err := r.Cookie(stateCookie)
+	if err != nil {
+		span.RecordError(err)
+		logger.Warn("failed to retrieve state from cookie", "error", err)
+		http.Error(w, "state cookie not found", http.StatusBadRequest)
+		return
+	}
+
+	if q.Get("state") != state.Value {
+		logger.Error("state in query parameter does not match state from cookie")
+		http.Error(w, "state mismatch detected. Please retry installing the app", http.StatusBadRequest)
+		return
+	}
+
+	code := q.Get("code")
+	if code == "" {
+		logger.Error("code is missing in callback query parameters")
+		http.Error(w, "invalid callback query parameters", http.StatusBadRequest)
+		return
+	}
+
+	// Exchange the authorization code for a bot token
+	slackCtx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	resp, err := slack.GetOAuthV2ResponseContext(slackCtx, s.GetHTTPClient(), s.GetSlackClientID(), s.GetSlackClientSecret(), code, s.redirectURI())
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to exchange OAuth2 code for bot token", "error", err)
+		http.Error(w, "Failed to install the app. Please retry installing the app", http.StatusInternalServerError)
+		return
+	}
+
+	logger = logger.With(
+		attributes.SlackTeamID, resp.Team.ID,
+		attributes.SlackEnterpriseID, resp.Enterprise.ID,
+	)
+	span.SetAttributes(
+		attribute.String(attributes.SlackTeamID, resp.Team.ID),
+		attribute.String(attributes.SlackEnterpriseID, resp.Enterprise.ID),
+		attribute.Bool("slack_enterprise_install", resp.IsEnterpriseInstall),
+	)
+
+	ctx := hclog.WithContext(r.Context(), logger)
+
+	workspace, err := bot.SaveWorkspace(ctx, s.GetDB(), resp)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Failed to install the app. Please retry installing the app", http.StatusInternalServerError)
+		return
+	}
+
+	// Drop the cached client in case the app was reinstalled with a new bot token
+	if clients := s.GetSlackClients(); clients != nil {
+		clients.Forget(workspace.TeamID)
+	}
+
+	// Onboard the Slack channels that the bot was added to during the install,
+	// which spans every workspace in the org for an org-wide installation
+	p := &bot.SyncChannelsParams{
+		BotUserID: workspace.BotUserID,
+	}
+
+	if err := bot.QueueSyncChannelsJob(bot.ContextWithWorkspace(ctx, workspace), s.GetDB(), p); err != nil {
+		span.RecordError(err)
+		logger.Error("failed to queue SYNC_CHANNELS job", "error", err)
+	}
+
+	logger.Info("installed Slack app in workspace", "slack_team_name", workspace.TeamName)
+
+	// Open the app in the Slack workspace
+	v := url.Values{}
+	v.Set("app", workspace.AppID)
+	v.Set("team", workspace.TeamID)
+
+	http.Redirect(w, r, SlackAppRedirectURL+"?"+v.Encode(), http.StatusFound)
+}
@@ -0,0 +1,44 @@
+package oauth
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// installHandler starts the OAuth "Add to Slack" flow to install the app in a Slack workspace
+// See: https://api.slack.com/authentication/oauth-v2
+//
+// HTTP Method: GET
+//
+// HTTP Path: /install
+func (s *implServer) installHandler(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	state, err := generateRandomURLSafeString()
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookie,
+		Value:    state,
+		Path:     RoutesPrefix,
+		MaxAge:   600, // 10 minutes is sufficient time to approve the install
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	q := url.Values{}
+	q.Set("client_id", s.GetSlackClientID())
+	q.Set("scope", strings.Join(botScopes, ","))
+	q.Set("redirect_uri", s.redirectURI())
+	q.Set("state", state)
+
+	http.Redirect(w, r, SlackAuthorizeURL+"?"+q.Encode(), http.StatusFound)
+}
@@ -0,0 +1,75 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"github.com/chat-roulettte/chat-roulette/internal/server"
+	"github.com/chat-roulettte/chat-roulette/internal/server/api"
+)
+
+type implServer struct {
+	*server.Server
+}
+
+const (
+	RoutesPrefix = "/oauth/"
+
+	// SlackAuthorizeURL is the URL of Slack's OAuth v2 authorize page
+	SlackAuthorizeURL = "https://slack.com/oauth/v2/authorize"
+
+	// SlackAppRedirectURL is the URL that opens the app in a Slack workspace
+	SlackAppRedirectURL = "https://slack.com/app_redirect"
+
+	// stateCookie is the name of the cookie containing the state of the install flow
+	stateCookie = "oauth_state"
+)
+
+// botScopes are the scopes requested for the bot token when the app is installed in a
+// Slack workspace. These must match the bot scopes in the app manifest.
+var botScopes = []string{
+	"channels:join",
+	"channels:history",
+	"channels:read",
+	"chat:write",
+	"commands",
+	"users:read",
+	"im:history",
+	"im:read",
+	"im:write",
+	"mpim:history",
+	"mpim:read",
+	"mpim:write",
+	"groups:history",
+	"groups:read",
+	"team:read",
+	"usergroups:read",
+}
+
+// RegisterRoutes registers the routes of the OAuth install flow on the given server
+func RegisterRoutes(s *server.Server) {
+	i := implServer{s}
+
+	routes := []api.Route{
+		{Path: "install", Methods: []string{"GET"}, Func: i.installHandler},
+		{Path: "callback", Methods: []string{"GET"}, Func: i.callbackHandler},
+	}
+
+	api.RegisterRoutes(s.GetMux(), RoutesPrefix, routes)
+}
+
+// redirectURI returns the URL that Slack redirects to after the app is installed
+func (s *implServer) redirectURI() string {
+	return strings.TrimSuffix(s.GetBaseURL(), "/") + RoutesPrefix + "callback"
+}
+
+// generateRandomURLSafeString generates a random 16-byte url safe string.
+func generateRandomURLSafeString() (string, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -89,6 +89,21 @@ func (s *implServer) callbackHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Identify the Slack workspace that the user signed in to,
+	// and the locale used to pick the language of the web UI
+	var claims struct {
+		TeamID       string `json:"https://slack.com/team_id"`
+		EnterpriseID string `json:"https://slack.com/enterprise_id"`
+		Locale       string `json:"locale"`
+	}
+
+	if err := idToken.Claims(&claims); err != nil {
+		span.RecordError(err)
+		logger.Error("failed to parse claims in id_token", "error", err)
+		http.Error(w, "Failed to parse claims in ID Token. Please retry the OAuth2 login flow", http.StatusInternalServerError)
+		return
+	}
+
 	session, err := s.GetSession(r)
 	if err != nil {
 		span.RecordError(err)
@@ -98,6 +113,9 @@ func (s *implServer) callbackHandler(w http.ResponseWriter, r *http.Request) {
 
 	session.Values["authenticated"] = true
 	session.Values["slack_user_id"] = idToken.Subject
+	session.Values["slack_team_id"] = claims.TeamID
+	session.Values["slack_enterprise_id"] = claims.EnterpriseID
+	session.Values["locale"] = claims.Locale
 
 	if err := session.Save(r, w); err != nil {
 		span.RecordError(err)
@@ -54,6 +54,9 @@ func (s *implServer) updateChannelHandler(w http.ResponseWriter, r *http.Request
 		validation.Field(&p.Weekday, validation.Required, validation.By(isx.Weekday)),
 		validation.Field(&p.Hour, validation.Min(0), validation.Max(23)),
 		validation.Field(&p.NextRound, validation.Required, validation.By(isx.NextRoundDate)),
+		validation.Field(&p.Language, validation.By(isx.Language)),
+		validation.Field(&p.Reminders, validation.By(reminderSettings)),
+		validation.Field(&p.Icebreakers, validation.By(icebreakerSettings)),
 	); err != nil {
 		span.RecordError(err)
 
@@ -108,7 +111,7 @@ func (s *implServer) updateChannelHandler(w http.ResponseWriter, r *http.Request
 	// Schedule an UPDATE_CHANNEL job to update the channel's settings (interval, weekday, hour)
 	// for chat-roulette. bot.UpdateChannel() could be directly called here,
 	// however scheduling a background job will ensure it is reliably executed.
-	if err := bot.QueueUpdateChannelJob(r.Context(), db, p); err != nil {
+	if err := bot.QueueUpdateChannelJob(sessionContext(r, session), db, p); err != nil {
 		span.RecordError(err)
 		logger.Error("failed to add job to the queue", "error", "job", models.JobTypeUpdateChannel.String())
 		w.WriteHeader(http.StatusInternalServerError)
@@ -117,3 +120,133 @@ func (s *implServer) updateChannelHandler(w http.ResponseWriter, r *http.Request
 
 	w.WriteHeader(http.StatusAccepted)
 }
+
+// reminderSettings validates the optional reminder settings for a channel
+func reminderSettings(value interface{}) error {
+	r, _ := value.(*bot.ReminderSettingsParams)
+	if r == nil {
+		return nil
+	}
+
+	return validation.ValidateStruct(r,
+		validation.Field(&r.KickoffAfterHours, validation.Required, validation.Min(1), validation.Max(168)),
+		validation.Field(&r.MidRoundCheckInPercent, validation.Required, validation.Min(10), validation.Max(90)),
+		validation.Field(&r.EndRoundCheckInHours, validation.Required, validation.Min(2), validation.Max(72)),
+		validation.Field(&r.MarkInactiveHours, validation.Required, validation.Min(1), validation.Max(24),
+			validation.Max(r.EndRoundCheckInHours-1).Error("must be less than the hours for the end of round check-in")),
+	)
+}
+
+// icebreakerSettings validates the optional icebreaker settings for a channel
+func icebreakerSettings(value interface{}) error {
+	i, _ := value.(*bot.IcebreakerSettingsParams)
+	if i == nil {
+		return nil
+	}
+
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Categories, validation.Each(validation.Required, validation.Length(1, bot.MaxIcebreakerCategoryLength))),
+		validation.Field(&i.RepeatRounds, validation.Required, validation.Min(1), validation.Max(52)),
+	)
+}
+
+type startRoundRequest struct {
+	ChannelID    string    `json:"channel_id"`
+	EndsAt       time.Time `json:"ends_at"`
+	UserGroupID  string    `json:"user_group_id,omitempty"`
+	Participants []string  `json:"participants,omitempty"`
+}
+
+// startRoundHandler handles starting an ad-hoc chat-roulette round for a channel,
+// optionally restricted to the members of a Slack user group or a list of users.
+//
+// Starting the next scheduled round early is not supported: a regular round
+// runs until the next one starts and each round is scheduled from the start of
+// the previous one, so it would end the current round early and shift the
+// schedule of the channel. Ad-hoc rounds run alongside the regular rounds and
+// the start of the next regular round is changed with the channel settings.
+//
+// HTTP Method: POST
+//
+// HTTP Path: /channel/round
+func (s *implServer) startRoundHandler(w http.ResponseWriter, r *http.Request) {
+	logger := hclog.FromContext(r.Context())
+	span := trace.SpanFromContext(r.Context())
+
+	slackUserID, status := s.authenticate(r)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+
+	// Unmarshal request body to JSON
+	var req *startRoundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Validate the request
+	if err := validation.ValidateStruct(req,
+		validation.Field(&req.ChannelID, validation.Required, is.Alphanumeric),
+		validation.Field(&req.EndsAt, validation.Required, validation.By(isx.AdHocRoundEndDate)),
+		validation.Field(&req.UserGroupID, is.Alphanumeric),
+		validation.Field(&req.Participants, validation.Each(is.Alphanumeric)),
+	); err != nil {
+		writeValidationError(w, span, err)
+		return
+	}
+
+	// Verify that the user is authorized to start a round for the chat-roulette channel
+	if status := s.authorizeChannel(r, slackUserID, req.ChannelID); status != http.StatusOK {
+		writeAuthzError(w, status)
+		return
+	}
+
+	session, err := s.GetSession(r)
+	if err != nil {
+		span.RecordError(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	db := s.GetDB()
+
+	dbCtx, cancel := context.WithTimeout(r.Context(), 300*time.Millisecond)
+	defer cancel()
+
+	var channel models.Channel
+	result := db.WithContext(dbCtx).
+		Select("interval").
+		Where("channel_id = ?", req.ChannelID).
+		First(&channel)
+
+	if result.Error != nil {
+		message := "failed to retrieve channel from the database"
+		logger.Error(message, "error", result.Error)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Schedule a CREATE_ROUND job to run immediately for the ad-hoc round
+	p := &bot.CreateRoundParams{
+		ChannelID: req.ChannelID,
+		NextRound: time.Now().UTC(),
+		Interval:  channel.Interval.String(),
+		AdHoc: &bot.AdHocRoundParams{
+			EndsAt:       req.EndsAt.UTC(),
+			UserGroupID:  req.UserGroupID,
+			Participants: req.Participants,
+		},
+	}
+
+	if err := bot.QueueCreateRoundJob(sessionContext(r, session), db, p); err != nil {
+		span.RecordError(err)
+		logger.Error("failed to add job to the queue", "error", err, "job", models.JobTypeCreateRound.String())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
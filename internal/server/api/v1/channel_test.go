@@ -116,6 +116,69 @@ func (s *UpdateChannelHandlerSuite) Test_Validation() {
 	r.Contains(s.response.Body.String(), "validation failed")
 }
 
+func (s *UpdateChannelHandlerSuite) Test_ReminderValidation() {
+	r := require.New(s.T())
+
+	p := &bot.UpdateChannelParams{
+		ChannelID: "C0123456789",
+		Interval:  "weekly",
+		Weekday:   "Thursday",
+		Hour:      12,
+		NextRound: time.Now().UTC(),
+		Reminders: &bot.ReminderSettingsParams{
+			KickoffAfterHours:      24,
+			MidRoundCheckInPercent: 50,
+			EndRoundCheckInHours:   6,
+			MarkInactiveHours:      12,
+		},
+	}
+
+	body := new(bytes.Buffer)
+	json.NewEncoder(body).Encode(p)
+
+	request, _ := http.NewRequest(http.MethodPost, "/v1/channel", body)
+
+	session, err := s.store.Get(request, server.SessionKey)
+	r.NoError(err)
+	session.Values["authenticated"] = true
+	session.Values["slack_user_id"] = "U9876543210"
+	session.Save(request, s.response)
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusBadRequest, s.response.Code)
+	r.Contains(s.response.Body.String(), "must be less than the hours for the end of round check-in")
+}
+
+func (s *UpdateChannelHandlerSuite) Test_LanguageValidation() {
+	r := require.New(s.T())
+
+	p := &bot.UpdateChannelParams{
+		ChannelID: "C0123456789",
+		Interval:  "weekly",
+		Weekday:   "Thursday",
+		Hour:      12,
+		NextRound: time.Now().UTC(),
+		Language:  "tlh",
+	}
+
+	body := new(bytes.Buffer)
+	json.NewEncoder(body).Encode(p)
+
+	request, _ := http.NewRequest(http.MethodPost, "/v1/channel", body)
+
+	session, err := s.store.Get(request, server.SessionKey)
+	r.NoError(err)
+	session.Values["authenticated"] = true
+	session.Values["slack_user_id"] = "U9876543210"
+	session.Save(request, s.response)
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusBadRequest, s.response.Code)
+	r.Contains(s.response.Body.String(), "unsupported language")
+}
+
 func (s *UpdateChannelHandlerSuite) Test_Unauthorized() {
 	r := require.New(s.T())
 
@@ -179,3 +242,126 @@ func (s *UpdateChannelHandlerSuite) Test_Success() {
 func Test_updateChannelHandler_suite(t *testing.T) {
 	suite.Run(t, new(UpdateChannelHandlerSuite))
 }
+
+type StartRoundHandlerSuite struct {
+	suite.Suite
+	resource *dockertest.Resource
+	db       *gorm.DB
+	router   *mux.Router
+	response *httptest.ResponseRecorder
+	store    *sessions.CookieStore
+}
+
+func (s *StartRoundHandlerSuite) SetupTest() {
+	resource, databaseURL, err := database.NewTestPostgresDB(true)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.resource = resource
+
+	db, err := database.NewGormDB(databaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Write channel to the database
+	db.Create(&models.Channel{
+		ChannelID:      "C0123456789",
+		Inviter:        "U9876543210",
+		ConnectionMode: models.ConnectionModePhysical,
+		Interval:       models.Biweekly,
+		Weekday:        time.Friday,
+		Hour:           12,
+		NextRound:      time.Now().Add(24 * time.Hour),
+	})
+
+	s.db = db
+
+	key, _ := hex.DecodeString("8c4faf836e29d282f2dc7ffdf4ef59c6081e2d8964ba0ac9cd4bc8800021300c")
+
+	s.store = sessions.NewCookieStore(key)
+
+	opts := &server.ServerOptions{
+		SessionsStore: s.store,
+		DB:            db,
+	}
+
+	srv := &implServer{server.NewTestServer(opts)}
+
+	s.router = mux.NewRouter()
+	s.router.HandleFunc("/v1/channel/round", srv.startRoundHandler).Methods(http.MethodPost)
+
+	s.response = httptest.NewRecorder()
+}
+
+func (s *StartRoundHandlerSuite) AfterTest(_, _ string) {
+	s.resource.Close()
+}
+
+func (s *StartRoundHandlerSuite) newRequest(req *startRoundRequest, userID string) *http.Request {
+	body := new(bytes.Buffer)
+	json.NewEncoder(body).Encode(req)
+
+	request, _ := http.NewRequest(http.MethodPost, "/v1/channel/round", body)
+
+	session, err := s.store.Get(request, server.SessionKey)
+	require.NoError(s.T(), err)
+	session.Values["authenticated"] = true
+	if userID != "" {
+		session.Values["slack_user_id"] = userID
+	}
+	session.Save(request, s.response)
+
+	return request
+}
+
+func (s *StartRoundHandlerSuite) Test_Validation() {
+	r := require.New(s.T())
+
+	request := s.newRequest(&startRoundRequest{
+		ChannelID: "C0123456789",
+		EndsAt:    time.Now().UTC().Add(time.Hour),
+	}, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusBadRequest, s.response.Code)
+	r.Contains(s.response.Body.String(), "validation failed")
+}
+
+func (s *StartRoundHandlerSuite) Test_Unauthorized() {
+	r := require.New(s.T())
+
+	request := s.newRequest(&startRoundRequest{
+		ChannelID: "C0123456789",
+		EndsAt:    time.Now().UTC().AddDate(0, 0, 7),
+	}, "U1111222233")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusForbidden, s.response.Code)
+	r.Contains(s.response.Body.String(), "authorization failed")
+}
+
+func (s *StartRoundHandlerSuite) Test_Success() {
+	r := require.New(s.T())
+
+	request := s.newRequest(&startRoundRequest{
+		ChannelID:    "C0123456789",
+		EndsAt:       time.Now().UTC().AddDate(0, 0, 7),
+		Participants: []string{"U0123456789", "U1111222233"},
+	}, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusAccepted, s.response.Code)
+
+	var count int64
+	result := s.db.Model(&models.Job{}).Where("job_type = ?", models.JobTypeCreateRound).Count(&count)
+	r.NoError(result.Error)
+	r.Equal(int64(1), count)
+}
+
+func Test_startRoundHandler_suite(t *testing.T) {
+	suite.Run(t, new(StartRoundHandlerSuite))
+}
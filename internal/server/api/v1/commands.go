@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/slack-go/slack"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
+	"github.com/chat-roulettte/chat-roulette/internal/iox"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+	"github.com/chat-roulettte/chat-roulette/internal/server"
+)
+
+// slackCommandHandler handles slash commands sent by Slack
+// See: https://api.slack.com/interactivity/slash-commands
+//
+// HTTP Method: POST
+//
+// HTTP Path: /slack/command
+func (s *implServer) slackCommandHandler(w http.ResponseWriter, r *http.Request) {
+	logger := hclog.FromContext(r.Context())
+	span := trace.SpanFromContext(r.Context())
+
+	// Verify that the request is sent from Slack by validating the X-Slack-Signature header.
+	// See: https://api.slack.com/authentication/verifying-requests-from-slack
+	//
+	// To ease testing, skip verification if running in Dev mode.
+	b, err := iox.ReadAndReset(&r.Body)
+	if err != nil {
+		span.RecordError(err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !s.IsDevMode() && !server.IsSocketModeRequest(r) {
+		sv, err := slack.NewSecretsVerifier(r.Header, s.GetSlackSigningSecret())
+		if err != nil {
+			span.RecordError(err)
+			logger.Error("failed to create new SecretsVerifier", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if _, err := sv.Write(b); err != nil {
+			span.RecordError(err)
+			logger.Error("failed to compute signature", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err := sv.Ensure(); err != nil {
+			span.RecordError(err)
+			logger.Error("failed to verify request is from Slack", "error", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	cmd, err := slack.SlashCommandParse(r)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to parse Slack slash command", "error", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	logger = logger.With(
+		attributes.SlackTeamID, cmd.TeamID,
+		attributes.SlackEnterpriseID, cmd.EnterpriseID,
+		attributes.SlackCommand, cmd.Command,
+		attributes.SlackChannelID, cmd.ChannelID,
+		attributes.SlackUserID, cmd.UserID,
+	)
+
+	span.SetAttributes(
+		attribute.String(attributes.SlackCommand, cmd.Command),
+		attribute.String(attributes.SlackTeamID, cmd.TeamID),
+		attribute.String(attributes.SlackEnterpriseID, cmd.EnterpriseID),
+	)
+
+	// Jobs queued for the command are executed with the Slack client of its workspace
+	ctx := bot.ContextWithTeamID(hclog.WithContext(r.Context(), logger), cmd.TeamID)
+	ctx = bot.ContextWithEnterpriseID(ctx, cmd.EnterpriseID)
+
+	var text string
+
+	switch cmd.Command {
+	case bot.RouletteCommand:
+		p := &bot.RouletteCommandParams{
+			AppURL:    s.GetBaseURL(),
+			ChannelID: cmd.ChannelID,
+			UserID:    cmd.UserID,
+			Text:      cmd.Text,
+		}
+
+		text, err = bot.HandleRouletteCommand(ctx, s.GetDB(), p)
+		if err != nil {
+			span.RecordError(err)
+			logger.Error("failed to handle slash command", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+	default:
+		logger.Error("unsupported slash command")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Respond with a message that is only visible to the user
+	msg := slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         text,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(msg) //nolint:errcheck
+}
@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
+	"github.com/chat-roulettte/chat-roulette/internal/config"
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/server"
+)
+
+func newSlashCommandRequest(command, text string) *http.Request {
+	d := url.Values{
+		"command":    []string{command},
+		"text":       []string{text},
+		"team_id":    []string{"T0123456789"},
+		"channel_id": []string{"C0123456789"},
+		"user_id":    []string{"U0123456789"},
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "/v1/slack/command", strings.NewReader(d.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	return req
+}
+
+func Test_slackCommandHandler(t *testing.T) {
+	opts := &server.ServerOptions{
+		DevMode: true,
+		Config: &config.Config{
+			Server: config.ServerConfig{
+				RedirectURL: "http://localhost/oauth/callback",
+			},
+		},
+	}
+
+	t.Run("help", func(t *testing.T) {
+		r := require.New(t)
+
+		s := &implServer{server.NewTestServer(opts)}
+
+		resp := httptest.NewRecorder()
+		s.slackCommandHandler(resp, newSlashCommandRequest(bot.RouletteCommand, ""))
+
+		r.Equal(http.StatusOK, resp.Code)
+		r.Equal("application/json", resp.Header().Get("Content-Type"))
+
+		var msg slack.Msg
+		r.NoError(json.NewDecoder(resp.Body).Decode(&msg))
+		r.Equal(slack.ResponseTypeEphemeral, msg.ResponseType)
+		r.Contains(msg.Text, "/roulette status")
+	})
+
+	t.Run("unsupported command", func(t *testing.T) {
+		r := require.New(t)
+
+		s := &implServer{server.NewTestServer(opts)}
+
+		resp := httptest.NewRecorder()
+		s.slackCommandHandler(resp, newSlashCommandRequest("/unknown", "status"))
+
+		r.Equal(http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("unverified", func(t *testing.T) {
+		r := require.New(t)
+
+		s := &implServer{server.NewTestServer(&server.ServerOptions{
+			Config: &config.Config{
+				Server: config.ServerConfig{
+					SigningSecret: "secret",
+				},
+			},
+		})}
+
+		resp := httptest.NewRecorder()
+		s.slackCommandHandler(resp, newSlashCommandRequest(bot.RouletteCommand, "status"))
+
+		r.Equal(http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("pause", func(t *testing.T) {
+		r := require.New(t)
+
+		db, mock := database.NewMockedGormDB()
+
+		mock.ExpectQuery(`SELECT \* FROM "channels" WHERE channel_id IN \(SELECT "channel_id" FROM "members" WHERE user_id = \$1\)`).
+			WithArgs("U0123456789").
+			WillReturnRows(sqlmock.NewRows([]string{"channel_id", "inviter", "interval", "next_round"}).
+				AddRow("C0123456789", "U9876543210", models.Weekly.String(), time.Now().Add(24*time.Hour)))
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "jobs" SET "status"=\$1,"is_completed"=\$2,"updated_at"=\$3 WHERE idempotency_key = \$4 AND is_completed = false`).
+			WithArgs(models.JobStatusCanceled, true, database.AnyTime(), "UPDATE_MEMBER:C0123456789:U0123456789:skip").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		isActive := false
+
+		database.MockQueueJobForTeam(
+			mock,
+			&bot.UpdateMemberParams{
+				ChannelID: "C0123456789",
+				UserID:    "U0123456789",
+				IsActive:  &isActive,
+			},
+			models.JobTypeUpdateMember.String(),
+			models.JobPriorityHigh,
+			"T0123456789",
+		)
+
+		o := *opts
+		o.DB = db
+		s := &implServer{server.NewTestServer(&o)}
+
+		resp := httptest.NewRecorder()
+		s.slackCommandHandler(resp, newSlashCommandRequest(bot.RouletteCommand, "pause"))
+
+		r.Equal(http.StatusOK, resp.Code)
+		r.NoError(mock.ExpectationsWereMet())
+
+		var msg slack.Msg
+		r.NoError(json.NewDecoder(resp.Body).Decode(&msg))
+		r.Contains(msg.Text, "You've paused Chat Roulette in <#C0123456789>")
+	})
+}
@@ -9,5 +9,6 @@ type ErrResponse struct {
 }
 
 var (
-	ErrAuthzFailed = errors.New("authorization failed")
+	ErrAuthzFailed  = errors.New("authorization failed")
+	ErrDuplicateJob = errors.New("a pending job with the same idempotency key already exists")
 )
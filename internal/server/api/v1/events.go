@@ -16,6 +16,7 @@ import (
 	"github.com/chat-roulettte/chat-roulette/internal/bot"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+	"github.com/chat-roulettte/chat-roulette/internal/server"
 )
 
 // slackEventHandler handles events sent by the Slack Events API
@@ -35,7 +36,7 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Skip verification of signing secret if running in Dev mode, to ease testing
-	if !s.IsDevMode() {
+	if !s.IsDevMode() && !server.IsSocketModeRequest(r) {
 		sv, err := slack.NewSecretsVerifier(r.Header, s.GetSlackSigningSecret())
 		if err != nil {
 			span.RecordError(err)
@@ -89,11 +90,28 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 
 	case slackevents.CallbackEvent:
 		innerEvent := eventsAPIEvent.InnerEvent
+		teamID := eventsAPIEvent.TeamID
+		enterpriseID := eventsAPIEvent.EnterpriseID
+
+		logger = logger.With(
+			attributes.SlackTeamID, teamID,
+			attributes.SlackEnterpriseID, enterpriseID,
+		)
 
 		span.SetAttributes(
 			attribute.String(attributes.SlackEvent, innerEvent.Type),
+			attribute.String(attributes.SlackTeamID, teamID),
+			attribute.String(attributes.SlackEnterpriseID, enterpriseID),
 		)
 
+		// Jobs queued for the event are executed with the Slack client of its workspace
+		ctx := bot.ContextWithEnterpriseID(bot.ContextWithTeamID(r.Context(), teamID), enterpriseID)
+
+		// Jobs are only queued once for the event, even if Slack retries its delivery
+		if callbackEvent, ok := eventsAPIEvent.Data.(*slackevents.EventsAPICallbackEvent); ok {
+			ctx = bot.ContextWithSlackEventID(ctx, callbackEvent.EventID)
+		}
+
 		switch ev := innerEvent.Data.(type) {
 
 		// Handle member_joined_channel events
@@ -101,8 +119,17 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 		case *slackevents.MemberJoinedChannelEvent:
 			db := s.GetDB()
 
+			botUserID, err := s.GetTeamSlackBotUserID(ctx, enterpriseID, teamID)
+			if err != nil {
+				span.RecordError(err)
+				logger.Error("failed to retrieve the user ID of the chat-roulette Slack bot", "error", err)
+				// Return HTTP 503 so that Slack marks the event as failed to deliver and retries up to 3 times.
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
 			// Onboard Slack channel for chat-roulette when the bot is invited to a channel
-			if s.GetSlackBotUserID() == ev.User {
+			if botUserID == ev.User {
 
 				// Inviter is blank if the bot is added by default to the channel
 				if ev.Inviter == "" {
@@ -110,7 +137,7 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 						BotUserID: ev.User,
 					}
 
-					if err := bot.QueueSyncChannelsJob(r.Context(), db, p); err != nil {
+					if err := bot.QueueSyncChannelsJob(ctx, db, p); err != nil {
 						span.RecordError(err)
 						logger.Error("failed to add job to the queue", "error", err, "job", models.JobTypeSyncChannels.String())
 						// Return HTTP 503 so that Slack marks the event as failed to deliver and retries up to 3 times.
@@ -124,7 +151,7 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 						Inviter:   ev.Inviter,
 					}
 
-					if err := bot.QueueGreetAdminJob(r.Context(), db, p); err != nil {
+					if err := bot.QueueGreetAdminJob(ctx, db, p); err != nil {
 						span.RecordError(err)
 						logger.Error("failed to add job to the queue", "error", err, "job", models.JobTypeGreetAdmin.String())
 						// Return HTTP 503 so that Slack marks the event as failed to deliver and retries up to 3 times.
@@ -138,7 +165,7 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Verify that the Slack channel is enrolled for chat-roulette
-			dbCtx, cancel := context.WithTimeout(r.Context(), 500*time.Millisecond)
+			dbCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 			defer cancel()
 
 			var channel models.Channel
@@ -158,7 +185,7 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 				UserID:    ev.User,
 			}
 
-			if err := bot.QueueAddMemberJob(r.Context(), db, p); err != nil {
+			if err := bot.QueueAddMemberJob(ctx, db, p); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to add job to the queue", "error", err, "job", models.JobTypeAddMember)
 				// Return HTTP 503 so that Slack marks the event as failed to deliver and retries up to 3 times.
@@ -172,7 +199,7 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 			db := s.GetDB()
 
 			// Verify that the Slack channel is enrolled for chat roulette
-			dbCtx, cancel := context.WithTimeout(r.Context(), 500*time.Millisecond)
+			dbCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 			defer cancel()
 
 			var channel models.Channel
@@ -193,7 +220,7 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 				UserID:    ev.User,
 			}
 
-			if err := bot.QueueDeleteMemberJob(r.Context(), db, p); err != nil {
+			if err := bot.QueueDeleteMemberJob(ctx, db, p); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to add job to the queue", "error", err, "job", models.JobTypeDeleteMember)
 				// Return HTTP 503 so that Slack marks the event as failed to deliver and retries up to 3 times.
@@ -205,13 +232,29 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 		// See: https://api.slack.com/events/app_home_opened
 		case *slackevents.AppHomeOpenedEvent:
 			if ev.Tab == "home" {
+				botUserID, err := s.GetTeamSlackBotUserID(ctx, enterpriseID, teamID)
+				if err != nil {
+					span.RecordError(err)
+					logger.Error("failed to retrieve the user ID of the chat-roulette Slack bot", "error", err)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				client, err := s.GetTeamSlackClient(ctx, enterpriseID, teamID)
+				if err != nil {
+					span.RecordError(err)
+					logger.Error("failed to retrieve the Slack client of the workspace", "error", err)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
 				p := &bot.AppHomeParams{
 					UserID:    ev.User,
-					BotUserID: s.GetSlackBotUserID(),
+					BotUserID: botUserID,
 					URL:       s.GetBaseURL(),
 				}
 
-				if err := bot.HandleAppHomeEvent(r.Context(), s.GetSlackClient(), s.GetDB(), p); err != nil {
+				if err := bot.HandleAppHomeEvent(ctx, client, s.GetDB(), p); err != nil {
 					span.RecordError(err)
 					logger.Error("failed to handle app_home_opened event", "error", err)
 
@@ -225,6 +268,27 @@ func (s *implServer) slackEventHandler(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
 			return
 
+		// Handle app_uninstalled events
+		// See: https://api.slack.com/events/app_uninstalled
+		case *slackevents.AppUninstalledEvent:
+			if err := bot.DeleteWorkspace(ctx, s.GetDB(), enterpriseID, teamID); err != nil {
+				span.RecordError(err)
+				// Return HTTP 503 so that Slack marks the event as failed to deliver and retries up to 3 times.
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			if clients := s.GetSlackClients(); clients != nil {
+				clients.Forget(teamID)
+
+				if enterpriseID != "" {
+					clients.Forget(enterpriseID)
+				}
+			}
+
+			w.WriteHeader(http.StatusOK)
+			return
+
 		default:
 			w.WriteHeader(http.StatusOK)
 			return
@@ -178,7 +178,7 @@ func Test_slackEventHandler_BotJoinedChannelEvent_failure(t *testing.T) {
 	s := &implServer{srv}
 
 	mock.ExpectBegin()
-	mock.ExpectQuery(`INSERT INTO "jobs" (.+) VALUES (.+) RETURNING`).
+	mock.ExpectQuery(`INSERT INTO "jobs" (.+) VALUES (.+) ON CONFLICT DO NOTHING RETURNING`).
 		WithArgs(
 			sqlmock.AnyArg(),
 			models.JobTypeGreetAdmin.String(),
@@ -189,6 +189,7 @@ func Test_slackEventHandler_BotJoinedChannelEvent_failure(t *testing.T) {
 			database.AnyTime(),
 			database.AnyTime(),
 			database.AnyTime(),
+			sqlmock.AnyArg(),
 		).
 		WillReturnError(fmt.Errorf("failed to add job to the queue"))
 	mock.ExpectRollback()
@@ -259,7 +260,7 @@ func Test_slackEventHandler_MemberJoinedChannelEvent(t *testing.T) {
 		UserID:    userID,
 	}
 
-	database.MockQueueJob(mock, p, models.JobTypeAddMember.String(), models.JobPriorityHigh)
+	database.MockQueueJobWithKeyForTeam(mock, p, models.JobTypeAddMember.String(), models.JobPriorityHigh, "ADD_MEMBER:"+channelID+":"+userID, "TXXXXXXXX")
 
 	path := "/v1/slack/events"
 
@@ -330,7 +331,7 @@ func Test_slackEventHandler_MemberLeftChannelEvent(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"channel_id"}).AddRow(p.ChannelID))
 
 	// Mock adding the DELETE_MEMBER job
-	database.MockQueueJob(mock, p, models.JobTypeDeleteMember.String(), models.JobPriorityHigh)
+	database.MockQueueJobWithKeyForTeam(mock, p, models.JobTypeDeleteMember.String(), models.JobPriorityHigh, "DELETE_MEMBER:"+p.ChannelID+":"+p.UserID, "TXXXXXXXX")
 
 	path := "/v1/slack/events"
 
@@ -0,0 +1,282 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-hclog"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+)
+
+// maxIcebreakersRequestSize is the maximum size of a request to add or import icebreakers.
+// It fits the maximum number of icebreakers at their maximum length, with room for the
+// JSON encoding and the quoting of CSV fields.
+const maxIcebreakersRequestSize = bot.MaxIcebreakersImport*(bot.MaxIcebreakerLength+bot.MaxIcebreakerCategoryLength) + 64*1024
+
+type addIcebreakersRequest struct {
+	ChannelID   string                 `json:"channel_id"`
+	Icebreakers []bot.IcebreakerParams `json:"icebreakers"`
+}
+
+type importIcebreakersRequest struct {
+	ChannelID string `json:"channel_id"`
+	CSV       string `json:"csv"`
+}
+
+type addIcebreakersResponse struct {
+	Added int64 `json:"added"`
+}
+
+// addIcebreakersHandler handles adding custom icebreakers for a channel.
+//
+// HTTP Method: POST
+//
+// HTTP Path: /channel/icebreakers
+func (s *implServer) addIcebreakersHandler(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	slackUserID, status := s.authenticate(r)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+
+	// Unmarshal request body to JSON
+	r.Body = http.MaxBytesReader(w, r.Body, maxIcebreakersRequestSize)
+
+	var req *addIcebreakersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Validate the request
+	if err := validation.ValidateStruct(req,
+		validation.Field(&req.ChannelID, validation.Required, is.Alphanumeric),
+		validation.Field(&req.Icebreakers, validation.Required, validation.Length(1, bot.MaxIcebreakersImport), validation.Each(validation.By(icebreaker))),
+	); err != nil {
+		writeValidationError(w, span, err)
+		return
+	}
+
+	s.addIcebreakers(w, r, slackUserID, req.ChannelID, req.Icebreakers)
+}
+
+// importIcebreakersHandler handles importing custom icebreakers for a channel from a CSV file.
+//
+// HTTP Method: POST
+//
+// HTTP Path: /channel/icebreakers/import
+func (s *implServer) importIcebreakersHandler(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	slackUserID, status := s.authenticate(r)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+
+	// Unmarshal request body to JSON
+	r.Body = http.MaxBytesReader(w, r.Body, maxIcebreakersRequestSize)
+
+	var req *importIcebreakersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Validate the request
+	if err := validation.ValidateStruct(req,
+		validation.Field(&req.ChannelID, validation.Required, is.Alphanumeric),
+		validation.Field(&req.CSV, validation.Required),
+	); err != nil {
+		writeValidationError(w, span, err)
+		return
+	}
+
+	icebreakers, err := bot.ParseIcebreakersCSV(strings.NewReader(req.CSV))
+	if err != nil {
+		writeValidationError(w, span, fmt.Errorf("csv: %s", err))
+		return
+	}
+
+	s.addIcebreakers(w, r, slackUserID, req.ChannelID, icebreakers)
+}
+
+// deleteIcebreakerHandler handles deleting a custom icebreaker of a channel.
+//
+// HTTP Method: DELETE
+//
+// HTTP Path: /channel/icebreakers/{icebreaker_id}
+func (s *implServer) deleteIcebreakerHandler(w http.ResponseWriter, r *http.Request) {
+	logger := hclog.FromContext(r.Context())
+	span := trace.SpanFromContext(r.Context())
+
+	slackUserID, status := s.authenticate(r)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+
+	icebreakerID, err := strconv.ParseInt(mux.Vars(r)["icebreaker_id"], 10, 32)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	db := s.GetDB()
+
+	dbCtx, cancel := context.WithTimeout(r.Context(), 300*time.Millisecond)
+	defer cancel()
+
+	// Only custom icebreakers of a channel can be deleted
+	var channelID string
+	result := db.WithContext(dbCtx).
+		Model(&models.Icebreaker{}).
+		Select("channel_id").
+		Where("id = ?", icebreakerID).
+		Where("channel_id IS NOT NULL").
+		First(&channelID)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if result.Error != nil {
+		span.RecordError(result.Error)
+		logger.Error("failed to retrieve icebreaker from the database", "error", result.Error)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if status := s.authorizeChannel(r, slackUserID, channelID); status != http.StatusOK {
+		writeAuthzError(w, status)
+		return
+	}
+
+	if err := bot.DeleteIcebreaker(r.Context(), db, channelID, int32(icebreakerID)); err != nil {
+		span.RecordError(err)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// addIcebreakers adds the custom icebreakers for a channel
+// after verifying that the user is authorized to modify it.
+func (s *implServer) addIcebreakers(w http.ResponseWriter, r *http.Request, slackUserID, channelID string, icebreakers []bot.IcebreakerParams) {
+	span := trace.SpanFromContext(r.Context())
+
+	if status := s.authorizeChannel(r, slackUserID, channelID); status != http.StatusOK {
+		writeAuthzError(w, status)
+		return
+	}
+
+	added, err := bot.AddIcebreakers(r.Context(), s.GetDB(), channelID, icebreakers)
+	if err != nil {
+		span.RecordError(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(&addIcebreakersResponse{Added: added}) //nolint:errcheck
+}
+
+// authorizeChannel verifies that the user is the inviter of the chat-roulette channel.
+func (s *implServer) authorizeChannel(r *http.Request, slackUserID, channelID string) int {
+	logger := hclog.FromContext(r.Context())
+	span := trace.SpanFromContext(r.Context())
+
+	dbCtx, cancel := context.WithTimeout(r.Context(), 300*time.Millisecond)
+	defer cancel()
+
+	var inviter string
+	result := s.GetDB().WithContext(dbCtx).
+		Model(&models.Channel{}).
+		Select("inviter").
+		Where("channel_id = ?", channelID).
+		First(&inviter)
+
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		message := "failed to retrieve inviter from the database"
+		logger.Error(message, "error", result.Error)
+		return http.StatusInternalServerError
+	}
+
+	if inviter != slackUserID {
+		span.RecordError(ErrAuthzFailed)
+		logger.Error("failed to authorize the user", "error", "user is not authorized to modify the chat-roulette channel")
+		return http.StatusForbidden
+	}
+
+	return http.StatusOK
+}
+
+// icebreaker validates a custom icebreaker for a channel
+func icebreaker(value interface{}) error {
+	i, _ := value.(bot.IcebreakerParams)
+
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Question, validation.Required, validation.Length(1, bot.MaxIcebreakerLength)),
+		validation.Field(&i.Category, validation.Length(0, bot.MaxIcebreakerCategoryLength)),
+	)
+}
+
+// writeValidationError writes the response for a request that failed validation
+func writeValidationError(w http.ResponseWriter, span trace.Span, err error) {
+	span.RecordError(err)
+
+	response := ErrResponse{
+		Error: fmt.Sprintf("validation failed: %s", err),
+	}
+
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(response) //nolint:errcheck
+}
+
+// writeAuthzError writes the response for a request that failed authorization
+func writeAuthzError(w http.ResponseWriter, status int) {
+	w.WriteHeader(status)
+
+	if status == http.StatusForbidden {
+		json.NewEncoder(w).Encode(ErrResponse{Error: ErrAuthzFailed.Error()}) //nolint:errcheck
+	}
+}
@@ -0,0 +1,250 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/sessions"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/server"
+)
+
+type IcebreakersHandlersSuite struct {
+	suite.Suite
+	resource *dockertest.Resource
+	db       *gorm.DB
+	router   *mux.Router
+	response *httptest.ResponseRecorder
+	store    *sessions.CookieStore
+}
+
+func (s *IcebreakersHandlersSuite) SetupTest() {
+	resource, databaseURL, err := database.NewTestPostgresDB(true)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.resource = resource
+
+	db, err := database.NewGormDB(databaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Write channel to the database
+	db.Create(&models.Channel{
+		ChannelID:      "C0123456789",
+		Inviter:        "U9876543210",
+		ConnectionMode: models.ConnectionModePhysical,
+		Interval:       models.Biweekly,
+		Weekday:        time.Friday,
+		Hour:           12,
+		NextRound:      time.Now().Add(24 * time.Hour),
+	})
+
+	s.db = db
+
+	key, _ := hex.DecodeString("8c4faf836e29d282f2dc7ffdf4ef59c6081e2d8964ba0ac9cd4bc8800021300c")
+
+	s.store = sessions.NewCookieStore(key)
+
+	opts := &server.ServerOptions{
+		SessionsStore: s.store,
+		DB:            db,
+	}
+
+	srv := &implServer{server.NewTestServer(opts)}
+
+	s.router = mux.NewRouter()
+	s.router.HandleFunc("/v1/channel/icebreakers", srv.addIcebreakersHandler).Methods(http.MethodPost)
+	s.router.HandleFunc("/v1/channel/icebreakers/import", srv.importIcebreakersHandler).Methods(http.MethodPost)
+	s.router.HandleFunc("/v1/channel/icebreakers/{icebreaker_id}", srv.deleteIcebreakerHandler).Methods(http.MethodDelete)
+
+	s.response = httptest.NewRecorder()
+}
+
+func (s *IcebreakersHandlersSuite) AfterTest(_, _ string) {
+	s.resource.Close()
+}
+
+func (s *IcebreakersHandlersSuite) newRequest(method, path string, body interface{}, slackUserID string) *http.Request {
+	b := new(bytes.Buffer)
+	if body != nil {
+		json.NewEncoder(b).Encode(body)
+	}
+
+	request, _ := http.NewRequest(method, path, b)
+
+	session, err := s.store.Get(request, server.SessionKey)
+	s.Require().NoError(err)
+	session.Values["authenticated"] = true
+	session.Values["slack_user_id"] = slackUserID
+	session.Save(request, s.response)
+
+	return request
+}
+
+func (s *IcebreakersHandlersSuite) Test_Unauthenticated() {
+	r := require.New(s.T())
+
+	request, _ := http.NewRequest(http.MethodPost, "/v1/channel/icebreakers", nil)
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusUnauthorized, s.response.Code)
+}
+
+func (s *IcebreakersHandlersSuite) Test_Validation() {
+	r := require.New(s.T())
+
+	body := &addIcebreakersRequest{
+		ChannelID: "C0123456789",
+		Icebreakers: []bot.IcebreakerParams{
+			{Question: ""},
+		},
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/icebreakers", body, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusBadRequest, s.response.Code)
+	r.Contains(s.response.Body.String(), "validation failed")
+}
+
+func (s *IcebreakersHandlersSuite) Test_Unauthorized() {
+	r := require.New(s.T())
+
+	body := &addIcebreakersRequest{
+		ChannelID: "C0123456789",
+		Icebreakers: []bot.IcebreakerParams{
+			{Question: "What's your favorite book?"},
+		},
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/icebreakers", body, "U1111222233")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusForbidden, s.response.Code)
+	r.Contains(s.response.Body.String(), "authorization failed")
+}
+
+func (s *IcebreakersHandlersSuite) Test_Add() {
+	r := require.New(s.T())
+
+	body := &addIcebreakersRequest{
+		ChannelID: "C0123456789",
+		Icebreakers: []bot.IcebreakerParams{
+			{Question: "What's your favorite book?", Category: "Books"},
+		},
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/icebreakers", body, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusCreated, s.response.Code)
+	r.JSONEq(`{"added":1}`, s.response.Body.String())
+
+	var icebreaker models.Icebreaker
+	r.NoError(s.db.Where("channel_id = ?", "C0123456789").First(&icebreaker).Error)
+	r.Equal("books", icebreaker.Category)
+}
+
+func (s *IcebreakersHandlersSuite) Test_Import() {
+	r := require.New(s.T())
+
+	body := &importIcebreakersRequest{
+		ChannelID: "C0123456789",
+		CSV:       "question,category\nWhat's your favorite book?,books\nTea or coffee?,food\nTea or coffee?,food",
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/icebreakers/import", body, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusCreated, s.response.Code)
+	r.JSONEq(`{"added":2}`, s.response.Body.String())
+}
+
+func (s *IcebreakersHandlersSuite) Test_Import_InvalidCSV() {
+	r := require.New(s.T())
+
+	body := &importIcebreakersRequest{
+		ChannelID: "C0123456789",
+		CSV:       "question,category\n,books",
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/icebreakers/import", body, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusBadRequest, s.response.Code)
+	r.Contains(s.response.Body.String(), "question cannot be blank")
+}
+
+func (s *IcebreakersHandlersSuite) Test_Import_TooLarge() {
+	r := require.New(s.T())
+
+	body := &importIcebreakersRequest{
+		ChannelID: "C0123456789",
+		CSV:       strings.Repeat("What's your favorite book?,books\n", maxIcebreakersRequestSize/32),
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/icebreakers/import", body, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusRequestEntityTooLarge, s.response.Code)
+}
+
+func (s *IcebreakersHandlersSuite) Test_Delete() {
+	r := require.New(s.T())
+
+	icebreaker := &models.Icebreaker{
+		ChannelID: "C0123456789",
+		Question:  "What's your favorite book?",
+	}
+	r.NoError(s.db.Create(icebreaker).Error)
+
+	request := s.newRequest(http.MethodDelete, fmt.Sprintf("/v1/channel/icebreakers/%d", icebreaker.ID), nil, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusNoContent, s.response.Code)
+
+	var count int64
+	r.NoError(s.db.Model(&models.Icebreaker{}).Where("id = ?", icebreaker.ID).Count(&count).Error)
+	r.Zero(count)
+}
+
+func (s *IcebreakersHandlersSuite) Test_Delete_Global() {
+	r := require.New(s.T())
+
+	// Icebreakers shared by every channel cannot be deleted
+	request := s.newRequest(http.MethodDelete, "/v1/channel/icebreakers/1", nil, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusNotFound, s.response.Code)
+}
+
+func Test_icebreakersHandlers_suite(t *testing.T) {
+	suite.Run(t, new(IcebreakersHandlersSuite))
+}
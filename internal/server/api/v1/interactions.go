@@ -16,6 +16,7 @@ import (
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
 	"github.com/chat-roulettte/chat-roulette/internal/iox"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+	"github.com/chat-roulettte/chat-roulette/internal/server"
 )
 
 const (
@@ -42,7 +43,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if !s.IsDevMode() {
+	if !s.IsDevMode() && !server.IsSocketModeRequest(r) {
 		sv, err := slack.NewSecretsVerifier(r.Header, s.GetSlackSigningSecret())
 		if err != nil {
 			span.RecordError(err)
@@ -91,10 +92,23 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	teamID := interaction.Team.ID
+	enterpriseID := interaction.Enterprise.ID
+
+	logger = logger.With(
+		attributes.SlackTeamID, teamID,
+		attributes.SlackEnterpriseID, enterpriseID,
+	)
+
 	span.SetAttributes(
 		attribute.String(attributes.SlackInteraction, string(interaction.Type)),
+		attribute.String(attributes.SlackTeamID, teamID),
+		attribute.String(attributes.SlackEnterpriseID, enterpriseID),
 	)
 
+	// Jobs queued for the interaction are executed with the Slack client of its workspace
+	ctx := bot.ContextWithEnterpriseID(bot.ContextWithTeamID(r.Context(), teamID), enterpriseID)
+
 	switch interaction.Type {
 	case slack.InteractionTypeViewSubmission:
 
@@ -106,7 +120,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 		switch interaction.View.CallbackID {
 		case "onboarding-admin-modal":
 			// Respond to the HTTP request with the new view
-			body, err := bot.RenderOnboardingChannelView(r.Context(), &interaction, s.GetBaseURL())
+			body, err := bot.RenderOnboardingChannelView(ctx, &interaction, s.GetBaseURL())
 			if err != nil {
 				span.RecordError(err)
 				logger.Error("failed to load onboarding channel template", "error", err)
@@ -121,7 +135,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 		case "onboarding-channel":
 			// Parse the contents of the view and queue ADD_CHANNEL job
-			if err := bot.UpsertChannelSettings(r.Context(), s.GetDB(), &interaction); err != nil {
+			if err := bot.UpsertChannelSettings(ctx, s.GetDB(), &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to configure Slack channel settings", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -129,7 +143,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 			}
 
 			// Update the original GREET_ADMIN message to remove the button
-			if err := bot.RespondGreetAdminWebhook(r.Context(), s.GetHTTPClient(), &interaction); err != nil {
+			if err := bot.RespondGreetAdminWebhook(ctx, s.GetHTTPClient(), &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to respond to GREET_ADMIN webhook", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -144,7 +158,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 		case "onboarding-member-modal":
 			// Respond to the HTTP request with the new view
-			body, err := bot.RenderOnboardingLocationView(r.Context(), &interaction, s.GetBaseURL())
+			body, err := bot.RenderOnboardingLocationView(ctx, &interaction, s.GetBaseURL())
 			if err != nil {
 				span.RecordError(err)
 				logger.Error("failed to load onboarding location template", "error", err)
@@ -159,7 +173,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 		case "onboarding-location":
 			// Parse the contents of the view and queue UPDATE_MEMBER job
-			if err := bot.UpsertMemberLocationInfo(r.Context(), s.GetDB(), &interaction); err != nil {
+			if err := bot.UpsertMemberLocationInfo(ctx, s.GetDB(), &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to upsert Slack member's location info", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -167,7 +181,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 			}
 
 			// Respond to the HTTP request with the new view
-			body, err := bot.RenderOnboardingTimezoneView(r.Context(), &interaction, s.GetBaseURL())
+			body, err := bot.RenderOnboardingTimezoneView(ctx, &interaction, s.GetBaseURL())
 			if err != nil {
 				span.RecordError(err)
 				logger.Error("failed to load onboarding timezone template", "error", err)
@@ -184,7 +198,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 			db := s.GetDB()
 
 			// Parse the contents of the view and queue UPDATE_MEMBER job
-			if err := bot.UpsertMemberTimezoneInfo(r.Context(), db, &interaction); err != nil {
+			if err := bot.UpsertMemberTimezoneInfo(ctx, db, &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to upsert Slack member's timezone info", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -203,7 +217,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 			// Lookup the channel's Connection Mode
 			var connectionMode models.ConnectionMode
 
-			dbCtx, cancel := context.WithTimeout(r.Context(), 200*time.Second)
+			dbCtx, cancel := context.WithTimeout(ctx, 200*time.Second)
 			defer cancel()
 
 			result := db.WithContext(dbCtx).Model(&models.Channel{}).Select("connection_mode").Where("channel_id = ?", channelID).Scan(&connectionMode)
@@ -218,9 +232,9 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 			// Connection Mode preference for participants is only applicable when the Chat Roulette channel is running in "Hybrid" mode
 			if connectionMode == models.ConnectionModeHybrid {
-				body, err = bot.RenderOnboardingConnectionModeView(r.Context(), &interaction, s.GetBaseURL())
+				body, err = bot.RenderOnboardingConnectionModeView(ctx, &interaction, s.GetBaseURL())
 			} else {
-				body, err = bot.RenderOnboardingGenderView(r.Context(), &interaction, s.GetBaseURL())
+				body, err = bot.RenderOnboardingGenderView(ctx, &interaction, s.GetBaseURL())
 			}
 			if err != nil {
 				span.RecordError(err)
@@ -236,7 +250,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 		case "onboarding-connection-mode":
 			// Parse the contents of the view and queue UPDATE_MEMBER job
-			if err := bot.UpsertMemberConnectionMode(r.Context(), s.GetDB(), &interaction); err != nil {
+			if err := bot.UpsertMemberConnectionMode(ctx, s.GetDB(), &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to upsert Slack member's connection mode preference", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -244,7 +258,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 			}
 
 			// Respond to the HTTP request with the new view
-			body, err := bot.RenderOnboardingGenderView(r.Context(), &interaction, s.GetBaseURL())
+			body, err := bot.RenderOnboardingGenderView(ctx, &interaction, s.GetBaseURL())
 			if err != nil {
 				span.RecordError(err)
 				logger.Error("failed to load onboarding gender template", "error", err)
@@ -259,7 +273,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 		case "onboarding-gender":
 			// Parse the contents of the view and queue UPDATE_MEMBER job
-			if err := bot.UpsertMemberGenderInfo(r.Context(), s.GetDB(), &interaction); err != nil {
+			if err := bot.UpsertMemberGenderInfo(ctx, s.GetDB(), &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to upsert Slack member's gender info", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -267,7 +281,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 			}
 
 			// Respond to the HTTP request with the new view
-			body, err := bot.RenderOnboardingProfileView(r.Context(), &interaction, s.GetBaseURL())
+			body, err := bot.RenderOnboardingProfileView(ctx, &interaction, s.GetBaseURL())
 			if err != nil {
 				span.RecordError(err)
 				logger.Error("failed to load onboarding profile template", "error", err)
@@ -282,7 +296,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 		case "onboarding-profile":
 			// Validate the Slack member's profile data
-			if err := bot.ValidateMemberProfileInfo(r.Context(), &interaction); err != nil {
+			if err := bot.ValidateMemberProfileInfo(ctx, &interaction); err != nil {
 				span.RecordError(err)
 
 				response := &slack.ViewSubmissionResponse{
@@ -303,7 +317,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 			}
 
 			// Parse the contents of the view and queue UPDATE_MEMBER job
-			if err := bot.UpsertMemberProfileInfo(r.Context(), s.GetDB(), &interaction); err != nil {
+			if err := bot.UpsertMemberProfileInfo(ctx, s.GetDB(), &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to upsert Slack member's profile info", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -311,7 +325,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 			}
 
 			// Respond to the HTTP request with the new view
-			body, err := bot.RenderOnboardingCalendlyView(r.Context(), &interaction, s.GetBaseURL())
+			body, err := bot.RenderOnboardingCalendlyView(ctx, &interaction, s.GetBaseURL())
 			if err != nil {
 				span.RecordError(err)
 				logger.Error("failed to load onboarding profile template", "error", err)
@@ -330,7 +344,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 			if calendlyLink != "" {
 				// Validate the Slack user's calendly link
-				if err := bot.ValidateMemberCalendlyLink(r.Context(), calendlyLink); err != nil {
+				if err := bot.ValidateMemberCalendlyLink(ctx, calendlyLink); err != nil {
 					span.RecordError(err)
 
 					response := &slack.ViewSubmissionResponse{
@@ -351,7 +365,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 				}
 
 				// Parse the contents of the view and queue UPDATE_MEMBER job
-				if err := bot.UpsertMemberCalendlyLink(r.Context(), s.GetDB(), &interaction); err != nil {
+				if err := bot.UpsertMemberCalendlyLink(ctx, s.GetDB(), &interaction); err != nil {
 					span.RecordError(err)
 					logger.Error("failed to upsert Slack member's Calendly link", "error", err)
 					w.WriteHeader(http.StatusInternalServerError)
@@ -360,7 +374,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 			}
 
 			// Mark the user as active since they have completed onboarding
-			if err := bot.SetMemberIsActive(r.Context(), s.GetDB(), &interaction); err != nil {
+			if err := bot.SetMemberIsActive(ctx, s.GetDB(), &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to upsert Slack member's profile info", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -376,7 +390,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 				return
 			}
 
-			if err := bot.QueueCreateMatchJob(r.Context(), s.GetDB(), &bot.CreateMatchParams{
+			if err := bot.QueueCreateMatchJob(ctx, s.GetDB(), &bot.CreateMatchParams{
 				ChannelID:   channelID,
 				Participant: interaction.User.ID,
 			}); err != nil {
@@ -387,7 +401,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 			}
 
 			// Update the original GREET_MEMBER message to remove the Opt-In button
-			if err := bot.RespondGreetMemberWebhook(r.Context(), s.GetHTTPClient(), &interaction); err != nil {
+			if err := bot.RespondGreetMemberWebhook(ctx, s.GetHTTPClient(), &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to respond to GREET_MEMBER webhook", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -402,7 +416,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 		case "block-member-modal":
 			// Respond to the HTTP request with the new view
-			if err := bot.UpsertMemberBlockList(r.Context(), s.GetDB(), &interaction); err != nil {
+			if err := bot.UpsertMemberBlockList(ctx, s.GetDB(), &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to upsert block list for member", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -415,7 +429,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 		case "unblock-member-modal":
 			// Respond to the HTTP request with the new view
-			if err := bot.UpsertMemberUnblockList(r.Context(), s.GetDB(), &interaction); err != nil {
+			if err := bot.UpsertMemberUnblockList(ctx, s.GetDB(), &interaction); err != nil {
 				span.RecordError(err)
 				logger.Error("failed to upsert unblock list for member", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -450,10 +464,18 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 				return
 			}
 
+			client, err := s.GetTeamSlackClient(ctx, enterpriseID, teamID)
+			if err != nil {
+				span.RecordError(err)
+				logger.Error("failed to retrieve the Slack client of the workspace", "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
 			switch jobType { //nolint:gocritic
 			case models.JobTypeGreetAdmin:
 				// Handle GREET_ADMIN button
-				if err := bot.HandleGreetAdminButton(r.Context(), s.GetSlackClient(), &interaction); err != nil {
+				if err := bot.HandleGreetAdminButton(ctx, client, &interaction); err != nil {
 					span.RecordError(err)
 					logger.Error("failed to handle greet admin button", "error", err)
 					w.WriteHeader(http.StatusInternalServerError)
@@ -462,7 +484,7 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 			case models.JobTypeGreetMember:
 				// Handle GREET_MEMBER button
-				if err := bot.HandleGreetMemberButton(r.Context(), s.GetSlackClient(), &interaction); err != nil {
+				if err := bot.HandleGreetMemberButton(ctx, s.GetDB(), client, &interaction); err != nil {
 					span.RecordError(err)
 					logger.Error("failed to handle greet member button", "error", err)
 					w.WriteHeader(http.StatusInternalServerError)
@@ -471,25 +493,43 @@ func (s *implServer) slackInteractionHandler(w http.ResponseWriter, r *http.Requ
 
 			case models.JobTypeCheckPair:
 				// handle CHECK_PAIR buttons
-				if err := bot.HandleCheckPairButtons(r.Context(), s.GetHTTPClient(), s.GetDB(), &interaction); err != nil {
+				if err := bot.HandleCheckPairButtons(ctx, s.GetHTTPClient(), s.GetDB(), &interaction); err != nil {
 					span.RecordError(err)
 					logger.Error("failed to handle check pair button", "error", err)
 					w.WriteHeader(http.StatusInternalServerError)
 					return
 				}
 
+			case models.JobTypeKickoffPair:
+				// handle KICKOFF_PAIR buttons
+				if err := bot.HandleKickoffPairButtons(ctx, s.GetHTTPClient(), s.GetDB(), &interaction); err != nil {
+					span.RecordError(err)
+					logger.Error("failed to handle kickoff pair button", "error", err)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
 			case models.JobTypeBlockMember:
 				// handle BLOCK_MEMBER button
-				if err := bot.HandleBlockMemberButton(r.Context(), s.GetBaseURL(), s.GetSlackClient(), &interaction); err != nil {
+				if err := bot.HandleBlockMemberButton(ctx, s.GetBaseURL(), client, &interaction); err != nil {
 					span.RecordError(err)
 					logger.Error("failed to handle block member button", "error", err)
 					w.WriteHeader(http.StatusInternalServerError)
 					return
 				}
 
+			case models.JobTypeUpdateMember:
+				// handle UPDATE_MEMBER buttons in the App Home
+				if err := bot.HandleAppHomeButtons(ctx, s.GetDB(), &interaction); err != nil {
+					span.RecordError(err)
+					logger.Error("failed to handle App Home button", "error", err)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
 			case models.JobTypeUnblockMember:
 				// handle UNBLOCK_MEMBER button
-				if err := bot.HandleUnblockMemberButton(r.Context(), s.GetBaseURL(), s.GetDB(), s.GetSlackClient(), &interaction); err != nil {
+				if err := bot.HandleUnblockMemberButton(ctx, s.GetBaseURL(), s.GetDB(), client, &interaction); err != nil {
 					span.RecordError(err)
 					logger.Error("failed to handle unblock member button", "error", err)
 					w.WriteHeader(http.StatusInternalServerError)
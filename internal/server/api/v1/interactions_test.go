@@ -219,7 +219,7 @@ func Test_slackInteractionHandler_ViewSubmission_OnboardingTimezone(t *testing.T
 
 	db, mock := database.NewMockedGormDB()
 
-	database.MockQueueJob(
+	database.MockQueueJobForTeam(
 		mock,
 		&bot.UpdateMemberParams{
 			ChannelID: channelID,
@@ -228,6 +228,7 @@ func Test_slackInteractionHandler_ViewSubmission_OnboardingTimezone(t *testing.T
 		},
 		models.JobTypeUpdateMember.String(),
 		models.JobPriorityHigh,
+		"T0123456789",
 	)
 
 	mock.ExpectQuery(`SELECT "connection_mode" FROM "channels" WHERE channel_id = \$1`).
@@ -0,0 +1,285 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-hclog"
+	"github.com/segmentio/ksuid"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+)
+
+type deadLetterJobsResponse struct {
+	Jobs []bot.DeadLetterJob `json:"jobs"`
+}
+
+type replayJobRequest struct {
+	// Data is rejected, since the params of a job can only be edited with the dead-letter CLI
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// listDeadLetterJobsHandler lists the jobs that ended FAILED or CANCELED
+// for the Slack channels that the user is the inviter of.
+//
+// HTTP Method: GET
+//
+// HTTP Path: /jobs/dead-letter
+func (s *implServer) listDeadLetterJobsHandler(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	slackUserID, status := s.authenticate(r)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+
+	// Parse the filters from the query string
+	query := r.URL.Query()
+
+	filter := &bot.DeadLetterFilter{
+		ChannelID: query.Get("channel_id"),
+		Inviter:   slackUserID,
+		JobType:   query.Get("job_type"),
+	}
+
+	var err error
+
+	if v := query.Get("job_type"); v != "" {
+		if _, err = models.ParseJobType(v); err != nil {
+			err = fmt.Errorf("job_type: %s", err)
+		}
+	}
+
+	if v := query.Get("since"); v != "" && err == nil {
+		if filter.Since, err = time.Parse(time.RFC3339, v); err != nil {
+			err = fmt.Errorf("since: must be a valid RFC3339 timestamp")
+		}
+	}
+
+	if v := query.Get("until"); v != "" && err == nil {
+		if filter.Until, err = time.Parse(time.RFC3339, v); err != nil {
+			err = fmt.Errorf("until: must be a valid RFC3339 timestamp")
+		}
+	}
+
+	if v := query.Get("limit"); v != "" && err == nil {
+		if filter.Limit, err = strconv.Atoi(v); err != nil || filter.Limit < 1 {
+			err = fmt.Errorf("limit: must be a positive integer")
+		}
+	}
+
+	if err != nil {
+		span.RecordError(err)
+
+		response := ErrResponse{
+			Error: fmt.Sprintf("validation failed: %s", err),
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response) //nolint:errcheck
+		return
+	}
+
+	jobs, err := bot.ListDeadLetterJobs(r.Context(), s.GetDB(), filter)
+	if err != nil {
+		span.RecordError(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(&deadLetterJobsResponse{Jobs: jobs}) //nolint:errcheck
+}
+
+// replayDeadLetterJobHandler returns a job that ended FAILED or CANCELED
+// to the job queue with its original params. Params can only be edited
+// with the dead-letter CLI, since the params of most jobs identify more
+// than the Slack channel, such as the members of a match.
+//
+// HTTP Method: POST
+//
+// HTTP Path: /jobs/dead-letter/{job_id}/replay
+func (s *implServer) replayDeadLetterJobHandler(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	slackUserID, status := s.authenticate(r)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+
+	// Unmarshal the optional request body to JSON
+	var req replayJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		span.RecordError(err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Data) > 0 {
+		response := ErrResponse{
+			Error: "validation failed: data: params can only be edited with the dead-letter CLI",
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response) //nolint:errcheck
+		return
+	}
+
+	job, status := s.authorizeDeadLetterJob(r, slackUserID)
+	if status != http.StatusOK {
+		writeDeadLetterError(w, status)
+		return
+	}
+
+	jobID, _ := ksuid.Parse(job.JobID)
+
+	if err := bot.ReplayDeadLetterJob(r.Context(), s.GetDB(), jobID, nil); err != nil {
+		span.RecordError(err)
+		writeDeadLetterError(w, deadLetterErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// discardDeadLetterJobHandler permanently deletes a job that ended FAILED or CANCELED.
+//
+// HTTP Method: DELETE
+//
+// HTTP Path: /jobs/dead-letter/{job_id}
+func (s *implServer) discardDeadLetterJobHandler(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	slackUserID, status := s.authenticate(r)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+
+	job, status := s.authorizeDeadLetterJob(r, slackUserID)
+	if status != http.StatusOK {
+		writeDeadLetterError(w, status)
+		return
+	}
+
+	jobID, _ := ksuid.Parse(job.JobID)
+
+	if err := bot.DiscardDeadLetterJob(r.Context(), s.GetDB(), jobID); err != nil {
+		span.RecordError(err)
+		writeDeadLetterError(w, deadLetterErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticate verifies that the request is from an authenticated
+// user and returns the Slack user ID of the user.
+func (s *implServer) authenticate(r *http.Request) (string, int) {
+	span := trace.SpanFromContext(r.Context())
+
+	session, err := s.GetSession(r)
+	if err != nil {
+		span.RecordError(err)
+		return "", http.StatusInternalServerError
+	}
+
+	if auth, ok := session.Values["authenticated"].(bool); !ok || !auth {
+		return "", http.StatusUnauthorized
+	}
+
+	slackUserID, ok := session.Values["slack_user_id"].(string)
+	if !ok {
+		return "", http.StatusForbidden
+	}
+
+	return slackUserID, http.StatusOK
+}
+
+// authorizeDeadLetterJob retrieves the dead-lettered job in the request path,
+// verifying that the user is the inviter of the job's Slack channel.
+func (s *implServer) authorizeDeadLetterJob(r *http.Request, slackUserID string) (*bot.DeadLetterJob, int) {
+	logger := hclog.FromContext(r.Context())
+	span := trace.SpanFromContext(r.Context())
+
+	jobID, err := ksuid.Parse(mux.Vars(r)["job_id"])
+	if err != nil {
+		return nil, http.StatusNotFound
+	}
+
+	db := s.GetDB()
+
+	job, err := bot.GetDeadLetterJob(r.Context(), db, jobID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, deadLetterErrorStatus(err)
+	}
+
+	// Jobs that are not for a Slack channel can only be managed using the CLI
+	if job.ChannelID == "" {
+		return nil, http.StatusForbidden
+	}
+
+	dbCtx, cancel := context.WithTimeout(r.Context(), 300*time.Millisecond)
+	defer cancel()
+
+	var inviter string
+	result := db.WithContext(dbCtx).
+		Model(&models.Channel{}).
+		Select("inviter").
+		Where("channel_id = ?", job.ChannelID).
+		First(&inviter)
+
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		message := "failed to retrieve inviter from the database"
+		logger.Error(message, "error", result.Error)
+		return nil, http.StatusInternalServerError
+	}
+
+	if inviter != slackUserID {
+		span.RecordError(ErrAuthzFailed)
+		logger.Error("failed to manage dead-lettered job", "error", "user is not authorized to modify the chat-roulette channel")
+		return nil, http.StatusForbidden
+	}
+
+	return job, http.StatusOK
+}
+
+// deadLetterErrorStatus returns the HTTP status code for an error from managing a dead-lettered job
+func deadLetterErrorStatus(err error) int {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+
+	// The idempotency key of a replayed job must not match a pending job
+	if database.IsUniqueViolation(err) {
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
+// writeDeadLetterError writes the response for a failed request to manage a dead-lettered job
+func writeDeadLetterError(w http.ResponseWriter, status int) {
+	w.WriteHeader(status)
+
+	switch status {
+	case http.StatusForbidden:
+		json.NewEncoder(w).Encode(ErrResponse{Error: ErrAuthzFailed.Error()}) //nolint:errcheck
+	case http.StatusConflict:
+		json.NewEncoder(w).Encode(ErrResponse{Error: ErrDuplicateJob.Error()}) //nolint:errcheck
+	}
+}
@@ -0,0 +1,235 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/sessions"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/server"
+)
+
+type DeadLetterHandlersSuite struct {
+	suite.Suite
+	resource *dockertest.Resource
+	db       *gorm.DB
+	router   *mux.Router
+	response *httptest.ResponseRecorder
+	store    *sessions.CookieStore
+	job      *models.Job
+}
+
+func (s *DeadLetterHandlersSuite) SetupTest() {
+	resource, databaseURL, err := database.NewTestPostgresDB(true)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.resource = resource
+
+	db, err := database.NewGormDB(databaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Write channel to the database
+	db.Create(&models.Channel{
+		ChannelID:      "C0123456789",
+		Inviter:        "U9876543210",
+		ConnectionMode: models.ConnectionModePhysical,
+		Interval:       models.Biweekly,
+		Weekday:        time.Friday,
+		Hour:           12,
+		NextRound:      time.Now().Add(24 * time.Hour),
+	})
+
+	// Write a failed job to the database
+	s.job = models.NewJob(models.JobTypeCreateRound, []byte(`{"channel_id":"C0123456789","interval":"fortnightly"}`))
+	s.job.Status = models.JobStatusFailed
+	s.job.IsCompleted = true
+	db.Create(s.job)
+
+	db.Create(&models.JobAttempt{
+		JobID:   s.job.ID,
+		Attempt: 1,
+		Error:   "invalid interval",
+	})
+
+	s.db = db
+
+	key, _ := hex.DecodeString("8c4faf836e29d282f2dc7ffdf4ef59c6081e2d8964ba0ac9cd4bc8800021300c")
+
+	s.store = sessions.NewCookieStore(key)
+
+	opts := &server.ServerOptions{
+		SessionsStore: s.store,
+		DB:            db,
+	}
+
+	srv := &implServer{server.NewTestServer(opts)}
+
+	s.router = mux.NewRouter()
+	s.router.HandleFunc("/v1/jobs/dead-letter", srv.listDeadLetterJobsHandler).Methods(http.MethodGet)
+	s.router.HandleFunc("/v1/jobs/dead-letter/{job_id}/replay", srv.replayDeadLetterJobHandler).Methods(http.MethodPost)
+	s.router.HandleFunc("/v1/jobs/dead-letter/{job_id}", srv.discardDeadLetterJobHandler).Methods(http.MethodDelete)
+
+	s.response = httptest.NewRecorder()
+}
+
+func (s *DeadLetterHandlersSuite) AfterTest(_, _ string) {
+	s.resource.Close()
+}
+
+func (s *DeadLetterHandlersSuite) newRequest(method, path string, body []byte, userID string) *http.Request {
+	request, _ := http.NewRequest(method, path, bytes.NewReader(body))
+
+	session, err := s.store.Get(request, server.SessionKey)
+	require.NoError(s.T(), err)
+	session.Values["authenticated"] = true
+	session.Values["slack_user_id"] = userID
+	session.Save(request, s.response)
+
+	return request
+}
+
+func (s *DeadLetterHandlersSuite) Test_List_Unauthenticated() {
+	r := require.New(s.T())
+
+	request, _ := http.NewRequest(http.MethodGet, "/v1/jobs/dead-letter", nil)
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusUnauthorized, s.response.Code)
+}
+
+func (s *DeadLetterHandlersSuite) Test_List() {
+	r := require.New(s.T())
+
+	request := s.newRequest(http.MethodGet, "/v1/jobs/dead-letter?job_type=CREATE_ROUND", nil, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusOK, s.response.Code)
+
+	var response deadLetterJobsResponse
+	r.NoError(json.NewDecoder(s.response.Body).Decode(&response))
+	r.Len(response.Jobs, 1)
+	r.Equal(s.job.JobID.String(), response.Jobs[0].JobID)
+	r.Len(response.Jobs[0].History, 1)
+	r.Equal("invalid interval", response.Jobs[0].History[0].Error)
+}
+
+func (s *DeadLetterHandlersSuite) Test_List_OtherUser() {
+	r := require.New(s.T())
+
+	request := s.newRequest(http.MethodGet, "/v1/jobs/dead-letter", nil, "U1111222233")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusOK, s.response.Code)
+
+	var response deadLetterJobsResponse
+	r.NoError(json.NewDecoder(s.response.Body).Decode(&response))
+	r.Len(response.Jobs, 0)
+}
+
+func (s *DeadLetterHandlersSuite) Test_List_Validation() {
+	r := require.New(s.T())
+
+	request := s.newRequest(http.MethodGet, "/v1/jobs/dead-letter?since=yesterday", nil, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusBadRequest, s.response.Code)
+	r.Contains(s.response.Body.String(), "validation failed")
+}
+
+func (s *DeadLetterHandlersSuite) Test_Replay_Unauthorized() {
+	r := require.New(s.T())
+
+	request := s.newRequest(http.MethodPost, "/v1/jobs/dead-letter/"+s.job.JobID.String()+"/replay", nil, "U1111222233")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusForbidden, s.response.Code)
+	r.Contains(s.response.Body.String(), "authorization failed")
+}
+
+func (s *DeadLetterHandlersSuite) Test_Replay_EditedData() {
+	r := require.New(s.T())
+
+	body := []byte(`{"data":{"channel_id":"C0123456789","interval":"weekly"}}`)
+	request := s.newRequest(http.MethodPost, "/v1/jobs/dead-letter/"+s.job.JobID.String()+"/replay", body, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusBadRequest, s.response.Code)
+	r.Contains(s.response.Body.String(), "params can only be edited with the dead-letter CLI")
+}
+
+func (s *DeadLetterHandlersSuite) Test_Replay() {
+	r := require.New(s.T())
+
+	request := s.newRequest(http.MethodPost, "/v1/jobs/dead-letter/"+s.job.JobID.String()+"/replay", nil, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusAccepted, s.response.Code)
+
+	var job models.Job
+	r.NoError(s.db.Where("id = ?", s.job.ID).First(&job).Error)
+	r.Equal(models.JobStatusPending, job.Status)
+	r.False(job.IsCompleted)
+	r.JSONEq(`{"channel_id":"C0123456789","interval":"fortnightly"}`, string(job.Data))
+	r.Equal(0, job.Attempts)
+	r.Equal(1, job.Replays)
+}
+
+func (s *DeadLetterHandlersSuite) Test_Replay_Conflict() {
+	r := require.New(s.T())
+
+	// A pending job with the same idempotency key as the failed job
+	key := models.IdempotencyKey(models.JobTypeCreateRound, "C0123456789")
+	r.NoError(s.db.Model(s.job).Update("idempotency_key", key).Error)
+
+	pending := models.NewJob(models.JobTypeCreateRound, []byte(`{"channel_id":"C0123456789","interval":"weekly"}`))
+	pending.IdempotencyKey = &key
+	r.NoError(s.db.Create(pending).Error)
+
+	request := s.newRequest(http.MethodPost, "/v1/jobs/dead-letter/"+s.job.JobID.String()+"/replay", nil, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusConflict, s.response.Code)
+	r.Contains(s.response.Body.String(), ErrDuplicateJob.Error())
+}
+
+func (s *DeadLetterHandlersSuite) Test_Discard() {
+	r := require.New(s.T())
+
+	request := s.newRequest(http.MethodDelete, "/v1/jobs/dead-letter/"+s.job.JobID.String(), nil, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusNoContent, s.response.Code)
+
+	var count int64
+	r.NoError(s.db.Model(&models.JobAttempt{}).Where("job_id = ?", s.job.ID).Count(&count).Error)
+	r.Equal(int64(0), count)
+}
+
+func Test_deadLetterHandlers_suite(t *testing.T) {
+	suite.Run(t, new(DeadLetterHandlersSuite))
+}
@@ -23,6 +23,7 @@ type updateMemberRequest struct {
 	ChannelID           string `json:"channel_id"`
 	UserID              string `json:"user_id"`
 	ConnectionMode      string `json:"connection_mode,omitempty"`
+	Language            string `json:"language,omitempty"`
 	Country             string `json:"country,omitempty"`
 	City                string `json:"city,omitempty"`
 	Timezone            string `json:"timezone,omitempty"`
@@ -70,6 +71,7 @@ func (s *implServer) updateMemberHandler(w http.ResponseWriter, r *http.Request)
 		validation.Field(&req.ChannelID, validation.Required, is.Alphanumeric),
 		validation.Field(&req.UserID, validation.Required, is.Alphanumeric),
 		validation.Field(&req.ConnectionMode, validation.Required, validation.By(isx.ConnectionMode)),
+		validation.Field(&req.Language, validation.By(isx.Language)),
 		validation.Field(&req.Country, validation.Required, validation.By(isx.Country)),
 		validation.Field(&req.City, validation.Required),
 		validation.Field(&req.Timezone, validation.Required),
@@ -126,6 +128,7 @@ func (s *implServer) updateMemberHandler(w http.ResponseWriter, r *http.Request)
 		ChannelID:           req.ChannelID,
 		UserID:              req.UserID,
 		ConnectionMode:      req.ConnectionMode,
+		Language:            req.Language,
 		IsActive:            req.IsActive,
 		HasGenderPreference: req.HasGenderPreference,
 	}
@@ -168,7 +171,7 @@ func (s *implServer) updateMemberHandler(w http.ResponseWriter, r *http.Request)
 	// Schedule an UPDATE_MEMBER job to update the member's participation status
 	// for chat roulette. bot.UpdateMember() could be directly called here,
 	// however scheduling a background job will ensure it is reliably executed.
-	if err := bot.QueueUpdateMemberJob(r.Context(), s.GetDB(), p); err != nil {
+	if err := bot.QueueUpdateMemberJob(sessionContext(r, session), s.GetDB(), p); err != nil {
 		logger.Error("failed to add job to the queue", "error", err, "job", models.JobTypeUpdateMember.String())
 		span.RecordError(err)
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/chat-roulettte/chat-roulette/internal/iox"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+	"github.com/chat-roulettte/chat-roulette/internal/server"
 	"github.com/chat-roulettte/chat-roulette/internal/tzx"
 )
 
@@ -37,7 +38,7 @@ func (s *implServer) slackOptionsHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if !s.IsDevMode() {
+	if !s.IsDevMode() && !server.IsSocketModeRequest(r) {
 		sv, err := slack.NewSecretsVerifier(r.Header, s.GetSlackSigningSecret())
 		if err != nil {
 			span.RecordError(err)
@@ -0,0 +1,152 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
+)
+
+type messageTemplateRequest struct {
+	ChannelID string `json:"channel_id"`
+	Content   string `json:"content"`
+}
+
+type previewMessageTemplateResponse struct {
+	Preview string `json:"preview"`
+}
+
+// previewMessageTemplateHandler handles rendering an override of a Slack message template
+// for a channel with sample data, without saving it.
+//
+// HTTP Method: POST
+//
+// HTTP Path: /channel/templates/{name}/preview
+func (s *implServer) previewMessageTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	req, slackUserID, ok := s.decodeMessageTemplateRequest(w, r, true)
+	if !ok {
+		return
+	}
+
+	if status := s.authorizeChannel(r, slackUserID, req.ChannelID); status != http.StatusOK {
+		writeAuthzError(w, status)
+		return
+	}
+
+	// Preview in the default language of the channel, ignoring errors
+	lang, _ := bot.ChannelLanguage(r.Context(), s.GetDB(), req.ChannelID)
+
+	preview, err := bot.PreviewMessageTemplate(lang, req.ChannelID, mux.Vars(r)["name"], req.Content)
+	if err != nil {
+		writeMessageTemplateError(w, span, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(&previewMessageTemplateResponse{Preview: preview}) //nolint:errcheck
+}
+
+// saveMessageTemplateHandler handles saving an override of a Slack message template for a channel.
+//
+// HTTP Method: POST
+//
+// HTTP Path: /channel/templates/{name}
+func (s *implServer) saveMessageTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	req, slackUserID, ok := s.decodeMessageTemplateRequest(w, r, true)
+	if !ok {
+		return
+	}
+
+	if status := s.authorizeChannel(r, slackUserID, req.ChannelID); status != http.StatusOK {
+		writeAuthzError(w, status)
+		return
+	}
+
+	if err := bot.SaveMessageTemplate(r.Context(), s.GetDB(), req.ChannelID, mux.Vars(r)["name"], req.Content); err != nil {
+		writeMessageTemplateError(w, span, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// resetMessageTemplateHandler handles deleting the override of a Slack message template
+// for a channel, so that the default template is used again.
+//
+// HTTP Method: DELETE
+//
+// HTTP Path: /channel/templates/{name}
+func (s *implServer) resetMessageTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	req, slackUserID, ok := s.decodeMessageTemplateRequest(w, r, false)
+	if !ok {
+		return
+	}
+
+	if status := s.authorizeChannel(r, slackUserID, req.ChannelID); status != http.StatusOK {
+		writeAuthzError(w, status)
+		return
+	}
+
+	if err := bot.ResetMessageTemplate(r.Context(), s.GetDB(), req.ChannelID, mux.Vars(r)["name"]); err != nil {
+		writeMessageTemplateError(w, span, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeMessageTemplateRequest authenticates, decodes and validates a request for a message template.
+// The response has been written if ok is false.
+func (s *implServer) decodeMessageTemplateRequest(w http.ResponseWriter, r *http.Request, withContent bool) (req *messageTemplateRequest, slackUserID string, ok bool) {
+	span := trace.SpanFromContext(r.Context())
+
+	slackUserID, status := s.authenticate(r)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return nil, "", false
+	}
+
+	// Unmarshal request body to JSON
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		w.WriteHeader(http.StatusBadRequest)
+		return nil, "", false
+	}
+
+	// Validate the request
+	if err := validation.ValidateStruct(req,
+		validation.Field(&req.ChannelID, validation.Required, is.Alphanumeric),
+		validation.Field(&req.Content, validation.When(withContent, validation.Required, validation.Length(1, bot.MaxMessageTemplateLength))),
+	); err != nil {
+		writeValidationError(w, span, err)
+		return nil, "", false
+	}
+
+	return req, slackUserID, true
+}
+
+// writeMessageTemplateError writes the response for a request for a message template that failed
+func writeMessageTemplateError(w http.ResponseWriter, span trace.Span, err error) {
+	switch {
+	case errors.Is(err, bot.ErrUnknownMessageTemplate):
+		span.RecordError(err)
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, bot.ErrInvalidMessageTemplate):
+		writeValidationError(w, span, err)
+	default:
+		span.RecordError(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/sessions"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/server"
+)
+
+const testMessageTemplate = `{"blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": "Howdy <@{{ .Participant }}>!"}}]}`
+
+type MessageTemplatesHandlersSuite struct {
+	suite.Suite
+	resource *dockertest.Resource
+	db       *gorm.DB
+	router   *mux.Router
+	response *httptest.ResponseRecorder
+	store    *sessions.CookieStore
+}
+
+func (s *MessageTemplatesHandlersSuite) SetupTest() {
+	resource, databaseURL, err := database.NewTestPostgresDB(true)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.resource = resource
+
+	db, err := database.NewGormDB(databaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Write channel to the database
+	db.Create(&models.Channel{
+		ChannelID:      "C0123456789",
+		Inviter:        "U9876543210",
+		ConnectionMode: models.ConnectionModePhysical,
+		Interval:       models.Biweekly,
+		Weekday:        time.Friday,
+		Hour:           12,
+		NextRound:      time.Now().Add(24 * time.Hour),
+	})
+
+	s.db = db
+
+	key, _ := hex.DecodeString("8c4faf836e29d282f2dc7ffdf4ef59c6081e2d8964ba0ac9cd4bc8800021300c")
+
+	s.store = sessions.NewCookieStore(key)
+
+	opts := &server.ServerOptions{
+		SessionsStore: s.store,
+		DB:            db,
+	}
+
+	srv := &implServer{server.NewTestServer(opts)}
+
+	s.router = mux.NewRouter()
+	s.router.HandleFunc("/v1/channel/templates/{name}", srv.saveMessageTemplateHandler).Methods(http.MethodPost)
+	s.router.HandleFunc("/v1/channel/templates/{name}", srv.resetMessageTemplateHandler).Methods(http.MethodDelete)
+	s.router.HandleFunc("/v1/channel/templates/{name}/preview", srv.previewMessageTemplateHandler).Methods(http.MethodPost)
+
+	s.response = httptest.NewRecorder()
+}
+
+func (s *MessageTemplatesHandlersSuite) AfterTest(_, _ string) {
+	s.resource.Close()
+}
+
+func (s *MessageTemplatesHandlersSuite) newRequest(method, path string, body interface{}, slackUserID string) *http.Request {
+	b := new(bytes.Buffer)
+	if body != nil {
+		json.NewEncoder(b).Encode(body)
+	}
+
+	request, _ := http.NewRequest(method, path, b)
+
+	session, err := s.store.Get(request, server.SessionKey)
+	s.Require().NoError(err)
+	session.Values["authenticated"] = true
+	session.Values["slack_user_id"] = slackUserID
+	session.Save(request, s.response)
+
+	return request
+}
+
+func (s *MessageTemplatesHandlersSuite) Test_Unauthenticated() {
+	r := require.New(s.T())
+
+	request, _ := http.NewRequest(http.MethodPost, "/v1/channel/templates/check_pair", nil)
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusUnauthorized, s.response.Code)
+}
+
+func (s *MessageTemplatesHandlersSuite) Test_Unauthorized() {
+	r := require.New(s.T())
+
+	body := &messageTemplateRequest{
+		ChannelID: "C0123456789",
+		Content:   testMessageTemplate,
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/templates/check_pair", body, "U1111222233")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusForbidden, s.response.Code)
+	r.Contains(s.response.Body.String(), "authorization failed")
+}
+
+func (s *MessageTemplatesHandlersSuite) Test_Preview() {
+	r := require.New(s.T())
+
+	body := &messageTemplateRequest{
+		ChannelID: "C0123456789",
+		Content:   testMessageTemplate,
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/templates/check_pair/preview", body, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusOK, s.response.Code)
+	r.Contains(s.response.Body.String(), "Howdy <@U0123456789>!")
+
+	var count int64
+	r.NoError(s.db.Model(&models.MessageTemplate{}).Count(&count).Error)
+	r.Zero(count)
+}
+
+func (s *MessageTemplatesHandlersSuite) Test_Save_Invalid() {
+	r := require.New(s.T())
+
+	body := &messageTemplateRequest{
+		ChannelID: "C0123456789",
+		Content:   `{"blocks": [`,
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/templates/check_pair", body, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusBadRequest, s.response.Code)
+	r.Contains(s.response.Body.String(), "invalid message template")
+}
+
+func (s *MessageTemplatesHandlersSuite) Test_Save_UnknownTemplate() {
+	r := require.New(s.T())
+
+	body := &messageTemplateRequest{
+		ChannelID: "C0123456789",
+		Content:   testMessageTemplate,
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/templates/greet_admin", body, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusNotFound, s.response.Code)
+}
+
+func (s *MessageTemplatesHandlersSuite) Test_Save_Reset() {
+	r := require.New(s.T())
+
+	body := &messageTemplateRequest{
+		ChannelID: "C0123456789",
+		Content:   testMessageTemplate,
+	}
+
+	request := s.newRequest(http.MethodPost, "/v1/channel/templates/check_pair", body, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusNoContent, s.response.Code)
+
+	var template models.MessageTemplate
+	r.NoError(s.db.Where("channel_id = ? AND name = ?", "C0123456789", "check_pair").First(&template).Error)
+	r.Equal(testMessageTemplate, template.Content)
+
+	s.response = httptest.NewRecorder()
+
+	request = s.newRequest(http.MethodDelete, "/v1/channel/templates/check_pair", &messageTemplateRequest{ChannelID: "C0123456789"}, "U9876543210")
+
+	s.router.ServeHTTP(s.response, request)
+
+	r.Equal(http.StatusNoContent, s.response.Code)
+
+	var count int64
+	r.NoError(s.db.Model(&models.MessageTemplate{}).Count(&count).Error)
+	r.Zero(count)
+}
+
+func Test_messageTemplatesHandlers_suite(t *testing.T) {
+	suite.Run(t, new(MessageTemplatesHandlersSuite))
+}
@@ -1,6 +1,12 @@
 package v1
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/sessions"
+
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
 	"github.com/chat-roulettte/chat-roulette/internal/server"
 	"github.com/chat-roulettte/chat-roulette/internal/server/api"
 )
@@ -25,10 +31,30 @@ func RegisterRoutes(s *server.Server) {
 		{Path: "slack/event", Methods: []string{"POST"}, Func: i.slackEventHandler},
 		{Path: "slack/interaction", Methods: []string{"POST"}, Func: i.slackInteractionHandler},
 		{Path: "slack/options", Methods: []string{"POST"}, Func: i.slackOptionsHandler},
+		{Path: "slack/command", Methods: []string{"POST"}, Func: i.slackCommandHandler},
 		{Path: "member", Methods: []string{"POST"}, Func: i.updateMemberHandler},
 		{Path: "channel", Methods: []string{"POST"}, Func: i.updateChannelHandler},
+		{Path: "channel/round", Methods: []string{"POST"}, Func: i.startRoundHandler},
+		{Path: "channel/icebreakers", Methods: []string{"POST"}, Func: i.addIcebreakersHandler},
+		{Path: "channel/icebreakers/import", Methods: []string{"POST"}, Func: i.importIcebreakersHandler},
+		{Path: "channel/icebreakers/{icebreaker_id}", Methods: []string{"DELETE"}, Func: i.deleteIcebreakerHandler},
+		{Path: "channel/templates/{name}", Methods: []string{"POST"}, Func: i.saveMessageTemplateHandler},
+		{Path: "channel/templates/{name}", Methods: []string{"DELETE"}, Func: i.resetMessageTemplateHandler},
+		{Path: "channel/templates/{name}/preview", Methods: []string{"POST"}, Func: i.previewMessageTemplateHandler},
+		{Path: "jobs/dead-letter", Methods: []string{"GET"}, Func: i.listDeadLetterJobsHandler},
+		{Path: "jobs/dead-letter/{job_id}/replay", Methods: []string{"POST"}, Func: i.replayDeadLetterJobHandler},
+		{Path: "jobs/dead-letter/{job_id}", Methods: []string{"DELETE"}, Func: i.discardDeadLetterJobHandler},
 		{Path: "timezones/{country}", Methods: []string{"GET"}, Func: i.timezonesHandler},
 	}
 
 	api.RegisterRoutes(s.GetMux(), RoutesPrefix, routes)
 }
+
+// sessionContext returns the context of the request with the Slack workspace
+// that the user signed in to, so that jobs queued for the request are executed
+// with the Slack client of that workspace.
+func sessionContext(r *http.Request, session *sessions.Session) context.Context {
+	teamID, _ := session.Values["slack_team_id"].(string)
+	enterpriseID, _ := session.Values["slack_enterprise_id"].(string)
+	return bot.ContextWithEnterpriseID(bot.ContextWithTeamID(r.Context(), teamID), enterpriseID)
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/chat-roulettte/chat-roulette/internal/o11y"
+)
+
+// traceLoggerMiddleware annotates the logger in the request context with the
+// trace and span IDs of the request, so that log entries can be correlated with traces
+func traceLoggerMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		logger := o11y.LoggerWithTrace(ctx, hclog.FromContext(ctx))
+
+		next.ServeHTTP(w, r.WithContext(hclog.WithContext(ctx, logger)))
+	})
+}
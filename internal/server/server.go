@@ -4,7 +4,6 @@ import (
 	"context"
 	"net"
 	"net/http"
-	"net/url"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -23,6 +22,7 @@ import (
 	"github.com/chat-roulettte/chat-roulette/internal/bot"
 	"github.com/chat-roulettte/chat-roulette/internal/config"
 	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 	"github.com/chat-roulettte/chat-roulette/internal/slackclient"
 )
 
@@ -48,6 +48,8 @@ type Server struct {
 	sessionStore    *sessions.CookieStore
 	slackBotUserID  string
 	slackClient     *slack.Client
+	slackClients    *slackclient.Clients
+	slackTeamID     string
 }
 
 type ServerOptions struct {
@@ -58,6 +60,8 @@ type ServerOptions struct {
 	HTTPServer     *http.Server
 	SlackBotUserID string
 	SlackClient    *slack.Client
+	SlackClients   *slackclient.Clients
+	SlackTeamID    string
 	SessionsStore  *sessions.CookieStore
 }
 
@@ -76,7 +80,7 @@ func New(ctx context.Context, logger hclog.Logger, c *config.Config) (*Server, e
 
 	// Create HTTP server with tracing
 	r := mux.NewRouter()
-	r.Use(otelmux.Middleware("chat-roulette-server"))
+	r.Use(otelmux.Middleware("chat-roulette-server"), traceLoggerMiddleware)
 
 	httpServer := &http.Server{
 		Addr:         c.GetAddr(),
@@ -89,23 +93,45 @@ func New(ctx context.Context, logger hclog.Logger, c *config.Config) (*Server, e
 		},
 	}
 
-	// Create Slack client
-	slackClient, httpClient := slackclient.New(logger, c.Bot.AuthToken)
+	// Create Slack client. The app-level token is used to open the Socket Mode connection.
+	var slackOpts []slack.Option
+	if c.Bot.SocketMode {
+		slackOpts = append(slackOpts, slack.OptionAppLevelToken(c.Bot.AppToken))
+	}
 
-	// Retrieve the user_id of the chat-roulette Slack bot
-	logger.Debug("retrieving the user ID of the chat-roulette Slack bot")
+	slackClient, httpClient := slackclient.New(logger, c.Bot.AuthToken, slackOpts...)
 
-	slackCtx, cancel := context.WithTimeout(ctx, 3000*time.Millisecond)
-	defer cancel()
+	// Retrieve the user_id of the chat-roulette Slack bot and the Slack workspace of the
+	// bot token in the config. There is no bot token if the app is only installed in
+	// Slack workspaces with the OAuth install flow.
+	var slackBotUserID, slackTeamID string
+	var fallbackClient *slack.Client
 
-	slackBotUserID, err := bot.GetBotUserID(slackCtx, slackClient)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to retrieve the user ID of the chat-roulette Slack bot")
+	if c.Bot.AuthToken != "" {
+		logger.Debug("retrieving the user ID of the chat-roulette Slack bot")
+
+		slackCtx, cancel := context.WithTimeout(ctx, 3000*time.Millisecond)
+		defer cancel()
+
+		resp, err := slackClient.AuthTestContext(slackCtx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to retrieve the user ID of the chat-roulette Slack bot")
+		}
+
+		slackBotUserID = resp.UserID
+		slackTeamID = resp.TeamID
+		fallbackClient = slackClient
+
+		logger.Debug("retrieved the user ID of the chat-roulette Slack bot", "slack_bot_user_id", slackBotUserID)
+		span.SetAttributes(
+			attribute.String("slack_bot_user_id", slackBotUserID),
+			attribute.String(attributes.SlackTeamID, slackTeamID),
+		)
 	}
-	logger.Debug("retrieved the user ID of the chat-roulette Slack bot", "slack_bot_user_id", slackBotUserID)
-	span.SetAttributes(
-		attribute.String("slack_bot_user_id", slackBotUserID),
-	)
+
+	// Requests from Slack workspaces that the app was installed in with
+	// the OAuth install flow use the bot token stored in the database
+	slackClients := slackclient.NewClients(logger, fallbackClient, bot.SlackInstallations(db))
 
 	// Discover the OpenID Connect provider configuration for Slack
 	logger.Debug("discovering Slack OIDC provider")
@@ -165,6 +191,8 @@ func New(ctx context.Context, logger hclog.Logger, c *config.Config) (*Server, e
 		sessionStore:    store,
 		slackBotUserID:  slackBotUserID,
 		slackClient:     slackClient,
+		slackClients:    slackClients,
+		slackTeamID:     slackTeamID,
 	}
 
 	return s, nil
@@ -184,6 +212,8 @@ func NewTestServer(opts *ServerOptions) *Server {
 		httpServer:     opts.HTTPServer,
 		slackBotUserID: opts.SlackBotUserID,
 		slackClient:    opts.SlackClient,
+		slackClients:   opts.SlackClients,
+		slackTeamID:    opts.SlackTeamID,
 		sessionStore:   opts.SessionsStore,
 	}
 
@@ -220,6 +250,51 @@ func (s *Server) GetSlackClient() *slack.Client {
 	return s.slackClient
 }
 
+// GetSlackClients retrieves the Slack clients for each Slack workspace
+func (s *Server) GetSlackClients() *slackclient.Clients {
+	return s.slackClients
+}
+
+// GetTeamSlackClient retrieves the Slack client for a Slack workspace.
+// The enterprise ID is the ID of the Enterprise Grid org of the workspace, and may be empty.
+func (s *Server) GetTeamSlackClient(ctx context.Context, enterpriseID, teamID string) (*slack.Client, error) {
+	if s.slackClients == nil || (s.slackTeamID != "" && teamID == s.slackTeamID) {
+		return s.slackClient, nil
+	}
+
+	return s.slackClients.Client(ctx, enterpriseID, teamID)
+}
+
+// GetTeamSlackBotUserID returns the user_id of the chat roulette Slack bot in a Slack workspace
+func (s *Server) GetTeamSlackBotUserID(ctx context.Context, enterpriseID, teamID string) (string, error) {
+	if s.slackClients == nil || (s.slackTeamID != "" && teamID == s.slackTeamID) {
+		return s.slackBotUserID, nil
+	}
+
+	return s.slackClients.BotUserID(ctx, enterpriseID, teamID)
+}
+
+// GetSlackTeamID returns the ID of the Slack workspace of the bot token in the config
+func (s *Server) GetSlackTeamID() string {
+	return s.slackTeamID
+}
+
+// IsDistributed returns true if the app can be installed in
+// multiple Slack workspaces with the OAuth install flow
+func (s *Server) IsDistributed() bool {
+	return s.config != nil && s.config.Bot.Distributed
+}
+
+// GetSlackClientID retrieves the Slack client ID from the config
+func (s *Server) GetSlackClientID() string {
+	return s.config.Server.ClientID
+}
+
+// GetSlackClientSecret retrieves the Slack client secret from the config
+func (s *Server) GetSlackClientSecret() string {
+	return s.config.Server.ClientSecret
+}
+
 // GetSlackSigningSecret retrieves the Slack signing secret from the config
 func (s *Server) GetSlackSigningSecret() string {
 	return s.config.Server.SigningSecret
@@ -232,9 +307,7 @@ func (s *Server) GetSlackBotUserID() string {
 
 // GetBaseURL returns the base URL of the server
 func (s *Server) GetBaseURL() string {
-	u, _ := url.Parse(s.config.Server.RedirectURL)
-	u.Path = ""
-	return u.String()
+	return s.config.Server.GetBaseURL()
 }
 
 // GenerateAuthCodeURL generates the OIDC URL for Single Sign On with Slack
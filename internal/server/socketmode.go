@@ -0,0 +1,195 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+)
+
+const (
+	// slackEventPath is the path of the route that handles Slack events
+	slackEventPath = "/v1/slack/event"
+
+	// slackInteractionPath is the path of the route that handles Slack interactions
+	slackInteractionPath = "/v1/slack/interaction"
+
+	// slackOptionsPath is the path of the route that handles Slack options requests
+	slackOptionsPath = "/v1/slack/options"
+
+	// slackCommandPath is the path of the route that handles Slack slash commands
+	slackCommandPath = "/v1/slack/command"
+)
+
+// socketModeContextKey is the context key that marks requests received over Socket Mode
+type socketModeContextKey struct{}
+
+// IsSocketModeRequest returns true if the request was received over Slack Socket Mode.
+// These requests are authenticated by the app-level token of the websocket connection,
+// so they are not signed with the signing secret.
+func IsSocketModeRequest(r *http.Request) bool {
+	v, _ := r.Context().Value(socketModeContextKey{}).(bool)
+	return v
+}
+
+// RunSocketMode receives events, interactions, options requests and slash commands from Slack over
+// Socket Mode until the context is canceled. Each request is served by the same
+// handlers as the HTTP routes that Slack sends requests to when not using Socket Mode.
+func (s *Server) RunSocketMode(ctx context.Context) error {
+	logger := hclog.FromContext(ctx).Named("socketmode")
+
+	client := socketmode.New(s.slackClient,
+		socketmode.OptionLog(logger.StandardLogger(&hclog.StandardLoggerOptions{
+			InferLevels: true,
+		})),
+	)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt := <-client.Events:
+				go s.handleSocketModeEvent(ctx, client, evt)
+			}
+		}
+	}()
+
+	logger.Info("connecting to Slack using Socket Mode")
+
+	if err := client.RunContext(ctx); err != nil && ctx.Err() == nil {
+		logger.Error("failed to run Socket Mode", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// socketModeClient acknowledges requests received over Socket Mode
+type socketModeClient interface {
+	Ack(req socketmode.Request, payload ...interface{})
+}
+
+// handleSocketModeEvent handles an event received from the Socket Mode client
+func (s *Server) handleSocketModeEvent(ctx context.Context, client socketModeClient, evt socketmode.Event) {
+	logger := hclog.FromContext(ctx)
+
+	switch evt.Type {
+	case socketmode.EventTypeConnecting:
+		logger.Info("connecting to Slack with Socket Mode")
+
+	case socketmode.EventTypeConnected:
+		logger.Info("connected to Slack with Socket Mode")
+
+	case socketmode.EventTypeConnectionError:
+		logger.Warn("failed to connect to Slack with Socket Mode, retrying")
+
+	case socketmode.EventTypeInvalidAuth:
+		logger.Error("failed to authenticate with Slack using the app-level token")
+
+	case socketmode.EventTypeEventsAPI:
+		s.forwardSocketModeRequest(ctx, client, evt.Request, slackEventPath, "application/json", evt.Request.Payload)
+
+	case socketmode.EventTypeInteractive:
+		// Options for external select menus are requested with a block_suggestion
+		// interaction, which Slack sends to a different URL when not using Socket Mode
+		var interaction struct {
+			Type slack.InteractionType `json:"type"`
+		}
+
+		if err := json.Unmarshal(evt.Request.Payload, &interaction); err != nil {
+			logger.Error("failed to unmarshal Slack interaction", "error", err)
+			return
+		}
+
+		path := slackInteractionPath
+		if interaction.Type == slack.InteractionTypeBlockSuggestion {
+			path = slackOptionsPath
+		}
+
+		body := url.Values{"payload": {string(evt.Request.Payload)}}.Encode()
+		s.forwardSocketModeRequest(ctx, client, evt.Request, path, "application/x-www-form-urlencoded", []byte(body))
+
+	case socketmode.EventTypeSlashCommand:
+		// Slash commands are sent as JSON over Socket Mode, instead of the form
+		// that Slack sends to the URL of the command when not using Socket Mode
+		var fields map[string]interface{}
+
+		if err := json.Unmarshal(evt.Request.Payload, &fields); err != nil {
+			logger.Error("failed to unmarshal Slack slash command", "error", err)
+			return
+		}
+
+		form := url.Values{}
+		for k, v := range fields {
+			form.Set(k, fmt.Sprint(v))
+		}
+
+		s.forwardSocketModeRequest(ctx, client, evt.Request, slackCommandPath, "application/x-www-form-urlencoded", []byte(form.Encode()))
+	}
+}
+
+// forwardSocketModeRequest serves a request received over Socket Mode with the handler of the
+// HTTP route. The request is acknowledged with the response of the handler, unless the handler
+// failed with a server error, in which case Slack retries the request.
+func (s *Server) forwardSocketModeRequest(ctx context.Context, client socketModeClient, req *socketmode.Request, path, contentType string, body []byte) {
+	logger := hclog.FromContext(ctx)
+
+	r, err := http.NewRequestWithContext(context.WithValue(ctx, socketModeContextKey{}, true), http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		logger.Error("failed to create request for Socket Mode", "error", err)
+		return
+	}
+	r.Header.Set("Content-Type", contentType)
+
+	w := newSocketModeResponseWriter()
+	s.mux.ServeHTTP(w, r)
+
+	if w.status >= http.StatusInternalServerError {
+		logger.Warn("failed to handle request received over Socket Mode", "path", path, "status", w.status)
+		return
+	}
+
+	// Only JSON responses, such as options, view errors or command responses, are sent back to Slack
+	if w.body.Len() > 0 && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		client.Ack(*req, json.RawMessage(w.body.Bytes()))
+		return
+	}
+
+	client.Ack(*req)
+}
+
+// socketModeResponseWriter is a http.ResponseWriter that
+// captures the response for a request received over Socket Mode
+type socketModeResponseWriter struct {
+	header http.Header
+	status int
+	body   *bytes.Buffer
+}
+
+func newSocketModeResponseWriter() *socketModeResponseWriter {
+	return &socketModeResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+		body:   new(bytes.Buffer),
+	}
+}
+
+func (w *socketModeResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *socketModeResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *socketModeResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
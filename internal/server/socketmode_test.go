@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-hclog"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSocketModeClient struct {
+	acks     []socketmode.Request
+	payloads []interface{}
+}
+
+func (m *mockSocketModeClient) Ack(req socketmode.Request, payload ...interface{}) {
+	m.acks = append(m.acks, req)
+	m.payloads = append(m.payloads, payload...)
+}
+
+func Test_handleSocketModeEvent(t *testing.T) {
+	ctx := hclog.WithContext(context.Background(), hclog.NewNullLogger())
+
+	var received *http.Request
+	var receivedBody string
+
+	r := mux.NewRouter()
+	r.HandleFunc(slackEventPath, func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		b, _ := io.ReadAll(r.Body)
+		receivedBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	})
+	r.HandleFunc(slackInteractionPath, func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody = r.PostFormValue("payload")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	r.HandleFunc(slackOptionsPath, func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody = r.PostFormValue("payload")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"options":[]}`)) //nolint:errcheck
+	})
+
+	r.HandleFunc(slackCommandPath, func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody = r.PostFormValue("text")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"response_type":"ephemeral","text":"ok"}`)) //nolint:errcheck
+	})
+
+	s := &Server{mux: r}
+
+	t.Run("event", func(t *testing.T) {
+		client := &mockSocketModeClient{}
+		payload := `{"type":"event_callback","event":{"type":"member_joined_channel"}}`
+
+		s.handleSocketModeEvent(ctx, client, socketmode.Event{
+			Type: socketmode.EventTypeEventsAPI,
+			Request: &socketmode.Request{
+				EnvelopeID: "1",
+				Payload:    json.RawMessage(payload),
+			},
+		})
+
+		require.NotNil(t, received)
+		assert.True(t, IsSocketModeRequest(received))
+		assert.Equal(t, payload, receivedBody)
+		require.Len(t, client.acks, 1)
+		assert.Equal(t, "1", client.acks[0].EnvelopeID)
+		assert.Empty(t, client.payloads)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		client := &mockSocketModeClient{}
+		payload := `{"type":"block_suggestion","action_id":"country"}`
+
+		s.handleSocketModeEvent(ctx, client, socketmode.Event{
+			Type: socketmode.EventTypeInteractive,
+			Request: &socketmode.Request{
+				EnvelopeID: "2",
+				Payload:    json.RawMessage(payload),
+			},
+		})
+
+		assert.Equal(t, slackOptionsPath, received.URL.Path)
+		assert.Equal(t, payload, receivedBody)
+		require.Len(t, client.acks, 1)
+		require.Len(t, client.payloads, 1)
+		assert.JSONEq(t, `{"options":[]}`, string(client.payloads[0].(json.RawMessage)))
+	})
+
+	t.Run("slash command", func(t *testing.T) {
+		client := &mockSocketModeClient{}
+		payload := `{"command":"/roulette","text":"status","user_id":"U0123456789","channel_id":"C0123456789"}`
+
+		s.handleSocketModeEvent(ctx, client, socketmode.Event{
+			Type: socketmode.EventTypeSlashCommand,
+			Request: &socketmode.Request{
+				EnvelopeID: "4",
+				Payload:    json.RawMessage(payload),
+			},
+		})
+
+		assert.Equal(t, slackCommandPath, received.URL.Path)
+		assert.Equal(t, "status", receivedBody)
+		assert.Equal(t, "/roulette", received.PostFormValue("command"))
+		require.Len(t, client.acks, 1)
+		require.Len(t, client.payloads, 1)
+		assert.JSONEq(t, `{"response_type":"ephemeral","text":"ok"}`, string(client.payloads[0].(json.RawMessage)))
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		client := &mockSocketModeClient{}
+
+		s.handleSocketModeEvent(ctx, client, socketmode.Event{
+			Type: socketmode.EventTypeInteractive,
+			Request: &socketmode.Request{
+				EnvelopeID: "3",
+				Payload:    json.RawMessage(`{"type":"view_submission"}`),
+			},
+		})
+
+		assert.Equal(t, slackInteractionPath, received.URL.Path)
+		assert.Empty(t, client.acks)
+	})
+}
+
+func Test_IsSocketModeRequest(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodPost, slackEventPath, nil)
+	assert.False(t, IsSocketModeRequest(r))
+}
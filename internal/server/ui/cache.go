@@ -7,6 +7,8 @@ import (
 
 	"github.com/dgraph-io/ristretto"
 	"github.com/slack-go/slack"
+
+	"github.com/chat-roulettte/chat-roulette/internal/slackclient"
 )
 
 const (
@@ -20,8 +22,10 @@ var (
 
 // lookupSlackWorkspace looks up info on the Slack workspace.
 // It tries the cache first, before hitting the Slack API.
-func lookupSlackWorkspace(ctx context.Context, cache *ristretto.Cache, client *slack.Client) (*slack.TeamInfo, error) {
-	v, ok := cache.Get(CacheKeySlackTeamInfo)
+func lookupSlackWorkspace(ctx context.Context, cache *ristretto.Cache, client *slack.Client, teamID string) (*slack.TeamInfo, error) {
+	key := CacheKeySlackTeamInfo + ":" + teamID
+
+	v, ok := cache.Get(key)
 	if ok {
 		t, ok := v.(*slack.TeamInfo)
 		if !ok {
@@ -39,7 +43,7 @@ func lookupSlackWorkspace(ctx context.Context, cache *ristretto.Cache, client *s
 	}
 
 	// Cache for 8 hours
-	cache.SetWithTTL(CacheKeySlackTeamInfo, teamInfo, 1, 4*time.Hour)
+	cache.SetWithTTL(key, teamInfo, 1, 4*time.Hour)
 
 	return teamInfo, nil
 }
@@ -91,7 +95,10 @@ func lookupSlackUser(ctx context.Context, cache *ristretto.Cache, client *slack.
 	defer cancel()
 
 	user, err := client.GetUserInfoContext(ctx, userID)
-	if err != nil {
+	if slackclient.IsRestrictedUser(err) {
+		// External members of Slack Connect channels may not be visible
+		user = externalSlackUser(userID)
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -100,3 +107,16 @@ func lookupSlackUser(ctx context.Context, cache *ristretto.Cache, client *slack.
 
 	return user, nil
 }
+
+// externalSlackUser returns a placeholder for a Slack user from another
+// organization whose profile cannot be looked up.
+func externalSlackUser(userID string) *slack.User {
+	return &slack.User{
+		ID:   userID,
+		Name: userID,
+		Profile: slack.UserProfile{
+			RealName:    "External user",
+			DisplayName: "External user",
+		},
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 )
@@ -23,6 +24,10 @@ type channelAdminParams struct {
 	ChannelName string
 	Channel     *models.Channel
 	MinDate     time.Time
+	Icebreakers []models.Icebreaker
+	Categories  []string
+	Rankings    []bot.IcebreakerRanking
+	Templates   []bot.MessageTemplate
 }
 
 // channelAdminHandler for the channel admin page
@@ -38,7 +43,6 @@ func (s *implServer) channelAdminHandler(w http.ResponseWriter, r *http.Request)
 	span := trace.SpanFromContext(r.Context())
 
 	cache := s.GetCache()
-	slackClient := s.GetSlackClient()
 
 	session, err := s.GetSession(r)
 	if err != nil {
@@ -53,22 +57,33 @@ func (s *implServer) channelAdminHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	slackUserID := session.Values["slack_user_id"].(string) //nolint:errcheck
+	slackTeamID, _ := session.Values["slack_team_id"].(string)
+	slackEnterpriseID, _ := session.Values["slack_enterprise_id"].(string)
+
+	// Use the Slack client of the user's workspace
+	slackClient, err := s.GetTeamSlackClient(r.Context(), slackEnterpriseID, slackTeamID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to retrieve the Slack client of the workspace", "error", err, attributes.SlackTeamID, slackTeamID)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
+		return
+	}
 
 	// Retrieve the info for the Slack user
 	slackUser, err := lookupSlackUser(r.Context(), cache, slackClient, slackUserID)
 	if err != nil {
 		span.RecordError(err)
 		logger.Error("failed to lookup Slack user", "error", err, attributes.SlackUserID, slackUserID)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
 	// Retrieve the info for the Slack workspace
-	teamInfo, err := lookupSlackWorkspace(r.Context(), cache, slackClient)
+	teamInfo, err := lookupSlackWorkspace(r.Context(), cache, slackClient, slackTeamID)
 	if err != nil {
 		span.RecordError(err)
 		logger.Error("failed to lookup Slack workspace", "error", err)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
@@ -108,6 +123,41 @@ func (s *implServer) channelAdminHandler(w http.ResponseWriter, r *http.Request)
 		channelName = slackChannel.Name
 	}
 
+	// Retrieve the custom icebreakers and the icebreaker categories for the Slack channel
+	icebreakers, err := bot.ListIcebreakers(r.Context(), db, channelID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to retrieve icebreakers", "error", err)
+		http.Redirect(w, r, "/500", http.StatusFound)
+		return
+	}
+
+	categories, err := bot.ListIcebreakerCategories(r.Context(), db, channelID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to retrieve icebreaker categories", "error", err)
+		http.Redirect(w, r, "/500", http.StatusFound)
+		return
+	}
+
+	// Rank the icebreakers shared with pairs in the Slack channel
+	rankings, err := bot.RankIcebreakers(r.Context(), db, channelID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to rank icebreakers", "error", err)
+		http.Redirect(w, r, "/500", http.StatusFound)
+		return
+	}
+
+	// Retrieve the customizable message templates for the Slack channel
+	templates, err := bot.ListMessageTemplates(r.Context(), db, channelID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to retrieve message templates", "error", err)
+		http.Redirect(w, r, "/500", http.StatusFound)
+		return
+	}
+
 	// Render the template
 	p := channelAdminParams{
 		ID:          slackUserID,
@@ -118,8 +168,12 @@ func (s *implServer) channelAdminHandler(w http.ResponseWriter, r *http.Request)
 		Channel:     &channel,
 		ChannelName: channelName,
 		MinDate:     time.Now().Add(-(24 * time.Hour)),
+		Icebreakers: icebreakers,
+		Categories:  categories,
+		Rankings:    rankings,
+		Templates:   templates,
 	}
 
 	w.Header().Set("Cache-Control", "no-cache")
-	rend.HTML(w, http.StatusOK, "channel", p)
+	s.rend(r).HTML(w, http.StatusOK, "channel", p)
 }
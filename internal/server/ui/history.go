@@ -58,13 +58,12 @@ func (s *implServer) historyHandler(w http.ResponseWriter, r *http.Request) {
 	logger := hclog.FromContext(r.Context()).With(attributes.SlackChannelID, channelID)
 	span := trace.SpanFromContext(r.Context())
 	cache := s.GetCache()
-	slackClient := s.GetSlackClient()
 
 	// Verify authentication
 	session, err := s.GetSession(r)
 	if err != nil {
 		span.RecordError(err)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
@@ -74,22 +73,33 @@ func (s *implServer) historyHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	slackUserID := session.Values["slack_user_id"].(string) //nolint:errcheck
+	slackTeamID, _ := session.Values["slack_team_id"].(string)
+	slackEnterpriseID, _ := session.Values["slack_enterprise_id"].(string)
+
+	// Use the Slack client of the user's workspace
+	slackClient, err := s.GetTeamSlackClient(r.Context(), slackEnterpriseID, slackTeamID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to retrieve the Slack client of the workspace", "error", err, attributes.SlackTeamID, slackTeamID)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
+		return
+	}
 
 	// Retrieve the info for the Slack user
 	slackUser, err := lookupSlackUser(r.Context(), cache, slackClient, slackUserID)
 	if err != nil {
 		span.RecordError(err)
 		logger.Error("failed to lookup Slack user", "error", err, attributes.SlackUserID, slackUserID)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
 	// Retrieve the info for the Slack workspace
-	teamInfo, err := lookupSlackWorkspace(r.Context(), cache, slackClient)
+	teamInfo, err := lookupSlackWorkspace(r.Context(), cache, slackClient, slackTeamID)
 	if err != nil {
 		span.RecordError(err)
 		logger.Error("failed to lookup Slack workspace", "error", err)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
@@ -126,7 +136,7 @@ func (s *implServer) historyHandler(w http.ResponseWriter, r *http.Request) {
 	if result.Error != nil {
 		span.RecordError(result.Error)
 		logger.Error("failed to lookup Slack user's chat-roulette history", "error", result.Error)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
@@ -186,7 +196,7 @@ func (s *implServer) historyHandler(w http.ResponseWriter, r *http.Request) {
 	if errCount > 0 && errCount == len(history) {
 		span.RecordError(fmt.Errorf("failed to lookup Slack channels or users"))
 		logger.Error("failed to lookup Slack channels or users", "error", "too many errors calling Slack")
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
@@ -202,7 +212,7 @@ func (s *implServer) historyHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Cache-Control", "no-cache")
-	rend.HTML(w, http.StatusOK, "history", p)
+	s.rend(r).HTML(w, http.StatusOK, "history", p)
 }
 
 // generateSlackLink generates a deep link to a Slack user's profile
@@ -10,7 +10,9 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/chat-roulettte/chat-roulette/internal/bot"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 	"github.com/chat-roulettte/chat-roulette/internal/tzx"
 )
@@ -24,6 +26,7 @@ type memberProfileParams struct {
 	Workspace   string
 	Channel     string
 	Member      *models.Member
+	Language    i18n.Language
 	Countries   []tz.Country
 	Zones       []tz.Zone
 }
@@ -40,7 +43,6 @@ func (s *implServer) memberProfileHandler(w http.ResponseWriter, r *http.Request
 	logger := hclog.FromContext(r.Context()).With(attributes.SlackChannelID, channelID)
 	span := trace.SpanFromContext(r.Context())
 	cache := s.GetCache()
-	slackClient := s.GetSlackClient()
 
 	session, err := s.GetSession(r)
 	if err != nil {
@@ -55,22 +57,33 @@ func (s *implServer) memberProfileHandler(w http.ResponseWriter, r *http.Request
 	}
 
 	slackUserID := session.Values["slack_user_id"].(string) //nolint:errcheck
+	slackTeamID, _ := session.Values["slack_team_id"].(string)
+	slackEnterpriseID, _ := session.Values["slack_enterprise_id"].(string)
+
+	// Use the Slack client of the user's workspace
+	slackClient, err := s.GetTeamSlackClient(r.Context(), slackEnterpriseID, slackTeamID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to retrieve the Slack client of the workspace", "error", err, attributes.SlackTeamID, slackTeamID)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
+		return
+	}
 
 	// Retrieve the info for the Slack user
 	slackUser, err := lookupSlackUser(r.Context(), cache, slackClient, slackUserID)
 	if err != nil {
 		span.RecordError(err)
 		logger.Error("failed to lookup Slack user", "error", err, attributes.SlackUserID, slackUserID)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
 	// Retrieve the info for the Slack workspace
-	teamInfo, err := lookupSlackWorkspace(r.Context(), cache, slackClient)
+	teamInfo, err := lookupSlackWorkspace(r.Context(), cache, slackClient, slackTeamID)
 	if err != nil {
 		span.RecordError(err)
 		logger.Error("failed to lookup Slack workspace", "error", err)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
@@ -101,7 +114,7 @@ func (s *implServer) memberProfileHandler(w http.ResponseWriter, r *http.Request
 	if result.Error != nil {
 		span.RecordError(result.Error)
 		logger.Error("failed to retrieve user from the database", "error", result.Error)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
@@ -109,12 +122,15 @@ func (s *implServer) memberProfileHandler(w http.ResponseWriter, r *http.Request
 	country, ok := tzx.GetCountryByName(member.Country.String())
 	if !ok {
 		logger.Warn("failed to lookup country")
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
 	zones := country.Zones
 
+	// Members who have not chosen a language receive messages in the default language of the channel
+	channelLanguage, _ := bot.ChannelLanguage(r.Context(), db, channelID)
+
 	// Render the template
 	p := memberProfileParams{
 		ID:          slackUserID,
@@ -124,10 +140,11 @@ func (s *implServer) memberProfileHandler(w http.ResponseWriter, r *http.Request
 		Workspace:   teamInfo.Name,
 		Channel:     channelName,
 		Member:      &member,
+		Language:    i18n.Resolve(member.Language, channelLanguage.String()),
 		Countries:   tz.GetCountries(),
 		Zones:       zones,
 	}
 
 	w.Header().Set("Cache-Control", "no-cache")
-	rend.HTML(w, http.StatusOK, "member", p)
+	s.rend(r).HTML(w, http.StatusOK, "member", p)
 }
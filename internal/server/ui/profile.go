@@ -53,7 +53,6 @@ func (s *implServer) profileHandler(w http.ResponseWriter, r *http.Request) {
 	logger := hclog.FromContext(r.Context())
 	span := trace.SpanFromContext(r.Context())
 	cache := s.GetCache()
-	slackClient := s.GetSlackClient()
 
 	session, err := s.GetSession(r)
 	if err != nil {
@@ -68,21 +67,32 @@ func (s *implServer) profileHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	slackUserID := session.Values["slack_user_id"].(string) //nolint:errcheck
+	slackTeamID, _ := session.Values["slack_team_id"].(string)
+	slackEnterpriseID, _ := session.Values["slack_enterprise_id"].(string)
+
+	// Use the Slack client of the user's workspace
+	slackClient, err := s.GetTeamSlackClient(r.Context(), slackEnterpriseID, slackTeamID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to retrieve the Slack client of the workspace", "error", err, attributes.SlackTeamID, slackTeamID)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
+		return
+	}
 
 	// Retrieve the info for the Slack user
 	slackUser, err := lookupSlackUser(r.Context(), cache, slackClient, slackUserID)
 	if err != nil {
 		logger.Error("failed to lookup Slack user", "error", err, attributes.SlackUserID, slackUserID)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
 	// Retrieve the info for the Slack workspace
-	teamInfo, err := lookupSlackWorkspace(r.Context(), cache, slackClient)
+	teamInfo, err := lookupSlackWorkspace(r.Context(), cache, slackClient, slackTeamID)
 	if err != nil {
 		span.RecordError(err)
 		logger.Error("failed to lookup Slack workspace", "error", err)
-		rend.HTML(w, http.StatusInternalServerError, "500", nil)
+		s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 		return
 	}
 
@@ -118,7 +128,7 @@ func (s *implServer) profileHandler(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			span.RecordError(err)
 			logger.Error("failed to lookup Slack channel", "error", err, attributes.SlackChannelID, c.ChannelID)
-			rend.HTML(w, http.StatusInternalServerError, "500", nil)
+			s.rend(r).HTML(w, http.StatusInternalServerError, "500", nil)
 			return
 		}
 
@@ -139,5 +149,5 @@ func (s *implServer) profileHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Cache-Control", "no-cache")
-	rend.HTML(w, http.StatusOK, "profile", p)
+	s.rend(r).HTML(w, http.StatusOK, "profile", p)
 }
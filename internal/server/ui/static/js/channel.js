@@ -26,6 +26,22 @@ document
       hour: Number(data.get("hour")),
       next_round: next_round,
       connection_mode: data.get("connection-mode"),
+      language: data.get("language"),
+      include_external_members: data.get("include-external-members") === "true",
+      reminders: {
+        kickoff_enabled: data.get("kickoff-enabled") === "true",
+        kickoff_after_hours: Number(data.get("kickoff-after-hours")),
+        midround_checkin_enabled: data.get("midround-checkin-enabled") === "true",
+        midround_checkin_percent: Number(data.get("midround-checkin-percent")),
+        endround_checkin_enabled: data.get("endround-checkin-enabled") === "true",
+        endround_checkin_hours: Number(data.get("endround-checkin-hours")),
+        mark_inactive_enabled: data.get("mark-inactive-enabled") === "true",
+        mark_inactive_hours: Number(data.get("mark-inactive-hours")),
+      },
+      icebreakers: {
+        categories: data.getAll("icebreaker-categories"),
+        repeat_rounds: Number(data.get("icebreaker-repeat-rounds")),
+      },
     };
 
     let response = await fetch(form.action, {
@@ -61,4 +77,246 @@ document
     setTimeout(function () {
       window.location.replace("/profile");
     }, 3000); // 3 seconds
-  });
\ No newline at end of file
+  });
+// Submit form to /v1/channel/round API endpoint
+document
+  .getElementById("start-round-form")
+  .addEventListener("submit", async function (event) {
+    event.preventDefault();
+
+    const form = event.currentTarget;
+
+    var data = new FormData(form);
+
+    // Extract channel_id from the route
+    const channel_id = window.location.href.split("/").pop();
+
+    // Request body for the startRound API endpoint
+    let body = {
+      channel_id: channel_id,
+      ends_at: new Date(data.get("ends-at")),
+      user_group_id: data.get("user-group-id").trim(),
+      participants: data
+        .get("participants")
+        .split(",")
+        .map((s) => s.trim())
+        .filter((s) => s !== ""),
+    };
+
+    let response = await fetch(form.action, {
+      method: "POST",
+      headers: {
+        "Content-Type": "application/json",
+      },
+      body: JSON.stringify(body),
+    });
+
+    if (!response.ok) {
+      // Flash error alert
+      error = await response.json();
+
+      p = document.getElementById("error-alert-text");
+      p.textContent = error.error;
+
+      div = document.getElementById("error-alert");
+      div.classList.remove("hidden");
+
+      setTimeout(function () {
+        div.classList.add("hidden");
+      }, 5000); // 5 seconds
+
+      throw new Error("failed to start chat-roulette round");
+    }
+
+    // Flash the alert, then redirect back to /profile.
+    // Note: the CREATE_ROUND task will be completed asynchronously.
+    document.getElementById("success-alert").classList.remove("hidden");
+
+    setTimeout(function () {
+      window.location.replace("/profile");
+    }, 3000); // 3 seconds
+  });
+
+// Flash the error alert with the error from an API response
+async function flashError(response) {
+  let error = await response.json().catch(() => ({ error: response.statusText }));
+
+  p = document.getElementById("error-alert-text");
+  p.textContent = error.error;
+
+  div = document.getElementById("error-alert");
+  div.classList.remove("hidden");
+
+  setTimeout(function () {
+    div.classList.add("hidden");
+  }, 5000); // 5 seconds
+}
+
+// Submit icebreakers to the /v1/channel/icebreakers API endpoints,
+// then reload the page to list the channel's custom icebreakers.
+async function submitIcebreakers(form, body) {
+  let response = await fetch(form.action, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify(body),
+  });
+
+  if (!response.ok) {
+    await flashError(response);
+    throw new Error("failed to add icebreakers");
+  }
+
+  window.location.reload();
+}
+
+document
+  .getElementById("add-icebreaker-form")
+  .addEventListener("submit", async function (event) {
+    event.preventDefault();
+
+    const form = event.currentTarget;
+
+    var data = new FormData(form);
+
+    // Extract channel_id from the route
+    const channel_id = window.location.href.split("/").pop();
+
+    await submitIcebreakers(form, {
+      channel_id: channel_id,
+      icebreakers: [
+        {
+          question: data.get("icebreaker-question").trim(),
+          category: data.get("icebreaker-category").trim(),
+        },
+      ],
+    });
+  });
+
+document
+  .getElementById("import-icebreakers-form")
+  .addEventListener("submit", async function (event) {
+    event.preventDefault();
+
+    const form = event.currentTarget;
+
+    var data = new FormData(form);
+
+    // Extract channel_id from the route
+    const channel_id = window.location.href.split("/").pop();
+
+    await submitIcebreakers(form, {
+      channel_id: channel_id,
+      csv: await data.get("icebreakers-csv").text(),
+    });
+  });
+
+// Delete a custom icebreaker using the /v1/channel/icebreakers API endpoint
+document.querySelectorAll(".delete-icebreaker").forEach(function (button) {
+  button.addEventListener("click", async function () {
+    let response = await fetch(
+      `/v1/channel/icebreakers/${button.dataset.icebreakerId}`,
+      {
+        method: "DELETE",
+      },
+    );
+
+    if (!response.ok) {
+      await flashError(response);
+      throw new Error("failed to delete icebreaker");
+    }
+
+    button.closest("tr").remove();
+  });
+});
+
+// Send a message template to the /v1/channel/templates API endpoints
+async function sendMessageTemplate(details, path, method, content) {
+  // Extract channel_id from the route
+  const channel_id = window.location.href.split("/").pop();
+
+  return fetch(`/v1/channel/templates/${details.dataset.templateName}${path}`, {
+    method: method,
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify({
+      channel_id: channel_id,
+      content: content,
+    }),
+  });
+}
+
+// Render a live preview of a message template as it is edited
+async function previewMessageTemplate(details) {
+  const content = details.querySelector(".message-template-content").value;
+  const pre = details.querySelector(".message-template-preview");
+  const builder = details.querySelector(".message-template-builder");
+
+  let response = await sendMessageTemplate(details, "/preview", "POST", content);
+  let body = await response.json().catch(() => ({ error: response.statusText }));
+
+  if (!response.ok) {
+    pre.textContent = body.error;
+    pre.classList.add("text-red-700");
+    builder.classList.add("hidden");
+    return;
+  }
+
+  pre.textContent = body.preview;
+  pre.classList.remove("text-red-700");
+
+  // Block Kit Builder only renders the blocks of the message
+  builder.href =
+    "https://app.slack.com/block-kit-builder#" +
+    encodeURIComponent(JSON.stringify({ blocks: JSON.parse(body.preview).blocks }));
+  builder.classList.remove("hidden");
+}
+
+document.querySelectorAll(".message-template").forEach(function (details) {
+  let timeout;
+
+  details.addEventListener("toggle", function () {
+    if (details.open) {
+      previewMessageTemplate(details);
+    }
+  });
+
+  details
+    .querySelector(".message-template-content")
+    .addEventListener("input", function () {
+      clearTimeout(timeout);
+      timeout = setTimeout(function () {
+        previewMessageTemplate(details);
+      }, 500); // 0.5 seconds
+    });
+
+  details
+    .querySelector(".save-message-template")
+    .addEventListener("click", async function () {
+      const content = details.querySelector(".message-template-content").value;
+
+      let response = await sendMessageTemplate(details, "", "POST", content);
+
+      if (!response.ok) {
+        await flashError(response);
+        throw new Error("failed to save message template");
+      }
+
+      window.location.reload();
+    });
+
+  details
+    .querySelector(".reset-message-template")
+    ?.addEventListener("click", async function () {
+      let response = await sendMessageTemplate(details, "", "DELETE");
+
+      if (!response.ok) {
+        await flashError(response);
+        throw new Error("failed to reset message template");
+      }
+
+      window.location.reload();
+    });
+});
@@ -19,6 +19,7 @@ document
       channel_id: channel_id,
       user_id: user.dataset.slackUserId,
       connection_mode: data.get("connection-mode"),
+      language: data.get("language"),
       country: data.get("location-country"),
       city: data.get("location-city"),
       timezone: data.get("location-timezone"),
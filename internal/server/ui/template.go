@@ -3,10 +3,13 @@ package ui
 import (
 	"embed"
 	"html/template"
+	"net/http"
+	"time"
 
 	sprig "github.com/Masterminds/sprig/v3"
 	"github.com/unrolled/render"
 
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 	"github.com/chat-roulettte/chat-roulette/internal/templatex"
 )
 
@@ -14,7 +17,24 @@ var (
 	//go:embed static/* templates/*
 	embeddedFS embed.FS
 
-	rend = render.New(render.Options{
+	// renderers render the templates in each supported language
+	renderers = newRenderers()
+)
+
+// newRenderers returns a renderer for each supported language.
+func newRenderers() map[i18n.Language]*render.Render {
+	renderers := make(map[i18n.Language]*render.Render, len(i18n.Languages))
+
+	for _, lang := range i18n.Languages {
+		renderers[lang] = newRenderer(lang)
+	}
+
+	return renderers
+}
+
+// newRenderer returns a renderer for the templates translated into the language.
+func newRenderer(lang i18n.Language) *render.Render {
+	return render.New(render.Options{
 		// Load templates from this directory
 		Directory: "templates",
 		// Use the files embedded in the binary
@@ -27,13 +47,35 @@ var (
 		},
 		Funcs: []template.FuncMap{
 			{
-				"capitalize":         templatex.Capitalize,
-				"capitalizeInterval": templatex.CapitalizeInterval,
-				"prettyDate":         templatex.PrettyDate,
-				"prettyURL":          templatex.PrettyURL,
-				"derefBool":          templatex.DerefBool,
+				"capitalize":    templatex.Capitalize,
+				"prettyURL":     templatex.PrettyURL,
+				"prettyPercent": templatex.PrettyPercent,
+				"derefBool":     templatex.DerefBool,
+				"prettyDate": func(t time.Time) string {
+					return i18n.FormatDate(lang, t)
+				},
+				"clock": func(hour int) string {
+					return i18n.FormatClock(lang, hour)
+				},
 			},
+			i18n.Funcs(lang),
 			sprig.HtmlFuncMap(),
 		},
 	})
-)
+}
+
+// rend returns the renderer for the language of the web UI for the request.
+//
+// This is the language of the Slack user who signed in, or else the
+// language that best matches the Accept-Language header of the request.
+func (s *implServer) rend(r *http.Request) *render.Render {
+	if session, err := s.GetSession(r); err == nil {
+		if locale, ok := session.Values["locale"].(string); ok {
+			if lang, ok := i18n.Parse(locale); ok {
+				return renderers[lang]
+			}
+		}
+	}
+
+	return renderers[i18n.MatchAcceptLanguage(r.Header.Get("Accept-Language"))]
+}
@@ -48,7 +48,7 @@ func (s *implServer) indexHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Cache-Control", "public, max-age=300") // 5 minutes
-	rend.HTML(w, http.StatusOK, "index", nil)
+	s.rend(r).HTML(w, http.StatusOK, "index", nil)
 }
 
 // errorHandler is the handler for HTTP 400 and 500 errors
@@ -68,5 +68,5 @@ func (s *implServer) errorHandler(w http.ResponseWriter, r *http.Request) {
 		statusCode = http.StatusServiceUnavailable
 	}
 
-	rend.HTML(w, statusCode, path, nil)
+	s.rend(r).HTML(w, statusCode, path, nil)
 }
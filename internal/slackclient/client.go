@@ -1,6 +1,8 @@
 package slackclient
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"time"
 
@@ -10,20 +12,49 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-// New creates a Slack client that uses a retryable HTTP client
-func New(logger hclog.Logger, token string) (*slack.Client, *http.Client) {
+// New creates a Slack client that uses a retryable HTTP client.
+// Requests to the Slack API are rate limited per method.
+func New(logger hclog.Logger, token string, opts ...slack.Option) (*slack.Client, *http.Client) {
+	return newClient(logger, token, defaultRateLimiter, slack.APIURL, opts...)
+}
+
+func newClient(logger hclog.Logger, token string, limiter *rateLimiter, apiURL string, opts ...slack.Option) (*slack.Client, *http.Client) {
 	retryableHTTPClient := retryablehttp.NewClient()
 	retryableHTTPClient.RetryMax = 3
 	retryableHTTPClient.RetryWaitMin = 10 * time.Millisecond
 	retryableHTTPClient.RetryWaitMax = 200 * time.Millisecond
+	retryableHTTPClient.CheckRetry = checkRetry
 	retryableHTTPClient.Logger = logger.StandardLogger(&hclog.StandardLoggerOptions{
 		InferLevels: true,
 	})
 
+	retryableHTTPClient.HTTPClient.Transport = &rateLimitTransport{
+		logger:  logger,
+		limiter: limiter,
+		next:    retryableHTTPClient.HTTPClient.Transport,
+	}
+
 	httpClient := retryableHTTPClient.StandardClient()
 	httpClient.Transport = otelhttp.NewTransport(httpClient.Transport)
 
-	slackClient := slack.New(token, slack.OptionHTTPClient(httpClient))
+	opts = append([]slack.Option{slack.OptionHTTPClient(httpClient), slack.OptionAPIURL(apiURL)}, opts...)
+	slackClient := slack.New(token, opts...)
 
 	return slackClient, httpClient
 }
+
+// checkRetry does not retry requests that were rate limited, since retrying
+// immediately would only be rate limited again. The rate limit is returned
+// to the caller, so that the job making the request can be rescheduled.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return false, nil
+	}
+
+	var rateLimitedErr *slack.RateLimitedError
+	if errors.As(err, &rateLimitedErr) {
+		return false, err
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
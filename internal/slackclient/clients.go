@@ -0,0 +1,214 @@
+package slackclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/slack-go/slack"
+)
+
+// clientTTL is how long the client for a Slack workspace is cached, so that a new
+// bot token issued by reinstalling the app is picked up by every process.
+const clientTTL = 10 * time.Minute
+
+// ErrNotInstalled is returned when there is no bot token for a Slack workspace
+var ErrNotInstalled = errors.New("app is not installed in the Slack workspace")
+
+// Installation is the bot token and bot user of the app in a Slack workspace
+type Installation struct {
+	BotToken  string
+	BotUserID string
+}
+
+// InstallationFunc retrieves the installation of the app in a Slack workspace, or in the
+// Enterprise Grid org of the workspace if the app was installed org-wide. A nil
+// Installation is returned if the app was not installed in the workspace.
+type InstallationFunc func(ctx context.Context, enterpriseID, teamID string) (*Installation, error)
+
+// workspaceClient is the cached client for a Slack workspace
+type workspaceClient struct {
+	enterpriseID string
+	teamID       string
+	client       *slack.Client
+	botUserID    string
+	expiresAt    time.Time
+}
+
+// Clients resolves the Slack client for each Slack workspace that the app is installed in.
+//
+// Requests or jobs that are not for a specific workspace, and the workspace of the
+// bot token in the config if the app was not installed in it with OAuth, use the
+// default client, which uses the bot token in the config. Other workspaces that the
+// app was not installed in have no client.
+type Clients struct {
+	logger   hclog.Logger
+	fallback *slack.Client
+	lookup   InstallationFunc
+	apiURL   string
+
+	// fallbackTeamID is the ID of the Slack workspace of the bot token in the config
+	fallbackTeamID string
+
+	mu       sync.Mutex
+	clients  map[string]*workspaceClient
+	limiters map[string]*rateLimiter
+}
+
+// NewClients creates a new Clients. The fallback client may be nil if
+// there is no bot token in the config.
+func NewClients(logger hclog.Logger, fallback *slack.Client, lookup InstallationFunc) *Clients {
+	return &Clients{
+		logger:   logger,
+		fallback: fallback,
+		lookup:   lookup,
+		apiURL:   slack.APIURL,
+		clients:  make(map[string]*workspaceClient),
+		limiters: make(map[string]*rateLimiter),
+	}
+}
+
+// Client returns the Slack client for a Slack workspace. The enterprise ID is
+// the ID of the Enterprise Grid org of the workspace, and may be empty.
+func (c *Clients) Client(ctx context.Context, enterpriseID, teamID string) (*slack.Client, error) {
+	wc, err := c.get(ctx, enterpriseID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return wc.client, nil
+}
+
+// BotUserID returns the user ID of the bot in a Slack workspace
+func (c *Clients) BotUserID(ctx context.Context, enterpriseID, teamID string) (string, error) {
+	wc, err := c.get(ctx, enterpriseID, teamID)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	botUserID := wc.botUserID
+	c.mu.Unlock()
+
+	if botUserID != "" {
+		return botUserID, nil
+	}
+
+	resp, err := wc.client.AuthTestContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	wc.botUserID = resp.UserID
+	c.mu.Unlock()
+
+	return resp.UserID, nil
+}
+
+// Forget removes the cached clients for a Slack workspace or an Enterprise Grid
+// org, after the app was reinstalled or uninstalled.
+func (c *Clients) Forget(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, wc := range c.clients {
+		if wc.teamID == id || wc.enterpriseID == id {
+			delete(c.clients, key)
+		}
+	}
+}
+
+// get returns the cached client for a Slack workspace, creating it if necessary
+func (c *Clients) get(ctx context.Context, enterpriseID, teamID string) (*workspaceClient, error) {
+	now := time.Now()
+	key := enterpriseID + "/" + teamID
+
+	c.mu.Lock()
+	wc, ok := c.clients[key]
+	c.mu.Unlock()
+
+	if ok && now.Before(wc.expiresAt) {
+		return wc, nil
+	}
+
+	var installation *Installation
+	if enterpriseID != "" || teamID != "" {
+		var err error
+		if installation, err = c.lookup(ctx, enterpriseID, teamID); err != nil {
+			return nil, err
+		}
+	}
+
+	wc = &workspaceClient{
+		enterpriseID: enterpriseID,
+		teamID:       teamID,
+		client:       c.fallback,
+		expiresAt:    now.Add(clientTTL),
+	}
+
+	switch {
+	case installation != nil:
+		wc.client, _ = newClient(c.logger, installation.BotToken, c.limiter(key), c.apiURL)
+		wc.botUserID = installation.BotUserID
+
+	case c.fallback == nil:
+		return nil, ErrNotInstalled
+
+	case enterpriseID != "" || teamID != "":
+		// The bot token in the config is only valid for its own workspace
+		fallbackTeamID, err := c.getFallbackTeamID(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if teamID != fallbackTeamID {
+			return nil, ErrNotInstalled
+		}
+	}
+
+	c.mu.Lock()
+	c.clients[key] = wc
+	c.mu.Unlock()
+
+	return wc, nil
+}
+
+// getFallbackTeamID returns the ID of the Slack workspace of the bot token in the config
+func (c *Clients) getFallbackTeamID(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	teamID := c.fallbackTeamID
+	c.mu.Unlock()
+
+	if teamID != "" {
+		return teamID, nil
+	}
+
+	resp, err := c.fallback.AuthTestContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.fallbackTeamID = resp.TeamID
+	c.mu.Unlock()
+
+	return resp.TeamID, nil
+}
+
+// limiter returns the rate limiter for a Slack workspace. Slack rate limits
+// are per workspace, so each workspace has its own budget for each method.
+func (c *Clients) limiter(key string) *rateLimiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	l, ok := c.limiters[key]
+	if !ok {
+		l = newRateLimiter()
+		c.limiters[key] = l
+	}
+
+	return l
+}
@@ -0,0 +1,27 @@
+package slackclient
+
+import (
+	"errors"
+
+	"github.com/slack-go/slack"
+)
+
+// restrictedUserErrors are the errors returned by the Slack API when the
+// profile of a user from another org in a Slack Connect channel cannot be seen.
+//
+// Errors for users that do not exist, such as user_not_found, are not included
+// because they are also returned for deleted users and invalid user IDs.
+var restrictedUserErrors = map[string]bool{
+	"user_not_visible": true,
+}
+
+// IsRestrictedUser returns true if the error was returned because the profile of a
+// user is restricted, such as for external members of a Slack Connect channel.
+func IsRestrictedUser(err error) bool {
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		return restrictedUserErrors[slackErr.Err]
+	}
+
+	return false
+}
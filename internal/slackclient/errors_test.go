@@ -0,0 +1,20 @@
+package slackclient
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsRestrictedUser(t *testing.T) {
+	assert.True(t, IsRestrictedUser(slack.SlackErrorResponse{Err: "user_not_visible"}))
+	assert.True(t, IsRestrictedUser(fmt.Errorf("wrapped: %w", slack.SlackErrorResponse{Err: "user_not_visible"})))
+	assert.False(t, IsRestrictedUser(slack.SlackErrorResponse{Err: "invalid_auth"}))
+	assert.False(t, IsRestrictedUser(slack.SlackErrorResponse{Err: "user_not_found"}))
+	assert.False(t, IsRestrictedUser(slack.SlackErrorResponse{Err: "users_not_found"}))
+	assert.False(t, IsRestrictedUser(errors.New("user_not_found")))
+	assert.False(t, IsRestrictedUser(nil))
+}
@@ -0,0 +1,28 @@
+package slackclient
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var meter = otel.Meter("")
+
+var (
+	// requestsCounter counts the requests made to each Slack API method
+	requestsCounter, _ = meter.Int64Counter("slack.api.requests",
+		metric.WithDescription("Number of requests made to the Slack API"),
+	)
+
+	// errorsCounter counts the requests to each Slack API method that failed
+	// due to a network error or that received a 4xx or 5xx response
+	errorsCounter, _ = meter.Int64Counter("slack.api.errors",
+		metric.WithDescription("Number of requests to the Slack API that failed"),
+	)
+
+	// rateLimitedCounter counts the requests to each Slack API method that
+	// received a 429 response, or that were not sent because the client-side
+	// rate limit would have been exceeded
+	rateLimitedCounter, _ = meter.Int64Counter("slack.api.rate_limited",
+		metric.WithDescription("Number of requests to the Slack API that were rate limited"),
+	)
+)
@@ -0,0 +1,247 @@
+package slackclient
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/slack-go/slack"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+)
+
+// Tier is a Slack Web API rate limit tier.
+// See: https://api.slack.com/apis/rate-limits
+type Tier int
+
+const (
+	// Tier1 allows 1+ requests per minute
+	Tier1 Tier = 1
+	// Tier2 allows 20+ requests per minute
+	Tier2 Tier = 20
+	// Tier3 allows 50+ requests per minute
+	Tier3 Tier = 50
+	// Tier4 allows 100+ requests per minute
+	Tier4 Tier = 100
+	// TierPostMessage allows roughly 1 message per second
+	TierPostMessage Tier = 60
+)
+
+const (
+	// maxRateLimitWait is the longest that a request waits for the rate limit
+	// budget of its method, before it is rejected so that the job can be rescheduled
+	maxRateLimitWait = 10 * time.Second
+
+	// defaultRetryAfter is used when Slack does not send a valid Retry-After header
+	defaultRetryAfter = 30 * time.Second
+)
+
+// methodTiers are the rate limit tiers of the Slack Web API methods used by chat-roulette.
+// Methods that are not listed are assumed to be Tier 3, which most methods are.
+var methodTiers = map[string]Tier{
+	"auth.test":             Tier4,
+	"bots.info":             Tier3,
+	"chat.postMessage":      TierPostMessage,
+	"conversations.history": Tier3,
+	"conversations.info":    Tier3,
+	"conversations.members": Tier4,
+	"conversations.open":    Tier3,
+	"team.info":             Tier3,
+	"usergroups.users.list": Tier2,
+	"users.conversations":   Tier3,
+	"users.info":            Tier4,
+	"views.open":            Tier4,
+	"views.publish":         Tier4,
+}
+
+// bucket tracks the rate limit budget of a single Slack API method using the
+// generic cell rate algorithm. Requests reserve the next available slot in the
+// order they arrive, so that the budget is shared fairly between workers.
+type bucket struct {
+	mu sync.Mutex
+
+	// interval is the time between requests to stay within the budget
+	interval time.Duration
+
+	// burst is how far ahead of the budget requests are allowed to be sent
+	burst time.Duration
+
+	// tat is the theoretical arrival time of the next request
+	tat time.Time
+}
+
+func newBucket(tier Tier) *bucket {
+	interval := time.Minute / time.Duration(tier)
+
+	return &bucket{
+		interval: interval,
+		burst:    interval * time.Duration(max(int(tier)/10-1, 0)),
+	}
+}
+
+// reserve reserves a slot for a request and returns how long to wait before sending it.
+// No slot is reserved if the wait would be longer than maxWait.
+func (b *bucket) reserve(now time.Time, maxWait time.Duration) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tat := b.tat
+	if tat.Before(now) {
+		tat = now
+	}
+
+	wait := max(tat.Sub(now)-b.burst, 0)
+	if wait > maxWait {
+		return wait, false
+	}
+
+	b.tat = tat.Add(b.interval)
+
+	return wait, true
+}
+
+// pause stops requests from being sent until the given time has passed
+func (b *bucket) pause(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if tat := until.Add(b.burst); tat.After(b.tat) {
+		b.tat = tat
+	}
+}
+
+// rateLimiter enforces the rate limits of the Slack Web API per method.
+// The budget is tracked per process, so Slack may still rate limit requests
+// when multiple processes share a Slack workspace. Those are handled by
+// honouring the Retry-After header sent by Slack.
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// defaultRateLimiter is shared by all of the Slack clients in the process
+var defaultRateLimiter = newRateLimiter()
+
+// bucket returns the bucket for a Slack API method
+func (l *rateLimiter) bucket(method string) *bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[method]
+	if !ok {
+		tier, ok := methodTiers[method]
+		if !ok {
+			tier = Tier3
+		}
+
+		b = newBucket(tier)
+		l.buckets[method] = b
+	}
+
+	return b
+}
+
+// wait blocks until a request to the Slack API method can be sent. A *slack.RateLimitedError
+// is returned, without waiting, if the request cannot be sent within maxRateLimitWait
+// or before the context expires, so that the caller can try again later.
+func (l *rateLimiter) wait(ctx context.Context, method string) error {
+	now := time.Now()
+
+	maxWait := maxRateLimitWait
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = min(maxWait, deadline.Sub(now))
+	}
+
+	wait, ok := l.bucket(method).reserve(now, maxWait)
+	if !ok {
+		return &slack.RateLimitedError{RetryAfter: wait}
+	}
+
+	if wait == 0 {
+		return nil
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// rateLimitTransport is a http.RoundTripper that waits for the rate limit budget
+// of the Slack API method before sending a request, and pauses requests to the
+// method for the duration of the Retry-After header when Slack rate limits a request.
+type rateLimitTransport struct {
+	logger  hclog.Logger
+	limiter *rateLimiter
+	next    http.RoundTripper
+}
+
+// RoundTrip satisfies the http.RoundTripper interface
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only the Web API is rate limited, not OIDC discovery
+	if !strings.HasPrefix(req.URL.Path, "/api/") {
+		return t.next.RoundTrip(req)
+	}
+
+	method := path.Base(req.URL.Path)
+
+	ctx := req.Context()
+	attrs := metric.WithAttributes(attribute.String(attributes.SlackAPIMethod, method))
+
+	if err := t.limiter.wait(ctx, method); err != nil {
+		rateLimitedCounter.Add(ctx, 1, attrs)
+		return nil, err
+	}
+
+	requestsCounter.Add(ctx, 1, attrs)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		errorsCounter.Add(ctx, 1, attrs)
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		errorsCounter.Add(ctx, 1, attrs)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		rateLimitedCounter.Add(ctx, 1, attrs)
+
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+
+		t.logger.Warn("rate limited by Slack", "method", method, "retry_after", retryAfter)
+
+		t.limiter.bucket(method).pause(time.Now().Add(retryAfter))
+	}
+
+	return resp, nil
+}
+
+// parseRetryAfter parses the number of seconds in the Retry-After header
+func parseRetryAfter(v string) time.Duration {
+	seconds, err := strconv.Atoi(v)
+	if err != nil || seconds <= 0 {
+		return defaultRetryAfter
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 )
 
 var (
@@ -122,8 +124,8 @@ func NextMonth(t time.Time) time.Time {
 // FormatMonthlyOccurrence extracts the day and week number from a timestamp
 // and formats it into a human-readable string for when in the month that it occurs.
 //
-// eg, "first Monday" or "last Saturday"
-func FormatMonthlyOccurrence(t time.Time) string {
+// eg, "first Monday" or "last Saturday", translated into the language
+func FormatMonthlyOccurrence(lang i18n.Language, t time.Time) string {
 	week := (t.Day()-1)/7 + 1
 
 	var occurrence string
@@ -138,24 +140,28 @@ func FormatMonthlyOccurrence(t time.Time) string {
 		occurrence = "last"
 	}
 
-	formattedString := fmt.Sprintf("%s %s", occurrence, t.Weekday())
-
-	return formattedString
+	return i18n.T(lang, "occurrence."+occurrence, i18n.Weekday(lang, t.Weekday()))
 }
 
 // MidPoint calculates the mid-point between two timestamps
 func MidPoint(t1, t2 time.Time) (time.Time, error) {
+	return PercentPoint(t1, t2, 50)
+}
+
+// PercentPoint calculates the point between two timestamps
+// at which the given percentage of the duration has elapsed
+func PercentPoint(t1, t2 time.Time, percent int) (time.Time, error) {
 	if t2.Before(t1) {
 		return time.Time{}, fmt.Errorf("t2 cannot be before t1")
 	}
 
 	duration := t2.Sub(t1)
-	midpoint := t1.Add(duration / 2)
+	point := t1.Add(duration * time.Duration(percent) / 100)
 
 	return time.Date(
-		midpoint.Year(),
-		midpoint.Month(),
-		midpoint.Day(),
+		point.Year(),
+		point.Month(),
+		point.Day(),
 		t2.Hour(),
 		0,
 		0,
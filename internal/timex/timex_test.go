@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/chat-roulettte/chat-roulette/internal/i18n"
 )
 
 func TestParseWeekday(t *testing.T) {
@@ -160,18 +162,22 @@ func TestNextMonth(t *testing.T) {
 
 func TestFormatMonthlyInterval(t *testing.T) {
 	testCases := []struct {
+		lang     i18n.Language
 		input    time.Time
 		expected string
 	}{
-		{time.Date(2024, time.August, 1, 12, 0, 0, 0, time.UTC), "first Thursday"},
-		{time.Date(2024, time.August, 12, 12, 0, 0, 0, time.UTC), "second Monday"},
-		{time.Date(2024, time.August, 22, 12, 0, 0, 0, time.UTC), "last Thursday"},
-		{time.Date(2024, time.August, 21, 12, 0, 0, 0, time.UTC), "third Wednesday"},
-		{time.Date(2024, time.August, 30, 12, 0, 0, 0, time.UTC), "last Friday"},
+		{i18n.English, time.Date(2024, time.August, 1, 12, 0, 0, 0, time.UTC), "first Thursday"},
+		{i18n.English, time.Date(2024, time.August, 12, 12, 0, 0, 0, time.UTC), "second Monday"},
+		{i18n.English, time.Date(2024, time.August, 22, 12, 0, 0, 0, time.UTC), "last Thursday"},
+		{i18n.English, time.Date(2024, time.August, 21, 12, 0, 0, 0, time.UTC), "third Wednesday"},
+		{i18n.English, time.Date(2024, time.August, 30, 12, 0, 0, 0, time.UTC), "last Friday"},
+		{i18n.French, time.Date(2024, time.August, 1, 12, 0, 0, 0, time.UTC), "premier jeudi"},
+		{i18n.German, time.Date(2024, time.August, 12, 12, 0, 0, 0, time.UTC), "zweiten Montag"},
+		{i18n.Spanish, time.Date(2024, time.August, 30, 12, 0, 0, 0, time.UTC), "último viernes"},
 	}
 
 	for _, tt := range testCases {
-		actual := FormatMonthlyOccurrence(tt.input)
+		actual := FormatMonthlyOccurrence(tt.lang, tt.input)
 		assert.Equal(t, tt.expected, actual)
 	}
 }
@@ -226,3 +232,19 @@ func TestMidPoint(t *testing.T) {
 		assert.Equal(t, tc.expected, actual)
 	}
 }
+
+func TestPercentPoint(t *testing.T) {
+	t1 := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2024, 10, 11, 12, 0, 0, 0, time.UTC)
+
+	actual, err := PercentPoint(t1, t2, 25)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 10, 3, 12, 0, 0, 0, time.UTC), actual)
+
+	actual, err = PercentPoint(t1, t2, 80)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 10, 9, 12, 0, 0, 0, time.UTC), actual)
+
+	_, err = PercentPoint(t2, t1, 50)
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+)
+
+const (
+	// leaseDuration is how long a worker holds a claimed job before it
+	// can be claimed by another worker, unless the lease is extended
+	leaseDuration = 1 * time.Minute
+
+	// heartbeatInterval is how often the lease on an executing job is extended
+	heartbeatInterval = leaseDuration / 3
+)
+
+var (
+	// errLeaseLost is returned when a worker no longer holds the lease on a job
+	errLeaseLost = errors.New("lease on the job was lost")
+)
+
+// claimJob claims the next available job in the queue by leasing it to this worker
+func (w *Worker) claimJob(ctx context.Context) (*models.Job, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	job := new(models.Job)
+
+	// ClaimNextJob() is a custom Postgres function. Refer to SQL migration files
+	result := w.db.WithContext(dbCtx).
+		Raw("SELECT * FROM ClaimNextJob(?, ?)", w.id, int(leaseDuration.Seconds())).
+		First(job)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return job, nil
+}
+
+// heartbeat periodically extends the lease on a job while it is executing.
+// If the lease is lost, the job is canceled. The returned function stops the heartbeat.
+func (w *Worker) heartbeat(ctx context.Context, job *models.Job, cancelJob context.CancelFunc) func() {
+	ctx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-ticker.C:
+				err := w.extendLease(ctx, job)
+				if err == nil || ctx.Err() != nil {
+					continue
+				}
+
+				if errors.Is(err, errLeaseLost) {
+					w.logger.Warn("lost lease on job, canceling execution",
+						attributes.JobID, job.JobID.String(),
+						attributes.JobType, job.JobType.String(),
+					)
+					cancelJob()
+					return
+				}
+
+				// The lease can still be extended on the next heartbeat
+				w.logger.Warn("failed to extend lease on job", "error", err, attributes.JobID, job.JobID.String())
+			}
+		}
+	}()
+
+	return func() {
+		stop()
+		<-done
+	}
+}
+
+// extendLease extends the lease on a job held by this worker
+func (w *Worker) extendLease(ctx context.Context, job *models.Job) error {
+	dbCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	result := w.db.WithContext(dbCtx).
+		Model(&models.Job{}).
+		Where("id = ?", job.ID).
+		Where("locked_by = ?", w.id).
+		Update("locked_until", gorm.Expr("NOW() + make_interval(secs => ?)", int(leaseDuration.Seconds())))
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errLeaseLost
+	}
+
+	return nil
+}
+
+// completeJob records the outcome of a job and releases the lease on it.
+// The failed attempt, if any, is recorded in the job's attempt history.
+func (w *Worker) completeJob(ctx context.Context, job *models.Job, attempt *models.JobAttempt) error {
+	// The outcome must still be recorded if the job was canceled
+	dbCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 500*time.Millisecond)
+	defer cancel()
+
+	err := w.db.WithContext(dbCtx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Job{}).
+			Where("id = ?", job.ID).
+			Where("locked_by = ?", w.id).
+			Updates(map[string]interface{}{
+				"status":        job.Status,
+				"is_completed":  job.IsCompleted,
+				"attempts":      job.Attempts,
+				"last_error":    job.LastError,
+				"next_retry_at": job.NextRetryAt,
+				"locked_by":     nil,
+				"locked_until":  nil,
+			})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errLeaseLost
+		}
+
+		if attempt != nil {
+			return tx.Create(attempt).Error
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		w.logger.Error("failed to record the outcome of the job",
+			"error", err,
+			attributes.JobID, job.JobID.String(),
+			attributes.JobType, job.JobType.String(),
+		)
+
+		return err
+	}
+
+	recordJobOutcome(ctx, job)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// jobQueuedChannel is the Postgres channel that is notified when a job is ready to be executed.
+	// Refer to NotifyJobQueued() in the SQL migration files
+	jobQueuedChannel = "job_queued"
+
+	// listenRetryDelay is the delay before reconnecting after the listener has failed
+	listenRetryDelay = 5 * time.Second
+)
+
+// listen wakes up the worker whenever a job is ready to be executed.
+// If the listener fails, the worker falls back to polling until it reconnects.
+func (w *Worker) listen(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// This context will be canceled when the shutdown channel is closed
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.shutdownCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		err := w.waitForNotifications(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.logger.Warn("failed to listen for new jobs, falling back to polling", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+// waitForNotifications uses a dedicated connection to LISTEN for
+// new jobs and wakes up the worker for each notification received.
+func (w *Worker) waitForNotifications(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, w.databaseURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+jobQueuedChannel); err != nil {
+		return err
+	}
+
+	w.logger.Debug("listening for new jobs", "channel", jobQueuedChannel)
+
+	// Jobs may have been queued while the worker was not listening
+	w.wake()
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+
+		w.wake()
+	}
+}
+
+// wake wakes up an idle worker to process the next job. This does not block
+// when all of the workers are busy, since they will check for more jobs once done.
+func (w *Worker) wake() {
+	select {
+	case w.wakeCh <- struct{}{}:
+	default:
+	}
+}
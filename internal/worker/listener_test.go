@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+)
+
+func Test_wake(t *testing.T) {
+	w := Worker{
+		wakeCh: make(chan struct{}, 1),
+	}
+
+	// wake does not block when all of the workers are busy
+	w.wake()
+	w.wake()
+
+	assert.Len(t, w.wakeCh, 1)
+}
+
+func Test_listen(t *testing.T) {
+	r := require.New(t)
+
+	resource, databaseURL, err := database.NewTestPostgresDB(true)
+	r.NoError(err)
+	defer resource.Close()
+
+	db, err := database.NewGormDB(databaseURL)
+	r.NoError(err)
+
+	shutdownCh := make(chan bool)
+
+	w := &Worker{
+		id:          "test",
+		logger:      hclog.NewNullLogger(),
+		databaseURL: databaseURL,
+		wakeCh:      make(chan struct{}, 1),
+		shutdownCh:  shutdownCh,
+	}
+
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go w.listen(context.Background(), wg)
+
+	// The worker is woken up once it starts listening
+	select {
+	case <-w.wakeCh:
+	case <-time.After(10 * time.Second):
+		r.FailNow("worker was not woken up after it started listening")
+	}
+
+	// Queue a new job
+	r.NoError(db.Create(models.NewJob(models.JobTypeSyncChannels, []byte(`{}`))).Error)
+
+	select {
+	case <-w.wakeCh:
+	case <-time.After(10 * time.Second):
+		r.FailNow("worker was not woken up for the new job")
+	}
+
+	// Jobs scheduled to run later do not wake up the worker
+	job := models.NewJob(models.JobTypeSyncChannels, []byte(`{}`))
+	job.ExecAt = time.Now().UTC().Add(time.Hour)
+	r.NoError(db.Create(job).Error)
+
+	select {
+	case <-w.wakeCh:
+		r.FailNow("worker was woken up for a job scheduled to run later")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	close(shutdownCh)
+	wg.Wait()
+}
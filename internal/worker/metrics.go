@@ -0,0 +1,152 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"gorm.io/gorm"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
+)
+
+var meter = otel.Meter("")
+
+var (
+	// jobDuration records how long it took to execute a job
+	jobDuration, _ = meter.Float64Histogram("jobs.duration",
+		metric.WithDescription("Duration of the execution of jobs"),
+		metric.WithUnit("s"),
+	)
+
+	// jobLatency records the time from when a job was scheduled
+	// to be executed until it was completed, including retries
+	jobLatency, _ = meter.Float64Histogram("jobs.latency",
+		metric.WithDescription("Time from when jobs were scheduled to be executed until they were completed"),
+		metric.WithUnit("s"),
+	)
+
+	// jobsCompleted counts the jobs that were completed by their final status
+	jobsCompleted, _ = meter.Int64Counter("jobs.completed",
+		metric.WithDescription("Number of jobs that were completed"),
+	)
+
+	// jobRetries counts the jobs that were scheduled to be retried
+	jobRetries, _ = meter.Int64Counter("jobs.retries",
+		metric.WithDescription("Number of jobs that were scheduled to be retried"),
+	)
+)
+
+// queueDepth is the number of pending jobs in the queue
+type queueDepth struct {
+	JobType string
+	Status  string
+	Count   int64
+}
+
+// registerGauges registers the gauges that are observed from the database
+// whenever the metrics are collected. Since all workers share the database,
+// every worker process reports the same values.
+func registerGauges(db *gorm.DB) error {
+	queued, err := meter.Int64ObservableGauge("jobs.queued",
+		metric.WithDescription("Number of jobs in the queue that have not been completed"),
+	)
+	if err != nil {
+		return err
+	}
+
+	activeChannels, err := meter.Int64ObservableGauge("channels.active",
+		metric.WithDescription("Number of Slack channels with a chat-roulette round in progress"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		dbCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancel()
+
+		var depths []queueDepth
+
+		if err := db.WithContext(dbCtx).
+			Model(&models.Job{}).
+			Select("job_type, status, COUNT(*) AS count").
+			Where("is_completed = false").
+			Group("job_type, status").
+			Scan(&depths).Error; err != nil {
+			return err
+		}
+
+		for _, d := range depths {
+			o.ObserveInt64(queued, d.Count, metric.WithAttributes(
+				attribute.String(attributes.JobType, d.JobType),
+				attribute.String(attributes.JobStatus, d.Status),
+			))
+		}
+
+		var channels int64
+
+		if err := db.WithContext(dbCtx).
+			Model(&models.Round{}).
+			Distinct("channel_id").
+			Where("has_ended = false").
+			Count(&channels).Error; err != nil {
+			return err
+		}
+
+		o.ObserveInt64(activeChannels, channels)
+
+		return nil
+	}, queued, activeChannels)
+
+	return err
+}
+
+// registerSlotGauges registers the gauges for the busy and idle slots of the worker,
+// which are observed from Stats() whenever the metrics are collected.
+func registerSlotGauges(w *Worker) error {
+	busy, err := meter.Int64ObservableGauge("worker.slots.busy",
+		metric.WithDescription("Number of worker slots that are executing a job"),
+	)
+	if err != nil {
+		return err
+	}
+
+	idle, err := meter.Int64ObservableGauge("worker.slots.idle",
+		metric.WithDescription("Number of worker slots that are waiting for a job"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		stats := w.Stats()
+
+		o.ObserveInt64(busy, int64(stats.Busy))
+		o.ObserveInt64(idle, int64(stats.Idle))
+
+		return nil
+	}, busy, idle)
+
+	return err
+}
+
+// recordJobOutcome records the metrics for the outcome of a job
+func recordJobOutcome(ctx context.Context, job *models.Job) {
+	jobType := attribute.String(attributes.JobType, job.JobType.String())
+
+	if !job.IsCompleted {
+		jobRetries.Add(ctx, 1, metric.WithAttributes(jobType))
+		return
+	}
+
+	jobsCompleted.Add(ctx, 1, metric.WithAttributes(
+		jobType,
+		attribute.String(attributes.JobStatus, job.Status.String()),
+	))
+
+	jobLatency.Record(ctx, time.Since(job.ExecAt).Seconds(), metric.WithAttributes(jobType))
+}
@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/chat-roulettte/chat-roulette/internal/database"
+	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+)
+
+func Test_metrics(t *testing.T) {
+	r := require.New(t)
+
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	db, mock := database.NewMockedGormDB()
+
+	r.NoError(registerGauges(db))
+
+	// A worker with 1 of its 4 slots executing a job
+	w := &Worker{concurrency: 4}
+	w.busy.Store(1)
+
+	r.NoError(registerSlotGauges(w))
+
+	mock.ExpectQuery(`SELECT job_type, status, COUNT\(\*\) AS count FROM "jobs" WHERE is_completed = false GROUP BY job_type, status`).
+		WillReturnRows(sqlmock.NewRows([]string{"job_type", "status", "count"}).
+			AddRow("ADD_MEMBER", "PENDING", 3).
+			AddRow("CREATE_PAIR", "ERRORED", 1),
+		)
+
+	mock.ExpectQuery(`SELECT COUNT\(DISTINCT\("channel_id"\)\) FROM "rounds" WHERE has_ended = false`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	ctx := context.Background()
+
+	// A job that was retried and then succeeded
+	job := &models.Job{
+		JobType: models.JobTypeAddMember,
+		ExecAt:  time.Now().Add(-1 * time.Minute),
+	}
+	recordJobOutcome(ctx, job)
+
+	job.Status = models.JobStatusSucceeded
+	job.IsCompleted = true
+	recordJobOutcome(ctx, job)
+
+	var rm metricdata.ResourceMetrics
+	r.NoError(reader.Collect(ctx, &rm))
+	r.NoError(mock.ExpectationsWereMet())
+
+	collected := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			collected[m.Name] = m.Data
+		}
+	}
+
+	queued, ok := collected["jobs.queued"].(metricdata.Gauge[int64])
+	r.True(ok)
+	assert.Len(t, queued.DataPoints, 2)
+
+	channels, ok := collected["channels.active"].(metricdata.Gauge[int64])
+	r.True(ok)
+	r.Len(channels.DataPoints, 1)
+	assert.Equal(t, int64(2), channels.DataPoints[0].Value)
+
+	busy, ok := collected["worker.slots.busy"].(metricdata.Gauge[int64])
+	r.True(ok)
+	r.Len(busy.DataPoints, 1)
+	assert.Equal(t, int64(1), busy.DataPoints[0].Value)
+
+	idle, ok := collected["worker.slots.idle"].(metricdata.Gauge[int64])
+	r.True(ok)
+	r.Len(idle.DataPoints, 1)
+	assert.Equal(t, int64(3), idle.DataPoints[0].Value)
+
+	retries, ok := collected["jobs.retries"].(metricdata.Sum[int64])
+	r.True(ok)
+	r.Len(retries.DataPoints, 1)
+	assert.Equal(t, int64(1), retries.DataPoints[0].Value)
+
+	completed, ok := collected["jobs.completed"].(metricdata.Sum[int64])
+	r.True(ok)
+	r.Len(completed.DataPoints, 1)
+	status, _ := completed.DataPoints[0].Attributes.Value("job_status")
+	assert.Equal(t, "SUCCEEDED", status.AsString())
+
+	latency, ok := collected["jobs.latency"].(metricdata.Histogram[float64])
+	r.True(ok)
+	r.Len(latency.DataPoints, 1)
+	assert.GreaterOrEqual(t, latency.DataPoints[0].Sum, 60.0)
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// retryBaseDelay is the delay before the first retry of a failed job
+	retryBaseDelay = 10 * time.Second
+
+	// retryMaxDelay is the maximum delay between retries of a failed job
+	retryMaxDelay = 1 * time.Hour
+
+	// throttledMinDelay is the minimum delay before retrying a job that was rate limited
+	throttledMinDelay = 1 * time.Second
+)
+
+// retryBackoff returns how long to wait before retrying a job that has failed
+// the given number of attempts, using exponential backoff with equal jitter.
+func retryBackoff(attempts int) time.Duration {
+	delay := retryMaxDelay
+
+	// Guard against overflowing the shift for a large number of attempts
+	if attempts < 16 {
+		delay = min(retryBaseDelay<<max(attempts-1, 0), retryMaxDelay)
+	}
+
+	half := delay / 2
+
+	return half + rand.N(half+1) //nolint:gosec
+}
+
+// throttledRetryAfter returns how long to wait before retrying a job that
+// failed because its requests were rate limited by the Slack API.
+func throttledRetryAfter(err error) (time.Duration, bool) {
+	var rateLimitedErr *slack.RateLimitedError
+	if !errors.As(err, &rateLimitedErr) {
+		return 0, false
+	}
+
+	return max(rateLimitedErr.RetryAfter, throttledMinDelay), true
+}
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_retryBackoff(t *testing.T) {
+	testCases := []struct {
+		attempts int
+		min      time.Duration
+		max      time.Duration
+	}{
+		{1, 5 * time.Second, 10 * time.Second},
+		{2, 10 * time.Second, 20 * time.Second},
+		{3, 20 * time.Second, 40 * time.Second},
+		{10, 30 * time.Minute, time.Hour},
+		{100, 30 * time.Minute, time.Hour},
+	}
+
+	for _, tc := range testCases {
+		for i := 0; i < 100; i++ {
+			delay := retryBackoff(tc.attempts)
+			assert.GreaterOrEqual(t, delay, tc.min)
+			assert.LessOrEqual(t, delay, tc.max)
+		}
+	}
+}
+
+func Test_throttledRetryAfter(t *testing.T) {
+	t.Run("rate limited", func(t *testing.T) {
+		err := errors.Wrap(&slack.RateLimitedError{RetryAfter: 30 * time.Second}, "failed to send Slack message")
+
+		delay, ok := throttledRetryAfter(err)
+		assert.True(t, ok)
+		assert.Equal(t, 30*time.Second, delay)
+	})
+
+	t.Run("minimum delay", func(t *testing.T) {
+		delay, ok := throttledRetryAfter(&slack.RateLimitedError{})
+		assert.True(t, ok)
+		assert.Equal(t, throttledMinDelay, delay)
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		_, ok := throttledRetryAfter(errors.New("channel_not_found"))
+		assert.False(t, ok)
+	})
+}
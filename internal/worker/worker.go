@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
@@ -14,6 +15,7 @@ import (
 	"github.com/slack-go/slack"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 
@@ -21,10 +23,19 @@ import (
 	"github.com/chat-roulettte/chat-roulette/internal/config"
 	"github.com/chat-roulettte/chat-roulette/internal/database"
 	"github.com/chat-roulettte/chat-roulette/internal/database/models"
+	"github.com/chat-roulettte/chat-roulette/internal/o11y"
 	"github.com/chat-roulettte/chat-roulette/internal/o11y/attributes"
 	"github.com/chat-roulettte/chat-roulette/internal/slackclient"
 )
 
+var (
+	// errNotStarted is returned when the worker has not started processing jobs yet
+	errNotStarted = errors.New("worker has not started")
+
+	// errShuttingDown is returned when the worker is no longer picking up new jobs
+	errShuttingDown = errors.New("worker is shutting down")
+)
+
 // Worker works on jobs in the queue
 type Worker struct {
 	// id of the worker
@@ -36,15 +47,33 @@ type Worker struct {
 	// db ...
 	db *gorm.DB
 
-	// slackClient ...
-	slackClient *slack.Client
+	// slackClients resolves the Slack client for the workspace of each job
+	slackClients *slackclient.Clients
+
+	// databaseURL is used to LISTEN for new jobs on a dedicated connection
+	databaseURL string
 
-	// interval is the frequency to process jobs
+	// appURL is the base URL of the web app, used by jobs that refresh the App Home
+	appURL string
+
+	// interval is the frequency to poll for jobs that were scheduled to run later
 	interval time.Duration
 
+	// wakeCh receives a value whenever a job is ready to be executed
+	wakeCh chan struct{}
+
 	// concurrency is the number of jobs to process at a time
 	concurrency int
 
+	// busy is the number of worker slots that are currently processing a job
+	busy atomic.Int32
+
+	// started is set once the worker has started processing jobs
+	started atomic.Bool
+
+	// drainTimeout is how long to wait for in-flight jobs to complete on shutdown
+	drainTimeout time.Duration
+
 	// shutdownCh is the channel that is closed to stop the worker
 	shutdownCh <-chan bool
 }
@@ -72,95 +101,200 @@ func New(ctx context.Context, logger hclog.Logger, c *config.Config, ch <-chan b
 		return nil, err
 	}
 
-	// Create Slack client
-	slackClient, _ := slackclient.New(logger, c.Bot.AuthToken)
+	// Create Slack clients. Jobs for workspaces that the app was installed in
+	// with the OAuth install flow use the bot token stored in the database.
+	var slackClient *slack.Client
+	if c.Bot.AuthToken != "" {
+		slackClient, _ = slackclient.New(logger, c.Bot.AuthToken)
+	}
+
+	slackClients := slackclient.NewClients(logger, slackClient, bot.SlackInstallations(db))
+
+	// Report the depth of the queue and other gauges from the database
+	if err := registerGauges(db); err != nil {
+		logger.Error("failed to register metrics", "error", err)
+		return nil, err
+	}
 
 	w := &Worker{
-		id:          workerID,
-		logger:      logger,
-		db:          db,
-		slackClient: slackClient,
-		interval:    1 * time.Second,
-		concurrency: c.Worker.Concurrency,
-		shutdownCh:  ch,
+		id:           workerID,
+		logger:       logger,
+		db:           db,
+		slackClients: slackClients,
+		databaseURL:  c.Database.URL,
+		appURL:       c.Server.GetBaseURL(),
+		interval:     c.Worker.PollInterval,
+		wakeCh:       make(chan struct{}, c.Worker.Concurrency),
+		concurrency:  c.Worker.Concurrency,
+		drainTimeout: c.Worker.DrainTimeout,
+		shutdownCh:   ch,
+	}
+
+	// Report the busy and idle slots of the worker
+	if err := registerSlotGauges(w); err != nil {
+		logger.Error("failed to register metrics", "error", err)
+		return nil, err
 	}
 
 	return w, nil
 }
 
-// Start starts the worker running in the background with the desired concurrency
+// GetDB retrieves the gorm.DB of the worker
+func (w *Worker) GetDB() *gorm.DB {
+	return w.db
+}
+
+// Stats reports the number of busy and idle worker slots
+type Stats struct {
+	Slots int `json:"slots"`
+	Busy  int `json:"busy"`
+	Idle  int `json:"idle"`
+}
+
+// Stats returns the number of busy and idle worker slots
+func (w *Worker) Stats() Stats {
+	busy := int(w.busy.Load())
+
+	return Stats{
+		Slots: w.concurrency,
+		Busy:  busy,
+		Idle:  w.concurrency - busy,
+	}
+}
+
+// Ready returns an error if the worker is not ready to process jobs
+func (w *Worker) Ready(ctx context.Context) error {
+	if !w.started.Load() {
+		return errNotStarted
+	}
+
+	select {
+	case <-w.shutdownCh:
+		return errShuttingDown
+	default:
+	}
+
+	return database.Ping(ctx, w.db)
+}
+
+// Start starts the worker running in the background with a fixed number of worker
+// slots, so that no more than the desired concurrency of jobs are processed at a time
 func (w *Worker) Start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go w.listen(ctx, wg)
+
+	// In-flight jobs are only canceled if they do not complete before the drain deadline
+	jobCtx, cancelJobs := context.WithCancel(ctx)
+
+	slots := new(sync.WaitGroup)
 	for i := 0; i < w.concurrency; i++ {
-		wg.Add(1)
-		go w.run(ctx, wg)
+		slots.Add(1)
+		go w.run(jobCtx, slots)
 	}
+
+	wg.Add(1)
+	go w.drain(wg, slots, cancelJobs)
+
+	w.started.Store(true)
 }
 
-// run runs the control loop for the worker and gracefully stops when the shutdown channel is closed
+// run runs the control loop for a worker slot, processing one job at a time
+// until the shutdown channel is closed
 func (w *Worker) run(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	// Extract the link from the parent trace
 	link := trace.LinkFromContext(ctx)
 
-	// This context will be canceled when the shutdown channel is closed
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	// Jobs will be processed in the queue on this interval
+	// Jobs are processed as soon as they are queued, with a fallback poll
+	// on this interval to pick up jobs that were scheduled to run later
 	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
 
 	for {
 		select {
 		case <-w.shutdownCh:
-			w.logger.Info("received shutdown, gracefully stopping Worker")
-			cancel()
-			ticker.Stop()
 			return
-
+		case <-w.wakeCh:
 		case <-ticker.C:
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				w.processJob(ctx, link) //nolint:errcheck
-			}()
+		}
+
+		// Do not pick up another job if a shutdown was received at the same time
+		select {
+		case <-w.shutdownCh:
+			return
+		default:
+		}
+
+		w.busy.Add(1)
+		err := w.processJob(ctx, link)
+		w.busy.Add(-1)
+
+		// Keep going while there are jobs in the queue
+		if err == nil {
+			w.wake()
 		}
 	}
 }
 
-// processJob gets the next available job from the queue and handles it
+// drain waits for in-flight jobs to complete once the shutdown channel is closed,
+// canceling any jobs that are still running after the drain deadline has passed
+func (w *Worker) drain(wg *sync.WaitGroup, slots *sync.WaitGroup, cancelJobs context.CancelFunc) {
+	defer wg.Done()
+	defer cancelJobs()
+
+	<-w.shutdownCh
+
+	stats := w.Stats()
+	w.logger.Info("received shutdown, waiting for in-flight jobs to complete",
+		attributes.WorkerBusySlots, stats.Busy,
+		"timeout", w.drainTimeout,
+	)
+
+	done := make(chan struct{})
+	go func() {
+		slots.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.logger.Info("gracefully stopped Worker")
+
+	case <-time.After(w.drainTimeout):
+		w.logger.Warn("timed out waiting for in-flight jobs to complete, canceling them",
+			attributes.WorkerBusySlots, w.Stats().Busy,
+		)
+
+		cancelJobs()
+		<-done
+	}
+}
+
+// processJob claims the next available job from the queue and handles it
 func (w *Worker) processJob(ctx context.Context, link trace.Link) error {
 	// Start a new root span linked to the parent trace
 	tracer := otel.Tracer("")
 	ctx, span := tracer.Start(ctx, "worker.run", trace.WithLinks(link), trace.WithNewRoot())
+	stats := w.Stats()
 	span.SetAttributes(
 		attribute.String(attributes.WorkerID, w.id),
+		attribute.Int(attributes.WorkerBusySlots, stats.Busy),
+		attribute.Int(attributes.WorkerIdleSlots, stats.Idle),
 	)
 	defer span.End()
 
-	// Within a transaction: query for available jobs, get an exclusive lock on the job, and work on it
-	tx := w.db.Begin()
-	if tx.Error != nil {
-		w.logger.Debug("failed to begin db transaction", "error", tx.Error)
-		return tx.Error
-	}
-
-	childCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
-	defer cancel()
-
-	job := new(models.Job)
+	logger := o11y.LoggerWithTrace(ctx, w.logger)
 
-	// GetNextJob() is a custom Postgres function. Refer to SQL migration files
-	result := tx.WithContext(childCtx).Raw("SELECT * FROM GetNextJob()").First(&job)
-
-	if result.Error != nil {
-		tx.Rollback()
-
-		if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			w.logger.Error("failed to query for available jobs", "error", result.Error)
+	// Claim the next available job with a lease. The job is executed
+	// outside of a transaction while the lease is held by this worker.
+	job, err := w.claimJob(ctx)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("failed to query for available jobs", "error", err)
 		}
 
-		return result.Error
+		return err
 	}
 
 	span.SetAttributes(
@@ -169,6 +303,26 @@ func (w *Worker) processJob(ctx context.Context, link trace.Link) error {
 		attribute.Int(attributes.JobPriority, job.Priority),
 	)
 
+	// A job whose lease has repeatedly expired is likely causing
+	// the worker executing it to die, so it must not be retried
+	if job.Attempts >= models.JobMaxAttempts(job.JobType) {
+		logger.Warn("job has been attempted too many times",
+			attributes.JobID, job.JobID.String(),
+			attributes.JobType, job.JobType.String(),
+			attributes.JobAttempts, job.Attempts,
+		)
+
+		job.Status = models.JobStatusFailed
+		job.IsCompleted = true
+		w.completeJob(ctx, job, nil) //nolint:errcheck
+
+		span.SetAttributes(
+			attribute.String(attributes.JobStatus, job.Status.String()),
+		)
+
+		return errors.New(job.LastError)
+	}
+
 	// Since almost all jobs require the Slack channel to exist in the database,
 	// perform that check here instead of within each job. If the Slack channel
 	// does not exist, mark the job as canceled and skip it.
@@ -184,12 +338,12 @@ func (w *Worker) processJob(ctx context.Context, link trace.Link) error {
 				message = "failed to extract Slack channel ID from job data"
 			}
 
-			w.logger.Warn(message, "error", err)
+			logger.Warn(message, "error", err)
 
 			job.Status = models.JobStatusFailed
 			job.IsCompleted = true
-			tx.WithContext(ctx).Save(&job)
-			tx.Commit()
+			job.LastError = message
+			w.completeJob(ctx, job, nil) //nolint:errcheck
 
 			span.SetAttributes(
 				attribute.String(attributes.JobStatus, job.Status.String()),
@@ -202,19 +356,19 @@ func (w *Worker) processJob(ctx context.Context, link trace.Link) error {
 		dbCtx, cancel := context.WithTimeout(ctx, 250*time.Millisecond)
 		defer cancel()
 
-		result := tx.WithContext(dbCtx).
+		result := w.db.WithContext(dbCtx).
 			Model(&models.Channel{}).
 			Select("channel_id").
 			Where("channel_id = ?", p.ChannelID).
 			First(&models.Channel{})
 
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			w.logger.Warn("Slack channel does not exist in the database")
+			logger.Warn("Slack channel does not exist in the database")
 
 			job.Status = models.JobStatusCanceled
 			job.IsCompleted = true
-			tx.WithContext(ctx).Save(&job)
-			tx.Commit()
+			job.LastError = "Slack channel does not exist in the database"
+			w.completeJob(ctx, job, nil) //nolint:errcheck
 
 			span.SetAttributes(
 				attribute.String("job_status", job.Status.String()),
@@ -225,25 +379,77 @@ func (w *Worker) processJob(ctx context.Context, link trace.Link) error {
 	}
 
 	// Execute the job
-	w.logger.Info(
+	logger.Info(
 		"executing job",
 		attributes.JobID, job.JobID.String(),
 		attributes.JobType, job.JobType.String(),
 	)
 
-	if err := w.execJob(ctx, job, tx); err != nil {
-		w.logger.Error("failed to execute job",
+	// The lease is extended for as long as the job is executing. If the lease
+	// is lost, another worker may claim the job, so execution is canceled.
+	execCtx, cancelExec := context.WithCancel(ctx)
+	stopHeartbeat := w.heartbeat(execCtx, job, cancelExec)
+
+	start := time.Now()
+	err = w.execJob(execCtx, job, w.db)
+
+	jobDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		attribute.String(attributes.JobType, job.JobType.String()),
+	))
+
+	stopHeartbeat()
+	cancelExec()
+
+	// Jobs that were rate limited by the Slack API are rescheduled
+	// without counting the attempt against the maximum attempts
+	if retryAfter, ok := throttledRetryAfter(err); ok {
+		nextRetryAt := time.Now().UTC().Add(retryAfter)
+		job.NextRetryAt = &nextRetryAt
+		job.LastError = err.Error()
+
+		logger.Warn("rescheduled job that was rate limited by Slack",
+			attributes.JobID, job.JobID.String(),
+			attributes.JobType, job.JobType.String(),
+			"next_retry_at", nextRetryAt,
+		)
+
+		w.completeJob(ctx, job, nil) //nolint:errcheck
+
+		span.SetAttributes(
+			attribute.String("job_status", models.JobStatusPending.String()),
+		)
+
+		return err
+	}
+
+	if err != nil {
+		logger.Error("failed to execute job",
 			"error", err,
 			attributes.JobID, job.JobID.String(),
 			attributes.JobType, job.JobType.String(),
 		)
 
-		// If retrying the job will not make it succeed, then it should be marked as failed and not retried
-		if errors.Is(err, models.ErrJobParamsFailedValidation) {
+		job.Attempts++
+		job.LastError = err.Error()
+
+		span.SetAttributes(
+			attribute.Int(attributes.JobAttempts, job.Attempts),
+		)
+
+		// Record the failed attempt in the job's attempt history
+		attempt := &models.JobAttempt{
+			JobID:   job.ID,
+			Attempt: job.Attempts,
+			Replay:  job.Replays,
+			Error:   job.LastError,
+		}
+
+		// If retrying the job will not make it succeed or it has been attempted
+		// too many times, then it should be marked as failed and not retried
+		if errors.Is(err, models.ErrJobParamsFailedValidation) || errors.Is(err, slackclient.ErrNotInstalled) || job.Attempts >= models.JobMaxAttempts(job.JobType) {
 			job.Status = models.JobStatusFailed
 			job.IsCompleted = true
-			tx.WithContext(ctx).Save(&job)
-			tx.Commit()
+			w.completeJob(ctx, job, attempt) //nolint:errcheck
 
 			span.SetAttributes(
 				attribute.String("job_status", models.JobStatusFailed.String()),
@@ -252,18 +458,31 @@ func (w *Worker) processJob(ctx context.Context, link trace.Link) error {
 			return err
 		}
 
+		// Otherwise, schedule the job to be retried with exponential backoff
+		nextRetryAt := time.Now().UTC().Add(retryBackoff(job.Attempts))
+		job.NextRetryAt = &nextRetryAt
+
+		logger.Info("scheduled job to be retried",
+			attributes.JobID, job.JobID.String(),
+			attributes.JobType, job.JobType.String(),
+			attributes.JobAttempts, job.Attempts,
+			"next_retry_at", nextRetryAt,
+		)
+
+		w.completeJob(ctx, job, attempt) //nolint:errcheck
+
 		span.SetAttributes(
 			attribute.String("job_status", models.JobStatusErrored.String()),
 		)
 
-		tx.Rollback()
 		return err
 	}
 
 	job.Status = models.JobStatusSucceeded
 	job.IsCompleted = true
-	tx.WithContext(ctx).Save(&job)
-	tx.Commit()
+	if err := w.completeJob(ctx, job, nil); err != nil {
+		return err
+	}
 
 	span.SetAttributes(
 		attribute.String("job_status", job.Status.String()),
@@ -273,7 +492,7 @@ func (w *Worker) processJob(ctx context.Context, link trace.Link) error {
 }
 
 // execJob executes the job
-func (w *Worker) execJob(ctx context.Context, job *models.Job, tx *gorm.DB) error {
+func (w *Worker) execJob(ctx context.Context, job *models.Job, db *gorm.DB) error {
 	// Start a new span
 	ctx, span := otel.Tracer("").Start(ctx, "worker.exec")
 	span.SetAttributes(
@@ -285,83 +504,100 @@ func (w *Worker) execJob(ctx context.Context, job *models.Job, tx *gorm.DB) erro
 
 	defer span.End()
 
+	// Jobs are executed with the Slack client of the workspace they were queued for
+	ctx = bot.ContextWithTeamID(ctx, job.TeamID)
+	ctx = bot.ContextWithEnterpriseID(ctx, job.EnterpriseID)
+	ctx = bot.ContextWithAppURL(ctx, w.appURL)
+
+	var slackClient *slack.Client
+	if w.slackClients != nil {
+		var err error
+		if slackClient, err = w.slackClients.Client(ctx, job.EnterpriseID, job.TeamID); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	}
+
 	var err error
 
 	switch job.JobType {
 	case models.JobTypeAddChannel:
-		err = bot.ExecJob(ctx, tx, nil, job, bot.AddChannel)
+		err = bot.ExecJob(ctx, db, nil, job, bot.AddChannel)
 
 	case models.JobTypeSyncChannels:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.SyncChannels)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.SyncChannels)
 
 	case models.JobTypeUpdateChannel:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.UpdateChannel)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.UpdateChannel)
 
 	case models.JobTypeDeleteChannel:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.DeleteChannel)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.DeleteChannel)
 
 	case models.JobTypeGreetAdmin:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.GreetAdmin)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.GreetAdmin)
 
 	case models.JobTypeSyncMembers:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.SyncMembers)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.SyncMembers)
 
 	case models.JobTypeAddMember:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.AddMember)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.AddMember)
 
 	case models.JobTypeUpdateMember:
-		err = bot.ExecJob(ctx, tx, nil, job, bot.UpdateMember)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.UpdateMember)
 
 	case models.JobTypeDeleteMember:
-		err = bot.ExecJob(ctx, tx, nil, job, bot.DeleteMember)
+		err = bot.ExecJob(ctx, db, nil, job, bot.DeleteMember)
 
 	case models.JobTypeGreetMember:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.GreetMember)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.GreetMember)
 
 	case models.JobTypeCreateRound:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.CreateRound)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.CreateRound)
 
 	case models.JobTypeEndRound:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.EndRound)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.EndRound)
 
 	case models.JobTypeCreateMatches:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.CreateMatches)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.CreateMatches)
 
 	case models.JobTypeCreateMatch:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.CreateMatch)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.CreateMatch)
 
 	case models.JobTypeUpdateMatch:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.UpdateMatch)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.UpdateMatch)
 
 	case models.JobTypeCreatePair:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.CreatePair)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.CreatePair)
 
 	case models.JobTypeNotifyPair:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.NotifyPair)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.NotifyPair)
 
 	case models.JobTypeKickoffPair:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.KickoffPair)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.KickoffPair)
 
 	case models.JobTypeNotifyMember:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.NotifyMember)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.NotifyMember)
 
 	case models.JobTypeCheckPair:
-		err = bot.ExecJob(ctx, nil, w.slackClient, job, bot.CheckPair)
+		err = bot.ExecJob(ctx, nil, slackClient, job, bot.CheckPair)
 
 	case models.JobTypeReportMatches:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.ReportMatches)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.ReportMatches)
 
 	case models.JobTypeReportStats:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.ReportStats)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.ReportStats)
 
 	case models.JobTypeMarkInactive:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.MarkInactive)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.MarkInactive)
 
 	case models.JobTypeBlockMember:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.BlockMember)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.BlockMember)
 
 	case models.JobTypeUnblockMember:
-		err = bot.ExecJob(ctx, tx, w.slackClient, job, bot.UnblockMember)
+		err = bot.ExecJob(ctx, db, slackClient, job, bot.UnblockMember)
+
+	case models.JobTypeReconcileSchedules:
+		err = bot.ExecJob(ctx, db, nil, job, bot.ReconcileSchedules)
 
 	default:
 		err = fmt.Errorf("invalid job type")
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"sync"
 	"testing"
 	"time"
 
@@ -33,6 +34,98 @@ func Test_execJob(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func Test_Stats(t *testing.T) {
+	w := Worker{concurrency: 4}
+	w.busy.Add(1)
+
+	assert.Equal(t, Stats{Slots: 4, Busy: 1, Idle: 3}, w.Stats())
+}
+
+func Test_Ready(t *testing.T) {
+	db, mock := database.NewMockedGormDB()
+	mock.ExpectPing()
+
+	shutdownCh := make(chan bool)
+
+	w := &Worker{
+		db:         db,
+		shutdownCh: shutdownCh,
+	}
+
+	assert.ErrorIs(t, w.Ready(context.Background()), errNotStarted)
+
+	w.started.Store(true)
+	assert.NoError(t, w.Ready(context.Background()))
+
+	close(shutdownCh)
+	assert.ErrorIs(t, w.Ready(context.Background()), errShuttingDown)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_drain(t *testing.T) {
+	t.Run("completed", func(t *testing.T) {
+		logger, buffer := o11y.NewBufferedLogger()
+		shutdownCh := make(chan bool)
+
+		w := &Worker{
+			logger:       logger,
+			drainTimeout: time.Second,
+			shutdownCh:   shutdownCh,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// Simulate an in-flight job that completes shortly after shutdown
+		slots := new(sync.WaitGroup)
+		slots.Add(1)
+		go func() {
+			defer slots.Done()
+			<-shutdownCh
+			time.Sleep(10 * time.Millisecond)
+		}()
+
+		wg := new(sync.WaitGroup)
+		wg.Add(1)
+		go w.drain(wg, slots, cancel)
+
+		close(shutdownCh)
+		wg.Wait()
+
+		assert.Contains(t, buffer.String(), "gracefully stopped Worker")
+		assert.Error(t, ctx.Err())
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		logger, buffer := o11y.NewBufferedLogger()
+		shutdownCh := make(chan bool)
+
+		w := &Worker{
+			logger:       logger,
+			drainTimeout: 10 * time.Millisecond,
+			shutdownCh:   shutdownCh,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// Simulate an in-flight job that only stops once it is canceled
+		slots := new(sync.WaitGroup)
+		slots.Add(1)
+		go func() {
+			defer slots.Done()
+			<-ctx.Done()
+		}()
+
+		wg := new(sync.WaitGroup)
+		wg.Add(1)
+		go w.drain(wg, slots, cancel)
+
+		close(shutdownCh)
+		wg.Wait()
+
+		assert.Contains(t, buffer.String(), "timed out waiting for in-flight jobs to complete")
+	})
+}
+
 type ProcessJobTestSuite struct {
 	suite.Suite
 	worker   Worker
@@ -140,6 +233,171 @@ func (s *ProcessJobTestSuite) Test_Failed_ValidationError() {
 	r.True(job.IsCompleted)
 }
 
+func (s *ProcessJobTestSuite) Test_Errored_Retry() {
+	r := require.New(s.T())
+
+	p := bot.AddChannelParams{
+		ChannelID: "C0123456789",
+		Interval:  "fortnightly",
+	}
+
+	data, _ := json.Marshal(p)
+	job := models.NewJob(models.JobTypeAddChannel, data)
+	s.db.Save(&job)
+
+	err := s.worker.processJob(s.ctx, trace.Link{})
+	r.Error(err)
+
+	// Verify the failed attempt was recorded and the job will be retried later
+	r.Contains(s.buffer.String(), "scheduled job to be retried")
+	result := s.db.First(&job)
+	r.NoError(result.Error)
+	r.Equal(models.JobStatusPending, job.Status)
+	r.False(job.IsCompleted)
+	r.Equal(1, job.Attempts)
+	r.Contains(job.LastError, "fortnightly")
+	r.NotNil(job.NextRetryAt)
+	r.True(job.NextRetryAt.After(time.Now().UTC()))
+
+	var history []models.JobAttempt
+	result = s.db.Where("job_id = ?", job.ID).Find(&history)
+	r.NoError(result.Error)
+	r.Len(history, 1)
+	r.Equal(1, history[0].Attempt)
+	r.Equal(job.LastError, history[0].Error)
+
+	// The job is not available again until its next retry
+	err = s.worker.processJob(s.ctx, trace.Link{})
+	r.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *ProcessJobTestSuite) Test_Failed_MaxAttempts() {
+	r := require.New(s.T())
+
+	p := bot.AddChannelParams{
+		ChannelID: "C0123456789",
+		Interval:  "fortnightly",
+	}
+
+	data, _ := json.Marshal(p)
+	job := models.NewJob(models.JobTypeAddChannel, data)
+	s.db.Save(&job)
+
+	// Simulate a job that has one attempt remaining
+	s.db.Model(&job).Update("attempts", models.JobMaxAttempts(models.JobTypeAddChannel)-1)
+
+	err := s.worker.processJob(s.ctx, trace.Link{})
+	r.Error(err)
+
+	// Verify job was marked as failed with the error recorded
+	result := s.db.First(&job)
+	r.NoError(result.Error)
+	r.Equal(models.JobStatusFailed, job.Status)
+	r.True(job.IsCompleted)
+	r.Contains(job.LastError, "fortnightly")
+}
+
+func (s *ProcessJobTestSuite) Test_Leased() {
+	r := require.New(s.T())
+
+	data, _ := json.Marshal(bot.AddChannelParams{ChannelID: "C0123456789"})
+	job := models.NewJob(models.JobTypeAddChannel, data)
+	s.db.Save(&job)
+
+	// Simulate a job that is being executed by another worker
+	s.db.Model(&job).Updates(map[string]interface{}{
+		"locked_by":    "other",
+		"locked_until": time.Now().UTC().Add(time.Minute),
+	})
+
+	err := s.worker.processJob(s.ctx, trace.Link{})
+	r.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *ProcessJobTestSuite) Test_PendingParent() {
+	r := require.New(s.T())
+
+	parent := models.NewJob(models.JobTypeAddChannel, []byte(`{"channel_id":"C0123456789"}`))
+	parent.ExecAt = time.Now().UTC().Add(time.Hour)
+	s.db.Save(&parent)
+
+	data, _ := json.Marshal(bot.AddChannelParams{ChannelID: "C0123456789"})
+	job := models.NewJob(models.JobTypeAddChannel, data)
+	job.ParentIDs = []int32{parent.ID}
+	s.db.Save(&job)
+
+	// The job is not available until its parent has succeeded
+	err := s.worker.processJob(s.ctx, trace.Link{})
+	r.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	s.db.Model(&parent).Updates(map[string]interface{}{
+		"status":       models.JobStatusSucceeded,
+		"is_completed": true,
+	})
+
+	err = s.worker.processJob(s.ctx, trace.Link{})
+	r.NotErrorIs(err, gorm.ErrRecordNotFound)
+
+	result := s.db.First(&job)
+	r.NoError(result.Error)
+	r.NotEqual(models.JobStatusPending, job.Status)
+}
+
+func (s *ProcessJobTestSuite) Test_FailedParent() {
+	r := require.New(s.T())
+
+	parent := models.NewJob(models.JobTypeAddChannel, []byte(`{"channel_id":"C0123456789"}`))
+	parent.ExecAt = time.Now().UTC().Add(time.Hour)
+	s.db.Save(&parent)
+
+	data, _ := json.Marshal(bot.AddChannelParams{ChannelID: "C0123456789"})
+	job := models.NewJob(models.JobTypeAddChannel, data)
+	job.ParentIDs = []int32{parent.ID}
+	s.db.Save(&job)
+
+	s.db.Model(&parent).Updates(map[string]interface{}{
+		"status":       models.JobStatusFailed,
+		"is_completed": true,
+	})
+
+	// The job is canceled instead of being executed without its parent
+	err := s.worker.processJob(s.ctx, trace.Link{})
+	r.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	result := s.db.First(&job)
+	r.NoError(result.Error)
+	r.Equal(models.JobStatusCanceled, job.Status)
+	r.True(job.IsCompleted)
+}
+
+func (s *ProcessJobTestSuite) Test_ExpiredLease() {
+	r := require.New(s.T())
+
+	data, _ := json.Marshal(bot.AddChannelParams{ChannelID: "C0123456789"})
+	job := models.NewJob(models.JobTypeAddChannel, data)
+	s.db.Save(&job)
+
+	// Simulate a job whose worker died while executing it for the last time
+	s.db.Model(&job).Updates(map[string]interface{}{
+		"attempts":     models.JobMaxAttempts(models.JobTypeAddChannel) - 1,
+		"locked_by":    "dead",
+		"locked_until": time.Now().UTC().Add(-time.Minute),
+	})
+
+	err := s.worker.processJob(s.ctx, trace.Link{})
+	r.Error(err)
+
+	// Verify the expired lease was counted as an attempt and the job was marked as failed
+	result := s.db.First(&job)
+	r.NoError(result.Error)
+	r.Equal(models.JobStatusFailed, job.Status)
+	r.True(job.IsCompleted)
+	r.Equal(models.JobMaxAttempts(models.JobTypeAddChannel), job.Attempts)
+	r.Contains(job.LastError, "lease expired")
+	r.Nil(job.LockedBy)
+	r.Nil(job.LockedUntil)
+}
+
 func (s *ProcessJobTestSuite) Test_Canceled() {
 	r := require.New(s.T())
 